@@ -0,0 +1,74 @@
+package uax29
+
+import (
+	"bufio"
+
+	"github.com/clipperhouse/uax29/graphemes"
+	"github.com/clipperhouse/uax29/sentences"
+	"github.com/clipperhouse/uax29/words"
+)
+
+// Boundaries are the grapheme, word and sentence boundaries of a text, as
+// byte indices. For each, the first boundary is 0 and the last is the length
+// of the text, such that the i'th token is text[b[i]:b[i+1]].
+type Boundaries struct {
+	Graphemes []int
+	Words     []int
+	Sentences []int
+}
+
+// Analyze returns the grapheme, word and sentence boundaries of data.
+// Boundaries are identical to those of the graphemes, words and sentences
+// packages. For empty data, all boundaries are nil.
+//
+// It is not a single pass: it runs the three packages' segmentations, each
+// with its own trie and rules, interleaved, so that each advances when it is
+// furthest behind. Each segmentation reads the bytes for itself, so the work
+// is that of calling the three packages separately; see BenchmarkAnalyze.
+func Analyze(data []byte) Boundaries {
+	var b Boundaries
+	if len(data) == 0 {
+		return b
+	}
+
+	// The slices grow by append, rather than being sized for the worst case,
+	// which for graphemes would be 8 bytes of int per byte of data
+	b.Graphemes = []int{0}
+	b.Words = []int{0}
+	b.Sentences = []int{0}
+
+	cursors := [3]struct {
+		split bufio.SplitFunc
+		dest  *[]int
+	}{
+		{graphemes.SplitFunc, &b.Graphemes},
+		{words.SplitFunc, &b.Words},
+		{sentences.SplitFunc, &b.Sentences},
+	}
+
+	for {
+		// Find the cursor which is furthest behind
+		c := -1
+		pos := len(data)
+		for i := range cursors {
+			dest := *cursors[i].dest
+			if last := dest[len(dest)-1]; last < pos {
+				c, pos = i, last
+			}
+		}
+
+		if c < 0 {
+			// All are at the end
+			break
+		}
+
+		advance, _, _ := cursors[c].split(data[pos:], true) // can elide the error, see tests
+		if advance <= 0 {
+			// Interpret as EOF, which should not happen; be sure to terminate
+			advance = len(data) - pos
+		}
+		*cursors[c].dest = append(*cursors[c].dest, pos+advance)
+	}
+
+	return b
+}
@@ -0,0 +1,114 @@
+package uax29_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29"
+	"github.com/clipperhouse/uax29/graphemes"
+	"github.com/clipperhouse/uax29/sentences"
+	"github.com/clipperhouse/uax29/words"
+)
+
+// boundaries returns the boundaries of data, by iterating split
+func boundaries(data []byte, split bufio.SplitFunc) []int {
+	if len(data) == 0 {
+		return nil
+	}
+	result := []int{0}
+	for pos := 0; pos < len(data); {
+		advance, _, err := split(data[pos:], true)
+		if err != nil || advance == 0 {
+			break
+		}
+		pos += advance
+		result = append(result, pos)
+	}
+	return result
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	random := make([]byte, 50000)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := [][]byte{file, random, []byte("Hello, 世界. Nice dog! 👍🐶"), nil}
+
+	for _, input := range inputs {
+		got := uax29.Analyze(input)
+
+		if !reflect.DeepEqual(got.Graphemes, boundaries(input, graphemes.SplitFunc)) {
+			t.Fatal("grapheme boundaries should be identical to graphemes.SplitFunc")
+		}
+		if !reflect.DeepEqual(got.Words, boundaries(input, words.SplitFunc)) {
+			t.Fatal("word boundaries should be identical to words.SplitFunc")
+		}
+		if !reflect.DeepEqual(got.Sentences, boundaries(input, sentences.SplitFunc)) {
+			t.Fatal("sentence boundaries should be identical to sentences.SplitFunc")
+		}
+	}
+}
+
+// BenchmarkAnalyze compares Analyze with running the three packages
+// separately, one after another, over a small text and one which is too
+// large to stay in cache
+func BenchmarkAnalyze(b *testing.B) {
+	file, err := os.ReadFile("testdata/sample.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	inputs := []struct {
+		name string
+		data []byte
+	}{
+		{"sample", file},
+		{"large", bytes.Repeat(file, 100)},
+	}
+
+	for _, input := range inputs {
+		data := input.data
+
+		b.Run(input.name+"/Analyze", func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				_ = uax29.Analyze(data)
+			}
+		})
+
+		b.Run(input.name+"/separate", func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				var result uax29.Boundaries
+				result.Graphemes = ends(graphemes.NewSegmenter(data))
+				result.Words = ends(words.NewSegmenter(data))
+				result.Sentences = ends(sentences.NewSegmenter(data))
+				_ = result
+			}
+		})
+	}
+}
+
+// ends returns the boundaries of the tokens of seg, as Analyze does
+func ends(seg interface {
+	Next() bool
+	End() int
+}) []int {
+	result := []int{0}
+	for seg.Next() {
+		result = append(result, seg.End())
+	}
+	return result
+}
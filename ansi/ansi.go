@@ -0,0 +1,219 @@
+// Package ansi parses ANSI escape sequences (per ECMA-48), such as those used
+// for colors and cursor movement in terminals. It is the same parser used by
+// graphemes.ANSISplitFunc, so that stripping or otherwise handling escape
+// sequences will agree with segmentation.
+package ansi
+
+const (
+	esc = 0x1B
+	bel = 0x07
+)
+
+// DefaultMaxLength is the default maximum length, in bytes, of an escape
+// sequence. Some sequences (such as OSC and DCS) are terminated by a string
+// terminator, and may be arbitrarily long; see [Parse].
+const DefaultMaxLength = 4096
+
+// Kind is the kind of an escape sequence, determined by the byte following ESC.
+type Kind uint8
+
+const (
+	// None indicates that the data is not an escape sequence
+	None Kind = iota
+	// CSI is a control sequence, such as ESC [ 31 m
+	CSI
+	// OSC is an operating system command, terminated by BEL or ST
+	OSC
+	// DCS is a device control string, terminated by ST
+	DCS
+	// SOS is a start of string, terminated by ST
+	SOS
+	// PM is a privacy message, terminated by ST
+	PM
+	// APC is an application program command, terminated by ST
+	APC
+	// Escape is any other escape sequence, such as ESC 7 or ESC ( B
+	Escape
+)
+
+var kindNames = [...]string{"None", "CSI", "OSC", "DCS", "SOS", "PM", "APC", "Escape"}
+
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "Unknown"
+}
+
+// SequenceLength returns the length, in bytes, of the complete escape sequence
+// at the start of data. It returns 0 if data does not start with a complete,
+// valid escape sequence, or if the sequence is longer than DefaultMaxLength.
+func SequenceLength(data []byte) int {
+	n, _, _ := Parse(data, DefaultMaxLength, true)
+	return n
+}
+
+// Classify returns the Kind of the complete escape sequence at the start of
+// data. It returns None if data does not start with a complete, valid escape
+// sequence, or if the sequence is longer than DefaultMaxLength.
+func Classify(data []byte) Kind {
+	_, kind, _ := Parse(data, DefaultMaxLength, true)
+	return kind
+}
+
+// Parse returns the length, in bytes, and the Kind, of the escape sequence
+// at the start of data. It returns 0 and None if data does not start with a
+// complete, valid escape sequence, or if the sequence is longer than max.
+//
+// If the sequence might be completed by more data, and atEOF is false, it
+// returns more = true; this is intended for use in a bufio.SplitFunc. (If
+// there are already max bytes, more data will not help, so more is false.)
+//
+// A limit is important for untrusted input. Without one, many unterminated
+// sequences would each be scanned to the end of the data, which is O(n^2).
+// With a limit, the worst case is O(n * max).
+func Parse(data []byte, max int, atEOF bool) (n int, kind Kind, more bool) {
+	if len(data) == 0 || data[0] != esc {
+		return 0, None, false
+	}
+
+	capped := len(data) >= max
+	if capped {
+		data = data[:max]
+	}
+
+	// incomplete is the result when we reach the end of data
+	incomplete := !atEOF && !capped
+
+	if len(data) < 2 {
+		return 0, None, incomplete
+	}
+
+	switch data[1] {
+	case '[':
+		// Parameter bytes, intermediate bytes, then a final byte
+		i := 2
+		for i < len(data) && data[i] >= 0x30 && data[i] <= 0x3F {
+			i++
+		}
+		for i < len(data) && data[i] >= 0x20 && data[i] <= 0x2F {
+			i++
+		}
+		if i == len(data) {
+			return 0, None, incomplete
+		}
+		if data[i] >= 0x40 && data[i] <= 0x7E {
+			return i + 1, CSI, false
+		}
+		return 0, None, false
+	case ']':
+		// Terminated by BEL or ST
+		for i := 2; i < len(data); i++ {
+			if data[i] == bel {
+				return i + 1, OSC, false
+			}
+			if data[i] == esc && i+1 < len(data) && data[i+1] == '\\' {
+				return i + 2, OSC, false
+			}
+		}
+		return 0, None, incomplete
+	case 'P', 'X', '^', '_':
+		// Terminated by ST
+		for i := 2; i < len(data); i++ {
+			if data[i] == esc && i+1 < len(data) && data[i+1] == '\\' {
+				return i + 2, stringKinds[data[1]], false
+			}
+		}
+		return 0, None, incomplete
+	default:
+		// Intermediate bytes, then a final byte
+		i := 1
+		for i < len(data) && data[i] >= 0x20 && data[i] <= 0x2F {
+			i++
+		}
+		if i == len(data) {
+			return 0, None, incomplete
+		}
+		if data[i] >= 0x30 && data[i] <= 0x7E {
+			return i + 1, Escape, false
+		}
+		return 0, None, false
+	}
+}
+
+// stringKinds are the kinds of sequences which are terminated by ST
+var stringKinds = map[byte]Kind{
+	'P': DCS,
+	'X': SOS,
+	'^': PM,
+	'_': APC,
+}
+
+// Payload returns the bounds of the text payload of a complete OSC or DCS
+// sequence, such as the title in ESC ] 0 ; title BEL. The introducer (ESC,
+// the kind, and any parameters) is seq[:start], and the terminator (BEL or ST)
+// is seq[end:]. For other kinds of sequence, or if seq is not a complete
+// sequence, it returns 0, 0.
+func Payload(seq []byte) (start, end int) {
+	n, kind, _ := Parse(seq, len(seq)+1, true)
+	if n == 0 || n != len(seq) {
+		return 0, 0
+	}
+
+	end = n - 2 // ST
+	if seq[n-1] == bel {
+		end = n - 1
+	}
+
+	switch kind {
+	case OSC, DCS:
+		return introducer(seq[:end], kind), end
+	}
+	return 0, 0
+}
+
+// IsIntroducer determines if token is the introducer of an OSC or DCS
+// sequence (ESC, the kind, and any parameters), such as ESC ] 0 ;. A
+// segmenter which segments payloads returns it as a token of its own, see
+// Payload.
+func IsIntroducer(token []byte) bool {
+	if len(token) < 2 || token[0] != esc {
+		return false
+	}
+
+	switch token[1] {
+	case ']':
+		return introducer(token, OSC) == len(token)
+	case 'P':
+		return introducer(token, DCS) == len(token)
+	}
+	return false
+}
+
+// introducer returns the length of the introducer at the start of seq, an OSC
+// or DCS sequence without its terminator
+func introducer(seq []byte, kind Kind) int {
+	i := 2
+	switch kind {
+	case OSC:
+		// Numeric parameter followed by ;
+		for i < len(seq) && seq[i] >= '0' && seq[i] <= '9' {
+			i++
+		}
+		if i < len(seq) && seq[i] == ';' {
+			return i + 1
+		}
+	case DCS:
+		// Parameter bytes, intermediate bytes, then a final byte
+		for i < len(seq) && seq[i] >= 0x30 && seq[i] <= 0x3F {
+			i++
+		}
+		for i < len(seq) && seq[i] >= 0x20 && seq[i] <= 0x2F {
+			i++
+		}
+		if i < len(seq) && seq[i] >= 0x40 && seq[i] <= 0x7E {
+			return i + 1
+		}
+	}
+	return 2
+}
@@ -0,0 +1,140 @@
+package ansi_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/ansi"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input  string
+		atEOF  bool
+		length int
+		kind   ansi.Kind
+		more   bool
+	}
+
+	tests := []test{
+		{"\x1b[31mHi", true, 5, ansi.CSI, false},
+		{"\x1b[1;38;5;208m", true, 13, ansi.CSI, false},
+		{"\x1b[ q", true, 4, ansi.CSI, false},
+		{"\x1b]8;;https://example.com\x07link", true, 25, ansi.OSC, false},
+		{"\x1b]0;title\x1b\\", true, 11, ansi.OSC, false},
+		{"\x1bPq#0\x1b\\", true, 7, ansi.DCS, false},
+		{"\x1bXsos\x1b\\", true, 7, ansi.SOS, false},
+		{"\x1b^pm\x1b\\", true, 6, ansi.PM, false},
+		{"\x1b_apc\x1b\\", true, 7, ansi.APC, false},
+		{"\x1b(B", true, 3, ansi.Escape, false},
+		{"\x1b7", true, 2, ansi.Escape, false},
+		{"hello", true, 0, ansi.None, false},
+		{"", true, 0, ansi.None, false},
+		{"\x1b[3\x01", true, 0, ansi.None, false},
+		// incomplete
+		{"\x1b", true, 0, ansi.None, false},
+		{"\x1b", false, 0, ansi.None, true},
+		{"\x1b[31", true, 0, ansi.None, false},
+		{"\x1b[31", false, 0, ansi.None, true},
+		{"\x1b]0;title\x1b", false, 0, ansi.None, true},
+		{"\x1bPq", false, 0, ansi.None, true},
+		{"\x1b(", false, 0, ansi.None, true},
+	}
+
+	for _, test := range tests {
+		length, kind, more := ansi.Parse([]byte(test.input), ansi.DefaultMaxLength, test.atEOF)
+		if length != test.length || kind != test.kind || more != test.more {
+			t.Fatalf("for %q (atEOF %t), expected (%d, %s, %t), got (%d, %s, %t)",
+				test.input, test.atEOF, test.length, test.kind, test.more, length, kind, more)
+		}
+
+		if test.atEOF {
+			if got := ansi.SequenceLength([]byte(test.input)); got != test.length {
+				t.Fatalf("for %q, expected SequenceLength %d, got %d", test.input, test.length, got)
+			}
+			if got := ansi.Classify([]byte(test.input)); got != test.kind {
+				t.Fatalf("for %q, expected Classify %s, got %s", test.input, test.kind, got)
+			}
+		}
+	}
+}
+
+func TestParseMax(t *testing.T) {
+	t.Parallel()
+
+	seq := []byte("\x1b]0;title\x07")
+
+	if n, _, _ := ansi.Parse(seq, len(seq), true); n != len(seq) {
+		t.Fatalf("sequence within max should be parsed, got %d", n)
+	}
+
+	// more data will not help, so more should be false
+	if n, _, more := ansi.Parse(seq, len(seq)-1, false); n != 0 || more {
+		t.Fatalf("sequence beyond max should be abandoned, got %d, %t", n, more)
+	}
+}
+
+func TestPayload(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input   string
+		payload string
+	}
+
+	tests := []test{
+		{"\x1b]0;hello world\x07", "hello world"},
+		{"\x1b]2;title\x1b\\", "title"},
+		{"\x1b]title\x07", "title"},
+		{"\x1bP1$rdata\x1b\\", "data"},
+		{"\x1bPqsixel\x1b\\", "sixel"},
+		{"\x1b]0;\x07", ""},
+		{"\x1b[31m", ""},
+		{"\x1b]0;incomplete", ""},
+		{"plain", ""},
+	}
+
+	for _, test := range tests {
+		start, end := ansi.Payload([]byte(test.input))
+		got := test.input[start:end]
+		if got != test.payload {
+			t.Errorf("%q: expected payload %q, got %q", test.input, test.payload, got)
+		}
+	}
+}
+
+func TestIsIntroducer(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected bool
+	}
+
+	tests := []test{
+		{"\x1b]0;", true},
+		{"\x1b]", true},
+		{"\x1bP1$r", true},
+		{"\x1bPq", true},
+		{"\x1b]0", false},
+		{"\x1b]0;title", false},
+		{"\x1b[31m", false},
+		{"\x1b", false},
+		{"plain", false},
+	}
+
+	for _, test := range tests {
+		if got := ansi.IsIntroducer([]byte(test.input)); got != test.expected {
+			t.Errorf("%q: expected %t, got %t", test.input, test.expected, got)
+		}
+	}
+
+	// Introducers are those of Payload
+	for _, seq := range []string{"\x1b]0;hello world\x07", "\x1b]title\x07", "\x1bP1$rdata\x1b\\", "\x1bPqsixel\x1b\\"} {
+		start, _ := ansi.Payload([]byte(seq))
+		if !ansi.IsIntroducer([]byte(seq[:start])) {
+			t.Errorf("%q: expected %q to be an introducer", seq, seq[:start])
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package ansi
+
+import (
+	"bytes"
+
+	"golang.org/x/text/transform"
+)
+
+// Stripper is a transform.Transformer which removes escape sequences, as
+// recognized by Parse, leaving the plain text. For example, use it with
+// transform.NewReader to strip a stream, or with the Transform method of a
+// Segmenter or Scanner to strip each token.
+//
+// It records the position of each removed sequence, so that a position in
+// the plain text can be mapped back to the original, see [Stripper.Source].
+// Positions are relative to the most recent Reset, and the record grows
+// with the number of sequences. A Stripper is stateful, and not safe for
+// concurrent use.
+type Stripper struct {
+	// MaxLength is the maximum length, in bytes, of an escape sequence.
+	// Longer sequences are abandoned, and retained as text. If zero,
+	// DefaultMaxLength is used. See Parse.
+	MaxLength int
+
+	edits    []edit
+	src, dst int // the number of bytes consumed and written, across calls to Transform
+}
+
+// edit is a removal of bytes, at src in the source, and dst in the result
+type edit struct {
+	src, dst, removed int
+}
+
+// Reset implements transform.Transformer.
+func (s *Stripper) Reset() {
+	s.edits = s.edits[:0]
+	s.src, s.dst = 0, 0
+}
+
+// Transform implements transform.Transformer.
+func (s *Stripper) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	defer func() {
+		s.src += nSrc
+		s.dst += nDst
+	}()
+
+	max := DefaultMaxLength
+	if s.MaxLength > 0 {
+		max = s.MaxLength
+	}
+
+	for nSrc < len(src) {
+		if src[nSrc] == esc {
+			n, _, more := Parse(src[nSrc:], max, atEOF)
+			if more {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			if n > 0 {
+				s.edits = append(s.edits, edit{src: s.src + nSrc, dst: s.dst + nDst, removed: n})
+				nSrc += n
+				continue
+			}
+		}
+
+		if nDst >= len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		dst[nDst] = src[nSrc]
+		nDst++
+		nSrc++
+	}
+
+	return nDst, nSrc, nil
+}
+
+// Source maps pos, a position in the plain text, to the corresponding
+// position in the original. A position at which a sequence was removed maps
+// to the position following the sequence.
+func (s *Stripper) Source(pos int) int {
+	delta := 0
+	for _, e := range s.edits {
+		if pos < e.dst {
+			break
+		}
+		delta = e.src + e.removed - e.dst
+	}
+	return pos + delta
+}
+
+// Strip returns data with escape sequences removed, as by Stripper. If there
+// is no ESC, data is returned; otherwise, the result is newly allocated.
+func Strip(data []byte) []byte {
+	if bytes.IndexByte(data, esc) < 0 {
+		return data
+	}
+
+	var s Stripper
+	result, _, _ := transform.Bytes(&s, data) // can elide the error, see tests
+	return result
+}
@@ -0,0 +1,133 @@
+package ansi_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/clipperhouse/uax29/ansi"
+	"github.com/clipperhouse/uax29/graphemes"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"golang.org/x/text/transform"
+)
+
+var stripTests = []struct {
+	input, expected string
+}{
+	{"hello", "hello"},
+	{"", ""},
+	{"\x1b[1;31mred\x1b[0m text", "red text"},
+	{"\x1b]0;title\x07日本\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "日本link"},
+	{"a\x1bPdata\x1b\\b", "ab"},
+	{"a\x1b7b\x1b8c", "abc"},
+	{"\x1b[", "\x1b["}, // incomplete
+	{"a\x1b", "a\x1b"}, // lone ESC
+	{"\x1b]0;unterminated", "\x1b]0;unterminated"},
+}
+
+func TestStripper(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range stripTests {
+		s := &ansi.Stripper{}
+		got, _, err := transform.String(s, test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.input, test.expected, got)
+		}
+
+		if got := string(ansi.Strip([]byte(test.input))); got != test.expected {
+			t.Errorf("%q: Strip expected %q, got %q", test.input, test.expected, got)
+		}
+
+		// Source should map every byte of the result to the same byte of the input
+		for i := 0; i < len(got); i++ {
+			j := s.Source(i)
+			if got[i] != test.input[j] {
+				t.Errorf("%q: position %d maps to %d, %q != %q", test.input, i, j, got[i], test.input[j])
+			}
+		}
+		if s.Source(len(got)) != len(test.input) {
+			t.Errorf("%q: expected end to map to end", test.input)
+		}
+	}
+}
+
+func TestStripperMaxLength(t *testing.T) {
+	t.Parallel()
+
+	input := "a\x1b]0;a long title\x07b"
+
+	got, _, err := transform.String(&ansi.Stripper{MaxLength: 8}, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != input {
+		t.Errorf("expected a sequence longer than MaxLength to be retained, got %q", got)
+	}
+
+	got, _, err = transform.String(&ansi.Stripper{}, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "ab" {
+		t.Errorf("expected %q, got %q", "ab", got)
+	}
+}
+
+func TestStripperStreaming(t *testing.T) {
+	t.Parallel()
+
+	var input strings.Builder
+	for _, test := range stripTests {
+		input.WriteString(test.input)
+	}
+	// Concatenated, the incomplete tests might complete each other, so
+	// strip the whole as a reference
+	expected := string(ansi.Strip([]byte(input.String())))
+
+	// Sequences which span reads should be stripped
+	r := transform.NewReader(iotest.OneByteReader(strings.NewReader(input.String())), &ansi.Stripper{})
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+
+	// Long input, exercising ErrShortDst
+	long := strings.Repeat("ab\x1b[1mcd", 1000)
+	out, _, err := transform.String(&ansi.Stripper{}, long)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != strings.Repeat("abcd", 1000) {
+		t.Fatal("unexpected result for long input")
+	}
+}
+
+func TestStripperAgreesWithGraphemes(t *testing.T) {
+	t.Parallel()
+
+	// Stripping should be the same as removing the tokens which
+	// graphemes.ANSISplitFunc recognizes as escape sequences
+	for _, test := range stripTests {
+		seg := graphemes.NewSegmenter([]byte(test.input))
+		seg.Split(graphemes.ANSISplitFunc(0))
+		seg.Filter(filter.NoANSI)
+
+		var expected []byte
+		for seg.Next() {
+			expected = append(expected, seg.Bytes()...)
+		}
+
+		if got := ansi.Strip([]byte(test.input)); !bytes.Equal(got, expected) {
+			t.Errorf("%q: expected %q, got %q", test.input, expected, got)
+		}
+	}
+}
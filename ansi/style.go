@@ -0,0 +1,210 @@
+package ansi
+
+import "bytes"
+
+// ColorType is the type of a terminal Color.
+type ColorType uint8
+
+const (
+	// DefaultColor is the terminal's default color
+	DefaultColor ColorType = iota
+	// IndexedColor is a color from the terminal's palette, 0-255, where 0-7
+	// are the basic colors and 8-15 are their bright variants
+	IndexedColor
+	// RGBColor is a 24-bit "true" color
+	RGBColor
+)
+
+// Color is a terminal color. The zero value is the default color.
+type Color struct {
+	Type ColorType
+	// Index is the palette index, for IndexedColor
+	Index uint8
+	// R, G and B are the components, for RGBColor
+	R, G, B uint8
+}
+
+// Style is the state of graphic rendition (SGR) attributes, as set by escape
+// sequences such as ESC [ 1 ; 31 m. The zero value is the default style.
+type Style struct {
+	Bold          bool
+	Faint         bool
+	Italic        bool
+	Underline     bool
+	Blink         bool
+	Inverse       bool
+	Hidden        bool
+	Strikethrough bool
+	Foreground    Color
+	Background    Color
+}
+
+// Apply updates the Style with the escape sequence seq, if it is an SGR
+// sequence (a CSI sequence with the final byte m). It returns false, and the
+// Style is unchanged, if seq is not an SGR sequence. Unrecognized parameters
+// are ignored.
+func (s *Style) Apply(seq []byte) bool {
+	n, kind, _ := Parse(seq, len(seq)+1, true)
+	if kind != CSI || n != len(seq) || seq[n-1] != 'm' {
+		return false
+	}
+
+	params := seq[2 : n-1]
+	if len(params) == 0 {
+		// ESC [ m is equivalent to ESC [ 0 m
+		*s = Style{}
+		return true
+	}
+
+	fields := bytes.Split(params, []byte(";"))
+	for i := 0; i < len(fields); i++ {
+		// Sub-parameters, as in 38:2::255:0:0
+		if bytes.IndexByte(fields[i], ':') >= 0 {
+			sub := bytes.Split(fields[i], []byte(":"))
+			switch atoi(sub[0]) {
+			case 38:
+				s.Foreground = subColor(sub[1:])
+			case 48:
+				s.Background = subColor(sub[1:])
+			case 4:
+				// 4:0 is no underline, 4:n are underline styles
+				s.Underline = len(sub) < 2 || atoi(sub[1]) != 0
+			}
+			continue
+		}
+
+		p := atoi(fields[i])
+		switch {
+		case p == 0:
+			*s = Style{}
+		case p == 1:
+			s.Bold = true
+		case p == 2:
+			s.Faint = true
+		case p == 3:
+			s.Italic = true
+		case p == 4 || p == 21:
+			s.Underline = true
+		case p == 5 || p == 6:
+			s.Blink = true
+		case p == 7:
+			s.Inverse = true
+		case p == 8:
+			s.Hidden = true
+		case p == 9:
+			s.Strikethrough = true
+		case p == 22:
+			s.Bold = false
+			s.Faint = false
+		case p == 23:
+			s.Italic = false
+		case p == 24:
+			s.Underline = false
+		case p == 25:
+			s.Blink = false
+		case p == 27:
+			s.Inverse = false
+		case p == 28:
+			s.Hidden = false
+		case p == 29:
+			s.Strikethrough = false
+		case p >= 30 && p <= 37:
+			s.Foreground = Color{Type: IndexedColor, Index: uint8(p - 30)}
+		case p == 38:
+			var c Color
+			c, i = extendedColor(fields, i)
+			s.Foreground = c
+		case p == 39:
+			s.Foreground = Color{}
+		case p >= 40 && p <= 47:
+			s.Background = Color{Type: IndexedColor, Index: uint8(p - 40)}
+		case p == 48:
+			var c Color
+			c, i = extendedColor(fields, i)
+			s.Background = c
+		case p == 49:
+			s.Background = Color{}
+		case p >= 90 && p <= 97:
+			s.Foreground = Color{Type: IndexedColor, Index: uint8(p - 90 + 8)}
+		case p >= 100 && p <= 107:
+			s.Background = Color{Type: IndexedColor, Index: uint8(p - 100 + 8)}
+		}
+	}
+
+	return true
+}
+
+// extendedColor parses the parameters following 38 or 48 at fields[i], which
+// are 5;n or 2;r;g;b. It returns the color, and the index of the last field
+// consumed.
+func extendedColor(fields [][]byte, i int) (Color, int) {
+	if i+1 >= len(fields) {
+		return Color{}, i
+	}
+
+	switch atoi(fields[i+1]) {
+	case 5:
+		if i+2 < len(fields) {
+			return Color{Type: IndexedColor, Index: uint8(atoi(fields[i+2]))}, i + 2
+		}
+	case 2:
+		if i+4 < len(fields) {
+			return Color{
+				Type: RGBColor,
+				R:    uint8(atoi(fields[i+2])),
+				G:    uint8(atoi(fields[i+3])),
+				B:    uint8(atoi(fields[i+4])),
+			}, i + 4
+		}
+	}
+
+	return Color{}, len(fields) - 1
+}
+
+// subColor parses the sub-parameters following 38: or 48:, which are 5:n or
+// 2:[colorspace]:r:g:b.
+func subColor(sub [][]byte) Color {
+	if len(sub) == 0 {
+		return Color{}
+	}
+
+	switch atoi(sub[0]) {
+	case 5:
+		if len(sub) >= 2 {
+			return Color{Type: IndexedColor, Index: uint8(atoi(sub[1]))}
+		}
+	case 2:
+		if len(sub) >= 4 {
+			// The colorspace ID is optional: 2:r:g:b, or 2:id:r:g:b, which
+			// may be followed by parameters that are not used here
+			rgb := sub[1:4]
+			if len(sub) >= 5 {
+				rgb = sub[2:5]
+			}
+			return Color{
+				Type: RGBColor,
+				R:    uint8(atoi(rgb[0])),
+				G:    uint8(atoi(rgb[1])),
+				B:    uint8(atoi(rgb[2])),
+			}
+		}
+	}
+
+	return Color{}
+}
+
+// atoi parses a decimal parameter; empty or invalid parameters are 0
+func atoi(b []byte) int {
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0
+		}
+		n = n*10 + int(c-'0')
+		if n > 0xFFFF {
+			// avoid overflow on malicious input
+			return 0xFFFF
+		}
+	}
+	return n
+}
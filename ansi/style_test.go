@@ -0,0 +1,57 @@
+package ansi_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/ansi"
+)
+
+func TestStyleApply(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		seqs     []string
+		expected ansi.Style
+	}
+
+	red := ansi.Color{Type: ansi.IndexedColor, Index: 1}
+	brightBlue := ansi.Color{Type: ansi.IndexedColor, Index: 12}
+	orange := ansi.Color{Type: ansi.IndexedColor, Index: 208}
+	pink := ansi.Color{Type: ansi.RGBColor, R: 255, G: 105, B: 180}
+
+	tests := []test{
+		{[]string{"\x1b[1;31m"}, ansi.Style{Bold: true, Foreground: red}},
+		{[]string{"\x1b[1;31m", "\x1b[0m"}, ansi.Style{}},
+		{[]string{"\x1b[1;31m", "\x1b[m"}, ansi.Style{}},
+		{[]string{"\x1b[1;31m", "\x1b[22m"}, ansi.Style{Foreground: red}},
+		{[]string{"\x1b[3;4;9m", "\x1b[24m"}, ansi.Style{Italic: true, Strikethrough: true}},
+		{[]string{"\x1b[94;41m"}, ansi.Style{Foreground: brightBlue, Background: red}},
+		{[]string{"\x1b[38;5;208;1m"}, ansi.Style{Foreground: orange, Bold: true}},
+		{[]string{"\x1b[48;2;255;105;180m"}, ansi.Style{Background: pink}},
+		{[]string{"\x1b[38:2::255:105:180m"}, ansi.Style{Foreground: pink}},
+		{[]string{"\x1b[38:2:1:255:105:180m"}, ansi.Style{Foreground: pink}},
+		{[]string{"\x1b[38:2:255:105:180m"}, ansi.Style{Foreground: pink}},
+		{[]string{"\x1b[48:2::255:105:180:0:0m"}, ansi.Style{Background: pink}},
+		{[]string{"\x1b[38:2:255:105m"}, ansi.Style{}},
+		{[]string{"\x1b[38:5:208m", "\x1b[39m"}, ansi.Style{}},
+		{[]string{"\x1b[7m", "\x1b[2J"}, ansi.Style{Inverse: true}}, // not SGR
+	}
+
+	for _, test := range tests {
+		var style ansi.Style
+		for _, seq := range test.seqs {
+			style.Apply([]byte(seq))
+		}
+		if style != test.expected {
+			t.Fatalf("for %q, expected %+v, got %+v", test.seqs, test.expected, style)
+		}
+	}
+
+	var style ansi.Style
+	if style.Apply([]byte("\x1b[2J")) {
+		t.Fatal("Apply should return false for a non-SGR sequence")
+	}
+	if style.Apply([]byte("hello")) {
+		t.Fatal("Apply should return false for a non-sequence")
+	}
+}
@@ -0,0 +1,160 @@
+// Command uax29-web is a small web playground for this module. It segments
+// text into graphemes, words, sentences or phrases, and shows the boundaries.
+// For words, it shows the rule responsible for each boundary (see
+// words.Explain), with a choice of joiners.
+//
+// The form is submitted as a GET, so the URL of a result is a permalink,
+// which is handy for bug reports.
+//
+// Usage:
+//
+//	go run github.com/clipperhouse/uax29/cmd/uax29-web -addr localhost:8080
+package main
+
+import (
+	"embed"
+	"flag"
+	"html/template"
+	"log"
+	"net/http"
+
+	"github.com/clipperhouse/uax29/graphemes"
+	"github.com/clipperhouse/uax29/iterators"
+	"github.com/clipperhouse/uax29/phrases"
+	"github.com/clipperhouse/uax29/sentences"
+	"github.com/clipperhouse/uax29/words"
+)
+
+//go:embed index.html
+var files embed.FS
+
+var page = template.Must(template.ParseFS(files, "index.html"))
+
+// maxText is the maximum length of text to segment, in bytes
+const maxText = 64 * 1024
+
+// modes are the available segmentations
+var modes = map[string]func([]byte) *iterators.Segmenter{
+	"graphemes": graphemes.NewSegmenter,
+	"sentences": sentences.NewSegmenter,
+	"phrases":   phrases.NewSegmenter,
+}
+
+// presets are the available joiners, for words
+var presets = map[string]func() *words.Joiners{
+	"none":      func() *words.Joiners { return nil },
+	"search":    words.SearchJoiners,
+	"editorial": words.EditorialJoiners,
+	"chat":      words.ChatJoiners,
+	"ocr":       words.OCRJoiners,
+}
+
+// Token is a token and, for words, the reason for the boundary after it
+type Token struct {
+	Text       string
+	Start, End int
+	Rule       string
+	Reason     string
+}
+
+// Result is the data for the page template
+type Result struct {
+	Text    string
+	Mode    string
+	Preset  string
+	Middle  string
+	Leading string
+	Tokens  []Token
+	Error   string
+}
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	flag.Parse()
+
+	http.HandleFunc("/", handle)
+
+	log.Printf("listening on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	q := r.URL.Query()
+	result := Result{
+		Text:    q.Get("text"),
+		Mode:    q.Get("mode"),
+		Preset:  q.Get("preset"),
+		Middle:  q.Get("middle"),
+		Leading: q.Get("leading"),
+	}
+	if result.Mode == "" {
+		result.Mode = "words"
+	}
+	if result.Preset == "" {
+		result.Preset = "none"
+	}
+
+	if len(result.Text) > maxText {
+		result.Error = "text is too long, the maximum is 64 KiB"
+	} else if err := segment(&result); err != "" {
+		result.Error = err
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := page.Execute(w, result); err != nil {
+		log.Print(err)
+	}
+}
+
+// segment populates result.Tokens, or returns an error message
+func segment(result *Result) string {
+	if result.Mode == "words" {
+		preset, ok := presets[result.Preset]
+		if !ok {
+			return "unknown preset " + result.Preset
+		}
+
+		joiners := preset()
+		if result.Middle != "" || result.Leading != "" {
+			if joiners == nil {
+				joiners = &words.Joiners{}
+			}
+			joiners.Middle = append(joiners.Middle, []rune(result.Middle)...)
+			joiners.Leading = append(joiners.Leading, []rune(result.Leading)...)
+		}
+
+		for _, e := range words.Explain(result.Text, joiners) {
+			result.Tokens = append(result.Tokens, Token{
+				Text:   e.Token,
+				Start:  e.Start,
+				End:    e.End,
+				Rule:   e.Rule,
+				Reason: e.Reason,
+			})
+		}
+		return ""
+	}
+
+	newSegmenter, ok := modes[result.Mode]
+	if !ok {
+		return "unknown mode " + result.Mode
+	}
+
+	seg := newSegmenter([]byte(result.Text))
+	for seg.Next() {
+		result.Tokens = append(result.Tokens, Token{
+			Text:  seg.Text(),
+			Start: seg.Start(),
+			End:   seg.End(),
+		})
+	}
+	if err := seg.Err(); err != nil {
+		return err.Error()
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHandle(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		query    url.Values
+		status   int
+		contains []string
+	}
+
+	tests := []test{
+		{
+			query:    url.Values{},
+			status:   http.StatusOK,
+			contains: []string{"<form"},
+		},
+		{
+			query:    url.Values{"text": {"AB-CD"}},
+			status:   http.StatusOK,
+			contains: []string{"Tokens: 3.", "specify &#39;-&#39; as a Middle joiner", "WB2"},
+		},
+		{
+			query:    url.Values{"text": {"AB-CD"}, "middle": {"-"}},
+			status:   http.StatusOK,
+			contains: []string{"Tokens: 1.", "&#34;AB-CD&#34;"},
+		},
+		{
+			query:    url.Values{"text": {"Hi. There."}, "mode": {"sentences"}},
+			status:   http.StatusOK,
+			contains: []string{"Tokens: 2."},
+		},
+		{
+			query:    url.Values{"text": {"<script>"}, "mode": {"graphemes"}},
+			status:   http.StatusOK,
+			contains: []string{"&lt;script&gt;"},
+		},
+		{
+			query:    url.Values{"text": {"hi"}, "mode": {"nope"}},
+			status:   http.StatusOK,
+			contains: []string{"unknown mode nope"},
+		},
+		{
+			query:  url.Values{},
+			status: http.StatusNotFound,
+		},
+	}
+
+	for i, test := range tests {
+		path := "/"
+		if test.status == http.StatusNotFound {
+			path = "/missing"
+		}
+
+		req := httptest.NewRequest(http.MethodGet, path+"?"+test.query.Encode(), nil)
+		rec := httptest.NewRecorder()
+		handle(rec, req)
+
+		if rec.Code != test.status {
+			t.Errorf("test %d: expected status %d, got %d", i, test.status, rec.Code)
+		}
+
+		body := rec.Body.String()
+		for _, s := range test.contains {
+			if !strings.Contains(body, s) {
+				t.Errorf("test %d: expected body to contain %q", i, s)
+			}
+		}
+		if strings.Contains(body, "<script>") {
+			t.Errorf("test %d: expected text to be escaped", i)
+		}
+	}
+}
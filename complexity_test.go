@@ -0,0 +1,137 @@
+package uax29_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/clipperhouse/uax29/graphemes"
+	"github.com/clipperhouse/uax29/lines"
+	"github.com/clipperhouse/uax29/paragraphs"
+	"github.com/clipperhouse/uax29/phrases"
+	"github.com/clipperhouse/uax29/sentences"
+	"github.com/clipperhouse/uax29/words"
+)
+
+// adversarial are inputs which are repeated many times, to exercise the
+// lookbacks and lookaheads of the segmentation rules, such as SB8 and SB11
+// for sentences, and WB6/WB7 and WB11/WB12 for words. The prefix is not repeated.
+var adversarial = []struct {
+	name, prefix, unit string
+}{
+	{"Sp", "", " "},
+	{"Close", "", ")"},
+	{"SingleQuote", "", "'"},
+	{"MidNum", "", ","},
+	{"Numeric MidNum", "", "1,"},
+	{"Numeric MidNumLet", "", "1."},
+	{"MidLetter", "", ":"},
+	{"ALetter MidLetter", "", "a:"},
+	{"ALetter SingleQuote", "", "a'"},
+	{"Extend", "", "́"},
+	{"Format", "", "­"},
+	{"ZWJ", "", "‍"},
+	{"RI", "", "🇺"},
+	{"ATerm", "", "."},
+	{"ATerm Sp", "", ". "},
+	{"ATerm Close Sp", "", ".) "},
+	{"STerm Close", "", "!)"},
+	{"ATerm then Sp", "a.", " "},
+	{"ATerm then Close", "a.", ")"},
+	{"ATerm then Sp Close", "a.", " )"},
+	{"ATerm then SingleQuote", "a. ", "'"},
+	{"CRLF", "", "\r\n"},
+}
+
+var splits = []struct {
+	name  string
+	split bufio.SplitFunc
+}{
+	{"words", words.SplitFunc},
+	{"sentences", sentences.SplitFunc},
+	{"graphemes", graphemes.SplitFunc},
+	{"phrases", phrases.SplitFunc},
+	{"lines", lines.SplitFunc},
+	{"paragraphs", paragraphs.SplitFunc},
+	{"sentences lists", sentences.Options{Lists: true}.SplitFunc()},
+	{"sentences bounded", sentences.BoundedSplitFunc(64)},
+}
+
+// TestLinear asserts that segmentation is O(n) on adversarial inputs: when the
+// input is 16 times longer, it should take around 16 times as long, not 256.
+// The threshold has enough slack for noisy timings; a quadratic rule is well
+// beyond it. It is not parallel, so that other tests don't skew the timings.
+func TestLinear(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test, skipped in short mode")
+	}
+
+	const small, factor = 2_000, 16
+
+	for _, s := range splits {
+		for _, test := range adversarial {
+			input := func(n int) []byte {
+				return append([]byte(test.prefix), bytes.Repeat([]byte(test.unit), n)...)
+			}
+
+			d1, err := duration(s.split, input(small))
+			if err != nil {
+				t.Fatalf("%s %q: %v", s.name, test.name, err)
+			}
+			d2, err := duration(s.split, input(small*factor))
+			if err != nil {
+				t.Fatalf("%s %q: %v", s.name, test.name, err)
+			}
+
+			// Quadratic would be factor^2
+			ratio := float64(d2) / float64(d1)
+			if ratio > factor*4 {
+				t.Errorf("%s %q: %d times the input took %.0f times as long (%s vs %s), expected linear", s.name, test.name, factor, ratio, d1, d2)
+			}
+		}
+	}
+}
+
+// duration returns the time that split takes to segment data, as the fastest
+// of several runs, each of which repeats the segmentation for at least a few
+// milliseconds, for a stable measurement of small inputs
+func duration(split bufio.SplitFunc, data []byte) (time.Duration, error) {
+	const runs, minimum = 3, 5 * time.Millisecond
+
+	best := time.Duration(math.MaxInt64)
+	for run := 0; run < runs; run++ {
+		var n int
+		start := time.Now()
+		for {
+			if err := segment(split, data); err != nil {
+				return 0, err
+			}
+			n++
+			if elapsed := time.Since(start); elapsed >= minimum {
+				if d := elapsed / time.Duration(n); d < best {
+					best = d
+				}
+				break
+			}
+		}
+	}
+	return best, nil
+}
+
+// segment iterates the tokens of data, as a Segmenter would
+func segment(split bufio.SplitFunc, data []byte) error {
+	for pos := 0; pos < len(data); {
+		advance, _, err := split(data[pos:], true)
+		if err != nil {
+			return err
+		}
+		if advance == 0 {
+			return errors.New("no progress at EOF")
+		}
+		pos += advance
+	}
+	return nil
+}
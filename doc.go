@@ -1,6 +1,23 @@
 // Package uax29 provides Unicode text segmentation (UAX #29) for words, sentences and graphemes.
 //
-// See the words, sentences, and graphemes packages for details and usage.
+// See the words, sentences, and graphemes packages for details and usage. To get
+// boundaries for all three in a single pass, use Analyze; to count them in a
+// stream, use Stats. To truncate text to a number of bytes without splitting a
+// grapheme cluster or a word, use TruncateToFit.
+//
+// Segmentation is O(n) on the length of the text, including on adversarial
+// inputs, such as long runs of punctuation or spaces, which exercise the
+// lookbacks and lookaheads in the rules. See TestLinear.
+//
+// Code points which are unassigned in the Unicode version of the tables take
+// the default property values of the UCD, which for most is Other (or AL, for
+// line breaking). So a character from a later version of Unicode is never
+// split, keeps its combining marks, and is otherwise a token of its own. The
+// exceptions are also per the UCD: unassigned code points in the emoji blocks
+// are Extended_Pictographic, so a new emoji joins sequences with ZWJ, and
+// unassigned default-ignorable code points are Control for graphemes. When
+// the tables are upgraded, such characters may segment differently; see the
+// versions package. See TestUnassigned in each package.
 //
 // For more information on the UAX #29 spec: https://unicode.org/reports/tr29/
 package uax29
@@ -0,0 +1,81 @@
+// Package emoji decomposes emoji grapheme clusters into their parts: base
+// emoji, skin tone modifiers, and ZWJ components. It is intended for use with
+// clusters from the graphemes package, for example to bucket emoji by base
+// for analytics, regardless of skin tone. It also extracts well-formed emoji
+// sequences from text, see [NewSegmenter].
+package emoji
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	zwj    = '\u200D'
+	vs15   = '\uFE0E'
+	vs16   = '\uFE0F'
+	keycap = '\u20E3'
+)
+
+// Decomposition is the parts of an emoji grapheme cluster, see [Decompose].
+type Decomposition struct {
+	// Base is the first component, without modifiers, variation selectors,
+	// keycaps or tags. For example, the base of "👩🏽‍💻" is "👩", and the
+	// base of "🇺🇸" is "🇺🇸".
+	Base string
+
+	// Modifiers are the skin tone modifiers (U+1F3FB–U+1F3FF), in the order
+	// they appear, across all components.
+	Modifiers []string
+
+	// Components are the sequences joined by ZWJ (U+200D), including their
+	// modifiers, but without the ZWJ. For example, the components of "👩🏽‍💻"
+	// are "👩🏽" and "💻". A cluster without ZWJ has a single component.
+	Components []string
+}
+
+// Decompose returns the parts of a single grapheme cluster, such as one
+// returned by the graphemes package. For an empty cluster, it returns
+// an empty Decomposition.
+//
+// It does not validate that the cluster is a valid emoji sequence; any
+// cluster will be decomposed on ZWJ, with modifiers collected.
+func Decompose(cluster string) Decomposition {
+	var d Decomposition
+	if cluster == "" {
+		return d
+	}
+
+	d.Components = strings.Split(cluster, string(zwj))
+
+	for i, c := range d.Components {
+		var base strings.Builder
+		for pos, r := range c {
+			switch {
+			case IsModifier(r):
+				d.Modifiers = append(d.Modifiers, c[pos:pos+utf8.RuneLen(r)])
+			case i > 0:
+				// only the first component contributes to the base
+			case r == vs15, r == vs16, r == keycap, isTag(r):
+			default:
+				base.WriteRune(r)
+			}
+		}
+		if i == 0 {
+			d.Base = base.String()
+		}
+	}
+
+	return d
+}
+
+// IsModifier returns whether r is an emoji skin tone modifier (U+1F3FB–U+1F3FF).
+func IsModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+// isTag indicates the tag characters (U+E0020–U+E007F) used in subdivision
+// flags, such as Scotland's.
+func isTag(r rune) bool {
+	return r >= 0xE0020 && r <= 0xE007F
+}
@@ -0,0 +1,49 @@
+package emoji_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/emoji"
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func TestDecompose(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected emoji.Decomposition
+	}
+
+	tests := []test{
+		{"", emoji.Decomposition{}},
+		{"a", emoji.Decomposition{Base: "a", Components: []string{"a"}}},
+		{"👍", emoji.Decomposition{Base: "👍", Components: []string{"👍"}}},
+		{"👍🏽", emoji.Decomposition{Base: "👍", Modifiers: []string{"🏽"}, Components: []string{"👍🏽"}}},
+		{"❤️", emoji.Decomposition{Base: "❤", Components: []string{"❤️"}}},
+		{"1️⃣", emoji.Decomposition{Base: "1", Components: []string{"1️⃣"}}},
+		{"🇺🇸", emoji.Decomposition{Base: "🇺🇸", Components: []string{"🇺🇸"}}},
+		{"🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F", emoji.Decomposition{
+			Base:       "🏴",
+			Components: []string{"🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F"},
+		}},
+		{"👩🏽‍💻", emoji.Decomposition{Base: "👩", Modifiers: []string{"🏽"}, Components: []string{"👩🏽", "💻"}}},
+		{"🧑🏻‍🤝‍🧑🏿", emoji.Decomposition{Base: "🧑", Modifiers: []string{"🏻", "🏿"}, Components: []string{"🧑🏻", "🤝", "🧑🏿"}}},
+		{"🏳️‍🌈", emoji.Decomposition{Base: "🏳", Components: []string{"🏳️", "🌈"}}},
+	}
+
+	for _, test := range tests {
+		got := emoji.Decompose(test.input)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%q: expected %+q, got %+q", test.input, test.expected, got)
+		}
+
+		// Should be a single grapheme, as intended
+		if test.input != "" {
+			if n := len(graphemes.SegmentAll([]byte(test.input))); n != 1 {
+				t.Errorf("%q: expected a single grapheme, got %d", test.input, n)
+			}
+		}
+	}
+}
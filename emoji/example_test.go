@@ -0,0 +1,25 @@
+package emoji_test
+
+import (
+	"fmt"
+
+	"github.com/clipperhouse/uax29/emoji"
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func ExampleDecompose() {
+	text := []byte("👍🏻 👍🏿 👍 👩🏽‍💻")
+
+	counts := map[string]int{}
+
+	seg := graphemes.NewSegmenter(text)
+	for seg.Next() {
+		d := emoji.Decompose(seg.Text())
+		if len(d.Components) == 1 && d.Base != " " {
+			counts[d.Base]++
+		}
+	}
+
+	fmt.Println(counts["👍"])
+	// Output: 3
+}
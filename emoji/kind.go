@@ -0,0 +1,82 @@
+package emoji
+
+import "unicode/utf8"
+
+// Kind flags a token which is a malformed emoji sequence, see [KindOf]. It is
+// shared by the words, phrases and sentences packages, so that a switch on
+// Kind needn't depend on which segmenter produced the token. This API is
+// experimental.
+type Kind uint8
+
+const (
+	// Normal indicates that the token is not a malformed sequence.
+	Normal Kind = iota
+	// MalformedFlag indicates a lone regional indicator, i.e. half of a flag,
+	// such as "🇺" without a second regional indicator.
+	MalformedFlag
+	// MalformedKeycap indicates a combining enclosing keycap (U+20E3) which
+	// does not follow a keycap base (0-9, # or *), such as "a⃣".
+	MalformedKeycap
+)
+
+var kindNames = [...]string{"Normal", "MalformedFlag", "MalformedKeycap"}
+
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "Unknown"
+}
+
+// KindOf determines if a token is a malformed flag or keycap sequence. Such
+// sequences render unexpectedly, and can be used for spoofing, so moderation
+// pipelines may wish to catch them. It is intended for tokens returned by
+// a words, phrases or sentences Segmenter or Scanner, which keep regional
+// indicators paired. This API is experimental.
+func KindOf(token []byte) Kind {
+	var regionals int
+	var last, beforeLast rune
+
+	for pos := 0; pos < len(token); {
+		r, w := utf8.DecodeRune(token[pos:])
+
+		if isRegional(r) {
+			regionals++
+		} else {
+			// Regional indicators are paired within a run of them, so an odd
+			// run indicates one left over
+			if regionals%2 == 1 {
+				return MalformedFlag
+			}
+			regionals = 0
+		}
+
+		if r == keycap {
+			// The base may be followed by VS16, as in "1️⃣"
+			base := last
+			if base == vs16 {
+				base = beforeLast
+			}
+			if !isKeycapBase(base) {
+				return MalformedKeycap
+			}
+		}
+
+		beforeLast, last = last, r
+		pos += w
+	}
+
+	if regionals%2 == 1 {
+		return MalformedFlag
+	}
+
+	return Normal
+}
+
+func isRegional(r rune) bool {
+	return r >= '\U0001F1E6' && r <= '\U0001F1FF'
+}
+
+func isKeycapBase(r rune) bool {
+	return (r >= '0' && r <= '9') || r == '#' || r == '*'
+}
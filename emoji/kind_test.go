@@ -0,0 +1,83 @@
+package emoji_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/emoji"
+	"github.com/clipperhouse/uax29/phrases"
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+func TestKindOf(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected emoji.Kind
+	}
+
+	tests := []test{
+		{"hello", emoji.Normal},
+		{"🇺🇸", emoji.Normal},
+		{"🇺", emoji.MalformedFlag},
+		{"🇺🇸🇺", emoji.MalformedFlag},
+		{"Hi 🇺 there 🇸.", emoji.MalformedFlag},
+		{"🇺 and 🇺🇸", emoji.MalformedFlag},
+		{"🇺🇸 and 🇬🇧", emoji.Normal},
+		{"🇺\u0301🇸", emoji.MalformedFlag},
+		{"1️⃣", emoji.Normal},
+		{"#⃣", emoji.Normal},
+		{"a⃣", emoji.MalformedKeycap},
+		{"⃣", emoji.MalformedKeycap},
+	}
+
+	for _, test := range tests {
+		got := emoji.KindOf([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("for %q, expected %s, got %s", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestKindOfSegmenters(t *testing.T) {
+	t.Parallel()
+
+	input := []byte("Hi 🇺 there. Press a⃣ now.")
+
+	var kinds []emoji.Kind
+	seg := phrases.NewSegmenter(input)
+	for seg.Next() {
+		if k := emoji.KindOf(seg.Bytes()); k != emoji.Normal {
+			kinds = append(kinds, k)
+		}
+	}
+	if len(kinds) != 2 || kinds[0] != emoji.MalformedFlag || kinds[1] != emoji.MalformedKeycap {
+		t.Errorf("expected a malformed flag and keycap in phrases, got %v", kinds)
+	}
+
+	kinds = nil
+	seg = sentences.NewSegmenter(input)
+	for seg.Next() {
+		kinds = append(kinds, emoji.KindOf(seg.Bytes()))
+	}
+	if len(kinds) != 2 || kinds[0] != emoji.MalformedFlag || kinds[1] != emoji.MalformedKeycap {
+		t.Errorf("expected a malformed flag and keycap in sentences, got %v", kinds)
+	}
+}
+
+func TestKindString(t *testing.T) {
+	t.Parallel()
+
+	tests := map[emoji.Kind]string{
+		emoji.Normal:          "Normal",
+		emoji.MalformedFlag:   "MalformedFlag",
+		emoji.MalformedKeycap: "MalformedKeycap",
+		emoji.Kind(99):        "Unknown",
+	}
+
+	for kind, expected := range tests {
+		if kind.String() != expected {
+			t.Errorf("expected %q, got %q", expected, kind.String())
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package emoji
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/graphemes"
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+const tagEnd = '\U000E007F'
+
+// IsEmoji determines if token is a single, well-formed emoji sequence, per
+// UTS #51: an emoji, optionally with a skin tone modifier or a variation
+// selector (VS16), a flag, a keycap such as "1️⃣", a tag sequence such as
+// the flag of Scotland, or a ZWJ sequence of these, such as "👩🏽‍💻". It
+// is a filter.Func, intended for grapheme clusters, see [NewSegmenter].
+//
+// Emoji are determined by the Extended_Pictographic property of the
+// graphemes package, so that IsEmoji agrees with grapheme clustering. That
+// property includes symbols which default to text presentation, such as
+// "©", and code points reserved for future emoji. A symbol followed by VS15,
+// requesting text presentation, is not an emoji. This API is experimental.
+func IsEmoji(token []byte) bool {
+	if len(token) == 0 {
+		return false
+	}
+
+	r, w := utf8.DecodeRune(token)
+	if isRegional(r) {
+		// A flag is exactly two regional indicators
+		r2, w2 := utf8.DecodeRune(token[w:])
+		return isRegional(r2) && w+w2 == len(token)
+	}
+
+	for pos := 0; ; {
+		n := element(token[pos:])
+		if n == 0 {
+			return false
+		}
+		pos += n
+		if pos == len(token) {
+			return true
+		}
+
+		// Elements are joined by ZWJ
+		r, w := utf8.DecodeRune(token[pos:])
+		if r != zwj {
+			return false
+		}
+		pos += w
+	}
+}
+
+// element returns the length of a single emoji at the start of data, with
+// its modifier, variation selector, keycap or tags, or 0 if there is none
+func element(data []byte) int {
+	r, w := utf8.DecodeRune(data)
+	pos := w
+
+	next := func() rune {
+		if pos >= len(data) {
+			return -1
+		}
+		r, _ := utf8.DecodeRune(data[pos:])
+		return r
+	}
+
+	switch {
+	case isKeycapBase(r):
+		// A keycap, with an optional VS16
+		if next() == vs16 {
+			pos += utf8.RuneLen(vs16)
+		}
+		if next() != keycap {
+			return 0
+		}
+		return pos + utf8.RuneLen(keycap)
+	case IsModifier(r):
+		// A lone modifier renders as a swatch
+		return pos
+	case !graphemes.IsExtendedPictographic(r):
+		return 0
+	}
+
+	switch n := next(); {
+	case IsModifier(n):
+		pos += utf8.RuneLen(n)
+	case n == vs16:
+		pos += utf8.RuneLen(n)
+	case isTag(n) && n != tagEnd:
+		// A tag sequence is one or more tags, and a terminating tag
+		for isTag(next()) && next() != tagEnd {
+			pos += utf8.RuneLen(next())
+		}
+		if next() != tagEnd {
+			return 0
+		}
+		pos += utf8.RuneLen(tagEnd)
+	}
+
+	return pos
+}
+
+// NewSegmenter returns a Segmenter, which is an iterator over the emoji
+// sequences in data. Iterate while Next() is true, and access the emoji via
+// Bytes(). It segments grapheme clusters, per the graphemes package, and
+// returns those which are emoji, see [IsEmoji].
+func NewSegmenter(data []byte) *iterators.Segmenter {
+	seg := graphemes.NewSegmenter(data)
+	seg.Filter(IsEmoji)
+	return seg
+}
+
+// NewScanner returns a Scanner, which is an iterator over the emoji sequences
+// in r. Iterate while Scan() is true, and access the emoji via Bytes(). See
+// [NewSegmenter].
+func NewScanner(r io.Reader) *iterators.Scanner {
+	sc := graphemes.NewScanner(r)
+	sc.Filter(IsEmoji)
+	return sc
+}
@@ -0,0 +1,98 @@
+package emoji_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"github.com/clipperhouse/uax29/emoji"
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func TestIsEmoji(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected bool
+	}
+
+	tests := []test{
+		{"", false},
+		{"a", false},
+		{"1", false},
+		{"\U0001F44D", true},           // thumbs up
+		{"\U0001F44D\U0001F3FD", true}, // with a modifier
+		{"\U0001F3FD", true},           // a lone modifier
+		{"\u2764\ufe0f", true},         // heart, with VS16
+		{"\u2764", true},               // heart, text by default
+		{"\u2764\ufe0e", false},        // heart, with VS15
+		{"\u00a9", true},               // copyright
+		{"1\ufe0f\u20e3", true},        // keycap
+		{"#\u20e3", true},              // keycap without VS16
+		{"a\u20e3", false},             // not a keycap base
+		{"\U0001F1FA\U0001F1F8", true}, // flag
+		{"\U0001F1FA", false},          // half a flag
+		{"\U0001F3F4\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F", true}, // Scotland
+		{"\U0001F3F4\U000E0067\U000E0062", false},                                        // unterminated tags
+		{"\U0001F469\U0001F3FD\u200d\U0001F4BB", true},                                   // woman technologist
+		{"\U0001F9D1\U0001F3FB\u200d\U0001F91D\u200d\U0001F9D1\U0001F3FF", true},
+		{"\U0001F3F3\ufe0f\u200d\U0001F308", true}, // rainbow flag
+		{"\U0001F469\u200d", false},                // trailing ZWJ
+		{"\U0001F469\u200da", false},               // ZWJ and a letter
+		{"\U0001F44D\u0301", false},                // a combining mark
+	}
+
+	for _, test := range tests {
+		got := emoji.IsEmoji([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%+q: expected %t, got %t", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestSegmenter(t *testing.T) {
+	t.Parallel()
+
+	input := "Hi \U0001F44B\U0001F3FD there! \U0001F1FA\U0001F1F8 \U0001F469\u200d\U0001F4BB rocks 1\ufe0f\u20e3, not 1 or \u2764\ufe0e.\U0001F600\U0001F600"
+	expected := []string{
+		"\U0001F44B\U0001F3FD",
+		"\U0001F1FA\U0001F1F8",
+		"\U0001F469\u200d\U0001F4BB",
+		"1\ufe0f\u20e3",
+		"\U0001F600",
+		"\U0001F600",
+	}
+
+	var got []string
+	seg := emoji.NewSegmenter([]byte(input))
+	for seg.Next() {
+		got = append(got, seg.Text())
+	}
+	if err := seg.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+q, got %+q", expected, got)
+	}
+
+	var scanned []string
+	sc := emoji.NewScanner(iotest.OneByteReader(bytes.NewReader([]byte(input))))
+	for sc.Scan() {
+		scanned = append(scanned, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(scanned, expected) {
+		t.Fatalf("scanner expected %+q, got %+q", expected, scanned)
+	}
+
+	// Each emoji is a grapheme cluster
+	for _, e := range got {
+		if n := graphemes.Count([]byte(e)); n != 1 {
+			t.Errorf("%+q: expected a single grapheme, got %d", e, n)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package dense
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	values := map[rune]uint64{
+		'a':      1,
+		'z':      2,
+		0x7F:     4,
+		0x80:     8,
+		0x4E16:   1,
+		0xFFFD:   2,
+		0x10000:  4,
+		0x1F44D:  8,
+		0x10FFFF: 1,
+	}
+
+	table := build("test", values)
+
+	lookup := func(r rune) uint64 {
+		if r < 0x10000 {
+			return table.Values[table.BMPIndex[r>>6]][r&0x3F]
+		}
+		a := r - 0x10000
+		mid := table.AstralMid[table.AstralIndex[a>>12]]
+		return table.Values[mid[(a>>6)&0x3F]][r&0x3F]
+	}
+
+	for r := rune(0); r <= 0x10FFFF; r++ {
+		if got := lookup(r); got != values[r] {
+			t.Fatalf("%U: expected %d, got %d", r, values[r], got)
+		}
+	}
+
+	// ASCII must be the first two blocks, which are indexed directly
+	if table.BMPIndex[0] != 0 || table.BMPIndex[1] != 1 {
+		t.Fatal("expected ASCII in the first two blocks")
+	}
+}
+
+func TestGen(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Gen(&buf, "test", map[rune]uint64{'a': 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{"type testTrie struct{}", "func newTestTrie(i int) *testTrie", "func (t *testTrie) lookup(s []byte) (v property, sz int)"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("expected generated code to contain %q", s)
+		}
+	}
+}
+
+func TestTitle(t *testing.T) {
+	t.Parallel()
+
+	for input, expected := range map[string]string{"words": "Words", "w": "W", "": ""} {
+		if got := title(input); got != expected {
+			t.Errorf("%q: expected %q, got %q", input, expected, got)
+		}
+	}
+}
@@ -4,27 +4,53 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"flag"
 	"fmt"
 	"go/format"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/clipperhouse/uax29/gen/dense"
 	"github.com/clipperhouse/uax29/gen/triegen"
 	"golang.org/x/text/unicode/rangetable"
 )
 
+var layout = flag.String("layout", "triegen", `the layout of the generated tries: "triegen", or "dense" for a directly-indexed table, optimized for lookup latency (see gen/dense)`)
+
+// version is the version of the Unicode Character Database from which the
+// tries and tests are generated. It defaults to the version of the current
+// tables, rather than to unicode.Version, which follows the Go toolchain.
+//
+// The Han and Hiragana ranges for BleveIdeographic come from Go's unicode
+// package regardless.
+var version = flag.String("unicode", "15.0.0", `the Unicode version of the data, such as "15.1.0"`)
+
+// ucd is a local copy of the Unicode Character Database, laid out as
+// https://www.unicode.org/Public/<version>/ucd/, for use instead of
+// downloading
+var ucd = flag.String("ucd", "", "a local directory to read UCD files from, instead of downloading them")
+
+var only = flag.String("props", "", `a comma-separated subset of the properties to generate, such as "Line"; all if empty`)
+
 func main() {
+	flag.Parse()
+
+	if *layout != "triegen" && *layout != "dense" {
+		panic(fmt.Sprintf("unknown layout %q", *layout))
+	}
+
 	props := []prop{
 		// make sure emoji goes first, subsequent props need it
 		{
 			name: "Emoji",
-			url:  "https://www.unicode.org/Public/" + unicode.Version + "/ucd/emoji/emoji-data.txt",
+			url:  baseURL() + "/emoji/emoji-data.txt",
 		},
 		{
 			name: "Word",
@@ -38,9 +64,24 @@ func main() {
 		{
 			name: "Sentence",
 		},
+		{
+			name: "Line",
+			url:  baseURL() + "/LineBreak.txt",
+		},
+	}
+
+	selected := map[string]bool{}
+	for _, name := range strings.Split(*only, ",") {
+		if name != "" {
+			selected[name] = true
+		}
 	}
 
 	for _, p := range props {
+		if len(selected) > 0 && !selected[p.name] && p.name != "Emoji" {
+			continue
+		}
+
 		if err := p.generateTrie(); err != nil {
 			panic(err)
 		}
@@ -51,7 +92,27 @@ func main() {
 	}
 }
 
-const baseURL = "https://www.unicode.org/Public/" + unicode.Version + "/ucd/auxiliary"
+// baseURL is the location of the UCD for the requested version
+func baseURL() string {
+	return "https://www.unicode.org/Public/" + *version + "/ucd"
+}
+
+// get returns the contents of url, from the local UCD if one was given
+func get(url string) (io.ReadCloser, error) {
+	if *ucd != "" {
+		return os.Open(filepath.Join(*ucd, filepath.FromSlash(strings.TrimPrefix(url, baseURL()))))
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
 
 type prop struct {
 	name string
@@ -67,14 +128,14 @@ func (p prop) URL() string {
 		p.name = "Word"
 	}
 
-	return fmt.Sprintf("%s/%sBreakProperty.txt", baseURL, p.name)
+	return fmt.Sprintf("%s/auxiliary/%sBreakProperty.txt", baseURL(), p.name)
 }
 
 func (p prop) TestURL() string {
 	if p.name == "Emoji" {
 		panic("no tests for emoji")
 	}
-	return fmt.Sprintf("%s/%sBreakTest.txt", baseURL, p.name)
+	return fmt.Sprintf("%s/auxiliary/%sBreakTest.txt", baseURL(), p.name)
 }
 
 func (p prop) PackageName() string {
@@ -85,14 +146,16 @@ var extendedPictographic []rune
 
 func (p prop) generateTrie() error {
 	fmt.Println(p.URL())
-	resp, err := http.Get(p.URL())
+	body, err := get(p.URL())
 	if err != nil {
 		return err
 	}
+	defer body.Close()
 
-	b := bufio.NewReader(resp.Body)
+	b := bufio.NewReader(body)
 
 	runesByProperty := map[string][]rune{}
+	var unassigned, marks []rune
 	for {
 		s, err := b.ReadString('\n')
 		if err != nil {
@@ -120,6 +183,17 @@ func (p prop) generateTrie() error {
 		property := strings.TrimSpace(split2[0])
 
 		runesByProperty[property] = append(runesByProperty[property], runes...)
+
+		// LineBreak.txt notes the general category in the comment
+		if len(split2) > 1 {
+			gc := strings.TrimSpace(split2[1])
+			switch {
+			case strings.HasPrefix(gc, "Cn"):
+				unassigned = append(unassigned, runes...)
+			case strings.HasPrefix(gc, "Mn"), strings.HasPrefix(gc, "Mc"):
+				marks = append(marks, runes...)
+			}
+		}
 	}
 
 	// Words and graphemes need Extended_Pictographic property
@@ -136,6 +210,37 @@ func (p prop) generateTrie() error {
 		runesByProperty[key] = extendedPictographic
 	}
 
+	if p.name == "Line" {
+		// Surrogates can't be encoded as UTF-8
+		delete(runesByProperty, "SG")
+
+		// LB30b needs unassigned Extended_Pictographic, [\p{Extended_Pictographic}&\p{Cn}]
+		if len(extendedPictographic) == 0 {
+			panic("didn't get emoji data; make sure it's loaded first")
+		}
+		pictographic := map[rune]bool{}
+		for _, r := range extendedPictographic {
+			pictographic[r] = true
+		}
+		for _, r := range unassigned {
+			if pictographic[r] {
+				runesByProperty["Extended_Pictographic_Cn"] = append(runesByProperty["Extended_Pictographic_Cn"], r)
+			}
+		}
+
+		// LB1 resolves SA to CM for marks, [\p{Line_Break=SA}&[\p{Mn}\p{Mc}]], from the same
+		// version's general category, rather than Go's
+		sa := map[rune]bool{}
+		for _, r := range runesByProperty["SA"] {
+			sa[r] = true
+		}
+		for _, r := range marks {
+			if sa[r] {
+				runesByProperty["SA_Mark"] = append(runesByProperty["SA_Mark"], r)
+			}
+		}
+	}
+
 	if p.name == "Word" {
 		// Concatenate UAX 29 definition of Katakana with Han and Hiragana
 		// The rangetable unicode.Katakana isn't complete for
@@ -167,13 +272,7 @@ func (p prop) generateTrie() error {
 		}
 	}
 
-	trie := triegen.NewTrie(p.PackageName())
-
-	for r, iotas := range iotasByRune {
-		trie.Insert(r, iotas)
-	}
-
-	err = writeTrie(p, trie, iotasByProperty)
+	err = writeTrie(p, iotasByRune, iotasByProperty)
 	if err != nil {
 		return err
 	}
@@ -195,12 +294,13 @@ func (p prop) generateTests() error {
 		return nil
 	}
 	fmt.Println(p.TestURL())
-	resp, err := http.Get(p.TestURL())
+	body, err := get(p.TestURL())
 	if err != nil {
 		return err
 	}
+	defer body.Close()
 
-	sc := bufio.NewScanner(resp.Body) // defaults to ScanLines
+	sc := bufio.NewScanner(body) // defaults to ScanLines
 
 	var unicodeTests []unicodeTest
 	for sc.Scan() {
@@ -344,7 +444,7 @@ var unicodeTests = [%d]unicodeTest {
 	return nil
 }
 
-func writeTrie(prop prop, trie *triegen.Trie, iotasByProperty map[string]uint64) error {
+func writeTrie(prop prop, iotasByRune map[rune]uint64, iotasByProperty map[string]uint64) error {
 	buf := bytes.Buffer{}
 
 	fmt.Fprintln(&buf, "package "+prop.PackageName())
@@ -358,20 +458,7 @@ func writeTrie(prop prop, trie *triegen.Trie, iotasByProperty map[string]uint64)
 	}
 	sort.Strings(properties)
 
-	inttype := ""
-	len := len(properties)
-	switch {
-	case len < 8:
-		inttype = "uint8"
-	case len < 16:
-		inttype = "uint16"
-	case len < 32:
-		inttype = "uint32"
-	default:
-		inttype = "uint64"
-	}
-
-	fmt.Fprintf(&buf, "type property %s\n\n", inttype)
+	fmt.Fprintf(&buf, "type property %s\n\n", intType(len(properties)))
 
 	fmt.Fprintln(&buf, "const (")
 	for i, property := range properties {
@@ -384,9 +471,20 @@ func writeTrie(prop prop, trie *triegen.Trie, iotasByProperty map[string]uint64)
 	}
 	fmt.Fprintln(&buf, ")")
 
-	_, err := triegen.Gen(&buf, prop.PackageName(), []*triegen.Trie{trie})
-	if err != nil {
-		return err
+	switch *layout {
+	case "dense":
+		if err := dense.Gen(&buf, prop.PackageName(), iotasByRune); err != nil {
+			return err
+		}
+	default:
+		trie := triegen.NewTrie(prop.PackageName())
+		for r, iotas := range iotasByRune {
+			trie.Insert(r, iotas)
+		}
+
+		if _, err := triegen.Gen(&buf, prop.PackageName(), []*triegen.Trie{trie}); err != nil {
+			return err
+		}
 	}
 
 	formatted, err := format.Source(buf.Bytes())
@@ -407,3 +505,17 @@ func writeTrie(prop prop, trie *triegen.Trie, iotasByProperty map[string]uint64)
 
 	return nil
 }
+
+// intType returns the smallest unsigned int type which will hold n bits
+func intType(n int) string {
+	switch {
+	case n < 8:
+		return "uint8"
+	case n < 16:
+		return "uint16"
+	case n < 32:
+		return "uint32"
+	default:
+		return "uint64"
+	}
+}
@@ -0,0 +1,49 @@
+package graphemes
+
+import (
+	"bufio"
+
+	"github.com/clipperhouse/uax29/ansi"
+)
+
+// DefaultMaxANSILength is the default maximum length, in bytes, of an ANSI
+// escape sequence, see [ANSISplitFunc].
+const DefaultMaxANSILength = ansi.DefaultMaxLength
+
+// ANSISplitFunc returns a bufio.SplitFunc which treats ANSI escape sequences,
+// such as those used for terminal colors, as single tokens. Text which is not
+// an escape sequence is segmented into graphemes, identically to SplitFunc.
+// Escape sequences are parsed by the ansi package.
+//
+// Some escape sequences (such as OSC and DCS) are terminated by a string
+// terminator, and may be arbitrarily long. Without a limit, malicious input
+// containing many unterminated sequences would be scanned to the end of the
+// data, once for each sequence, which is O(n^2). maxLength limits the scan:
+// if a sequence is not terminated within maxLength bytes, it is abandoned,
+// and its leading ESC is returned as a single (control) grapheme. This makes
+// the worst case O(n * maxLength). If maxLength is zero or less,
+// DefaultMaxANSILength is used.
+//
+// It is suitable for use with a Scanner; sequences which span reads are
+// handled correctly. Note that maxLength should not exceed the Scanner's
+// maximum token size, see bufio.Scanner.Buffer.
+func ANSISplitFunc(maxLength int) bufio.SplitFunc {
+	if maxLength <= 0 {
+		maxLength = DefaultMaxANSILength
+	}
+
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if len(data) > 0 && data[0] == 0x1B { // ESC
+			n, _, more := ansi.Parse(data, maxLength, atEOF)
+			if more {
+				// Sequence extends past current data, request more
+				return 0, nil, nil
+			}
+			if n > 0 {
+				return n, data[:n], nil
+			}
+		}
+
+		return SplitFunc(data, atEOF)
+	}
+}
@@ -0,0 +1,161 @@
+package graphemes_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+type ansiTest struct {
+	input    string
+	expected []string
+}
+
+var ansiTests = []ansiTest{
+	{"\x1b[31mHi\x1b[0m", []string{"\x1b[31m", "H", "i", "\x1b[0m"}},
+	{"\x1b[1;38;5;208mé\x1b[m", []string{"\x1b[1;38;5;208m", "é", "\x1b[m"}},
+	{"\x1b]8;;https://example.com\x07link\x1b]8;;\x1b\\", []string{"\x1b]8;;https://example.com\x07", "l", "i", "n", "k", "\x1b]8;;\x1b\\"}},
+	{"\x1bPq#0;2;0;0;0\x1b\\a", []string{"\x1bPq#0;2;0;0;0\x1b\\", "a"}},
+	{"\x1b(Ba", []string{"\x1b(B", "a"}},
+	{"\x1b7a\x1b8", []string{"\x1b7", "a", "\x1b8"}},
+	// incomplete or invalid sequences fall back to graphemes
+	{"\x1b[31", []string{"\x1b", "[", "3", "1"}},
+	{"\x1b]unterminated", []string{"\x1b", "]", "u", "n", "t", "e", "r", "m", "i", "n", "a", "t", "e", "d"}},
+	{"\x1b", []string{"\x1b"}},
+	{"a\x1b[31m👍🏽", []string{"a", "\x1b[31m", "👍🏽"}},
+}
+
+func TestANSISplitFunc(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range ansiTests {
+		seg := graphemes.NewSegmenter([]byte(test.input))
+		seg.Split(graphemes.ANSISplitFunc(0))
+
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Fatalf("for %q, expected %q, got %q", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestANSISplitFuncMaxLength(t *testing.T) {
+	t.Parallel()
+
+	osc := "\x1b]" + strings.Repeat("x", 100) + "\x07"
+
+	{
+		seg := graphemes.NewSegmenter([]byte(osc))
+		seg.Split(graphemes.ANSISplitFunc(len(osc)))
+		seg.Next()
+		if seg.Text() != osc {
+			t.Fatalf("sequence within max length should be a single token, got %q", seg.Bytes())
+		}
+	}
+
+	{
+		seg := graphemes.NewSegmenter([]byte(osc))
+		seg.Split(graphemes.ANSISplitFunc(len(osc) - 1))
+		seg.Next()
+		if seg.Text() != "\x1b" {
+			t.Fatalf("sequence beyond max length should be abandoned, got %q", seg.Bytes())
+		}
+	}
+}
+
+func TestANSISplitFuncPathological(t *testing.T) {
+	t.Parallel()
+
+	// Many unterminated DCS sequences; without a max length, each would be
+	// scanned to the end of the data
+	input := bytes.Repeat([]byte("\x1bP"), 100_000)
+
+	seg := graphemes.NewSegmenter(input)
+	seg.Split(graphemes.ANSISplitFunc(0))
+
+	start := time.Now()
+	var output []byte
+	for seg.Next() {
+		output = append(output, seg.Bytes()...)
+	}
+
+	if !bytes.Equal(output, input) {
+		t.Fatal("input bytes are not the same as segmented bytes")
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("segmentation of pathological input took %s", elapsed)
+	}
+}
+
+func TestANSISplitFuncRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	const runs = 2000
+
+	seg := graphemes.NewSegmenter(nil)
+	seg.Split(graphemes.ANSISplitFunc(0))
+
+	for i := 0; i < runs; i++ {
+		input := getRandomBytes()
+		seg.SetText(input)
+
+		var output []byte
+		for seg.Next() {
+			output = append(output, seg.Bytes()...)
+		}
+
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(output, input) {
+			t.Fatal("input bytes are not the same as segmented bytes")
+		}
+	}
+}
+
+func TestANSIScannerSameAsSegmenter(t *testing.T) {
+	t.Parallel()
+
+	var inputs [][]byte
+	for _, test := range ansiTests {
+		inputs = append(inputs, []byte(test.input))
+	}
+	for i := 0; i < 100; i++ {
+		inputs = append(inputs, getRandomBytes())
+	}
+
+	for _, input := range inputs {
+		seg := graphemes.NewSegmenter(input)
+		seg.Split(graphemes.ANSISplitFunc(0))
+
+		// OneByteReader ensures that sequences span reads
+		sc := graphemes.NewScanner(iotest.OneByteReader(bytes.NewReader(input)))
+		sc.Split(graphemes.ANSISplitFunc(0))
+
+		for seg.Next() {
+			if !sc.Scan() {
+				t.Fatal("scanner returned fewer tokens than segmenter")
+			}
+			if !bytes.Equal(seg.Bytes(), sc.Bytes()) {
+				t.Fatalf("scanner and segmenter should give identical results, expected %q, got %q", seg.Bytes(), sc.Bytes())
+			}
+		}
+		if sc.Scan() {
+			t.Fatal("scanner returned more tokens than segmenter")
+		}
+		if err := sc.Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
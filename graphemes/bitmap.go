@@ -0,0 +1,78 @@
+package graphemes
+
+import "math/bits"
+
+// Bitmap is a set of grapheme boundaries of a text, one bit per byte
+// position, such that IsBoundary is O(1). It is useful when the same text
+// will be queried many times, as in an editor or renderer. Memory is
+// len(text)/8 bytes, rounded up. See [NewBitmap].
+type Bitmap []uint64
+
+// NewBitmap returns the grapheme boundaries of data. Boundaries are
+// identical to those of a Segmenter: 0, the end of each grapheme, and
+// len(data). For empty data, there are no boundaries.
+func NewBitmap(data []byte) Bitmap {
+	return AppendBitmap(nil, data)
+}
+
+// AppendBitmap appends the grapheme boundaries of data to dst, and returns
+// the result. To reuse the storage of an existing Bitmap, pass it as dst[:0]:
+//
+//	b = graphemes.AppendBitmap(b[:0], data)
+//
+// If dst is not empty, positions are offset by 64 * len(dst).
+func AppendBitmap(dst Bitmap, data []byte) Bitmap {
+	if len(data) == 0 {
+		return dst
+	}
+
+	offset := len(dst)
+	n := (len(data) + 1 + 63) / 64
+	if cap(dst)-offset >= n {
+		dst = dst[:offset+n]
+		for i := offset; i < len(dst); i++ {
+			dst[i] = 0
+		}
+	} else {
+		dst = append(dst, make(Bitmap, n)...)
+	}
+
+	b := dst[offset:]
+	b.set(0)
+
+	pos := 0
+	for pos < len(data) {
+		advance, _, _ := SplitFunc(data[pos:], true)
+		if advance <= 0 {
+			// Shouldn't happen at EOF, but be safe against an infinite loop
+			advance = len(data) - pos
+		}
+		pos += advance
+		b.set(pos)
+	}
+
+	return dst
+}
+
+func (b Bitmap) set(pos int) {
+	b[pos/64] |= 1 << (pos % 64)
+}
+
+// IsBoundary returns whether pos is a grapheme boundary. Positions outside
+// the text are not boundaries.
+func (b Bitmap) IsBoundary(pos int) bool {
+	if pos < 0 || pos/64 >= len(b) {
+		return false
+	}
+	return b[pos/64]&(1<<(pos%64)) != 0
+}
+
+// Count returns the number of boundaries. The number of graphemes is one
+// less, for non-empty text.
+func (b Bitmap) Count() int {
+	var count int
+	for _, w := range b {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
@@ -0,0 +1,92 @@
+package graphemes_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func TestBitmap(t *testing.T) {
+	t.Parallel()
+
+	sample, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid, err := os.ReadFile("../testdata/UTF-8-test.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := [][]byte{sample, invalid}
+	for _, test := range unicodeTests {
+		inputs = append(inputs, test.input)
+	}
+
+	var reused graphemes.Bitmap
+	for _, input := range inputs {
+		expected := map[int]bool{0: true}
+		seg := graphemes.NewSegmenter(input)
+		for seg.Next() {
+			expected[seg.End()] = true
+		}
+
+		bitmap := graphemes.NewBitmap(input)
+		reused = graphemes.AppendBitmap(reused[:0], input)
+
+		for pos := -1; pos <= len(input)+64; pos++ {
+			if bitmap.IsBoundary(pos) != expected[pos] {
+				t.Fatalf("for input %q, expected IsBoundary(%d) to be %t", input, pos, expected[pos])
+			}
+			if reused.IsBoundary(pos) != expected[pos] {
+				t.Fatalf("for input %q, expected reused IsBoundary(%d) to be %t", input, pos, expected[pos])
+			}
+		}
+
+		if bitmap.Count() != len(expected) {
+			t.Fatalf("for input %q, expected Count() to be %d, got %d", input, len(expected), bitmap.Count())
+		}
+	}
+}
+
+func TestBitmapEmpty(t *testing.T) {
+	t.Parallel()
+
+	bitmap := graphemes.NewBitmap(nil)
+	if bitmap.IsBoundary(0) || bitmap.Count() != 0 {
+		t.Error("expected no boundaries for empty text")
+	}
+}
+
+func TestAppendBitmapOffset(t *testing.T) {
+	t.Parallel()
+
+	first := graphemes.NewBitmap([]byte("abc"))
+	bitmap := graphemes.AppendBitmap(first, []byte("de\u0301"))
+
+	expected := []int{0, 1, 2, 3, 64, 65, 68}
+	if bitmap.Count() != len(expected) {
+		t.Fatalf("expected %d boundaries, got %d", len(expected), bitmap.Count())
+	}
+	for _, pos := range expected {
+		if !bitmap.IsBoundary(pos) {
+			t.Errorf("expected %d to be a boundary", pos)
+		}
+	}
+}
+
+func BenchmarkBitmap(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		b.Error(err)
+	}
+
+	b.ResetTimer()
+	b.SetBytes(int64(len(file)))
+
+	var bitmap graphemes.Bitmap
+	for i := 0; i < b.N; i++ {
+		bitmap = graphemes.AppendBitmap(bitmap[:0], file)
+	}
+}
@@ -0,0 +1,55 @@
+package graphemes
+
+// IsBoundary determines if position i of data is a grapheme boundary, as
+// would be found by a Segmenter, without segmenting from the start of data.
+// It is useful for cursor movement and selection. Positions 0 and len(data)
+// are boundaries for non-empty data; positions outside data are not.
+//
+// It looks back from i to the nearest position which is known to be a
+// boundary by its neighbors alone, such as between two ASCII characters,
+// and segments forward from there. In the worst case (long runs of
+// combining marks, for example), that is the start of data.
+//
+// Data is assumed to be valid UTF-8. A Segmenter returns the remainder of
+// invalid text from an incomplete rune onward as a single token, which
+// IsBoundary does not look back far enough to see.
+func IsBoundary(data []byte, i int) bool {
+	if len(data) == 0 || i < 0 || i > len(data) {
+		return false
+	}
+	if i == 0 || i == len(data) {
+		return true
+	}
+
+	pos := i
+	for pos > 0 && !knownBoundary(data, pos) {
+		pos--
+	}
+
+	for pos < i {
+		advance, _, _ := SplitFunc(data[pos:], true) // can elide the error, see tests
+		if advance <= 0 {
+			return false
+		}
+		pos += advance
+	}
+
+	return pos == i
+}
+
+// knownBoundary determines if 0 < pos < len(data) is a boundary, by the
+// bytes on either side of it. It may return false for a boundary.
+func knownBoundary(data []byte, pos int) bool {
+	a, b := data[pos-1], data[pos]
+	if a == '\r' && b == '\n' {
+		// GB3
+		return false
+	}
+	if a < 0x20 || a == 0x7F || b < 0x20 || b == 0x7F {
+		// GB4 and GB5
+		return true
+	}
+	// ASCII characters are not Extend, SpacingMark, Prepend or ZWJ (GB9-GB9b),
+	// so they can only join one another by GB3
+	return a < 0x80 && b < 0x80
+}
@@ -0,0 +1,112 @@
+package graphemes
+
+import (
+	"github.com/clipperhouse/uax29/ansi"
+)
+
+// CellSegmenter is an iterator over the "cells" of text intended for a
+// terminal: grapheme clusters, with their display width and the style
+// (SGR attributes) set by preceding ANSI escape sequences.
+//
+// Escape sequences are not returned as tokens. SGR sequences update the
+// Style; other sequences, such as cursor movement, are skipped. Controls,
+// such as newlines, are returned, with a width of 0.
+type CellSegmenter struct {
+	data  []byte
+	token []byte
+	start int
+	pos   int
+	style ansi.Style
+}
+
+// NewCellSegmenter returns a CellSegmenter, which is an iterator over the cells
+// of the source text. Iterate while Next() is true, and access the current
+// cell's grapheme cluster via Bytes(), its width via Width(), and its style
+// via Style().
+func NewCellSegmenter(data []byte) *CellSegmenter {
+	seg := &CellSegmenter{}
+	seg.SetText(data)
+	return seg
+}
+
+// SetText sets the text for the segmenter to operate on, and resets all state,
+// including the style.
+func (seg *CellSegmenter) SetText(data []byte) {
+	seg.data = data
+	seg.token = nil
+	seg.start = 0
+	seg.pos = 0
+	seg.style = ansi.Style{}
+}
+
+// Reset rewinds the CellSegmenter to the start of the current text, and
+// resets all state, including the style.
+func (seg *CellSegmenter) Reset() {
+	seg.SetText(seg.data)
+}
+
+// Next advances CellSegmenter to the next cell. It returns false when there
+// are no remaining cells.
+func (seg *CellSegmenter) Next() bool {
+	for seg.pos < len(seg.data) {
+		data := seg.data[seg.pos:]
+
+		if n, _, _ := ansi.Parse(data, ansi.DefaultMaxLength, true); n > 0 {
+			seg.style.Apply(data[:n])
+			seg.pos += n
+			continue
+		}
+
+		advance, token, _ := SplitFunc(data, true) // can elide the error, see tests
+		if advance == 0 {
+			// Interpret as EOF, which should not happen
+			return false
+		}
+
+		seg.start = seg.pos
+		seg.pos += advance
+		seg.token = token
+		return true
+	}
+
+	return false
+}
+
+// Bytes returns the grapheme cluster of the current cell.
+func (seg *CellSegmenter) Bytes() []byte {
+	return seg.token
+}
+
+// Text returns the grapheme cluster of the current cell as a newly-allocated string.
+func (seg *CellSegmenter) Text() string {
+	return string(seg.token)
+}
+
+// Width returns the number of terminal columns that the current cell
+// will occupy, for a monospace font: 0, 1 or 2.
+func (seg *CellSegmenter) Width() int {
+	return cellWidth(seg.token)
+}
+
+// Presentation returns the presentation (text or emoji) requested by a
+// variation selector in the current cell, see [PresentationOf].
+func (seg *CellSegmenter) Presentation() Presentation {
+	return PresentationOf(seg.token)
+}
+
+// Style returns the style (SGR attributes) of the current cell, as set by
+// preceding escape sequences.
+func (seg *CellSegmenter) Style() ansi.Style {
+	return seg.style
+}
+
+// Start returns the position (byte index) of the current cell in the original text.
+func (seg *CellSegmenter) Start() int {
+	return seg.start
+}
+
+// End returns the position (byte index) of the first byte after the current
+// cell, in the original text.
+func (seg *CellSegmenter) End() int {
+	return seg.start + len(seg.token)
+}
@@ -0,0 +1,146 @@
+package graphemes_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/ansi"
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func TestCellSegmenter(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("\x1b[1;31mHi\x1b[0m 世界\x1b[2J❤️👍🏽🇺🇸é\n")
+
+	type cell struct {
+		text  string
+		width int
+		style ansi.Style
+	}
+
+	boldRed := ansi.Style{Bold: true, Foreground: ansi.Color{Type: ansi.IndexedColor, Index: 1}}
+
+	expected := []cell{
+		{"H", 1, boldRed},
+		{"i", 1, boldRed},
+		{" ", 1, ansi.Style{}},
+		{"世", 2, ansi.Style{}},
+		{"界", 2, ansi.Style{}},
+		{"❤️", 2, ansi.Style{}},
+		{"👍🏽", 2, ansi.Style{}},
+		{"🇺🇸", 2, ansi.Style{}},
+		{"é", 1, ansi.Style{}},
+		{"\n", 0, ansi.Style{}},
+	}
+
+	seg := graphemes.NewCellSegmenter(text)
+
+	var got []cell
+	for seg.Next() {
+		got = append(got, cell{seg.Text(), seg.Width(), seg.Style()})
+
+		if string(text[seg.Start():seg.End()]) != seg.Text() {
+			t.Fatal("start and end should match the cell")
+		}
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+
+	// Reset should rewind, including the style
+	seg.Reset()
+
+	got = nil
+	for seg.Next() {
+		got = append(got, cell{seg.Text(), seg.Width(), seg.Style()})
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("after Reset, expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestCellSegmenterRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	const runs = 2000
+
+	seg := graphemes.NewCellSegmenter(nil)
+
+	for i := 0; i < runs; i++ {
+		input := getRandomBytes()
+		seg.SetText(input)
+
+		// all bytes should be either in cells or in escape sequences
+		var n int
+		last := 0
+		for seg.Next() {
+			if seg.Start() < last {
+				t.Fatal("cells should not overlap")
+			}
+			for pos := last; pos < seg.Start(); {
+				l := ansi.SequenceLength(input[pos:])
+				if l == 0 {
+					t.Fatalf("bytes between cells should be escape sequences, got %q", input[pos:seg.Start()])
+				}
+				pos += l
+			}
+			n += len(seg.Bytes())
+			last = seg.End()
+		}
+
+		if n == 0 {
+			t.Fatal("expected some cells")
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected int
+	}
+
+	tests := []test{
+		{"", 0},
+		{"Hello", 5},
+		{"世界", 4},
+		{"ｈｉ", 4},      // fullwidth
+		{"e\u0301", 1}, // combining mark
+		{"\u0301", 0},  // lone combining mark
+		{"❤\uFE0F", 2}, // emoji presentation
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467", 2},          // ZWJ sequence
+		{"\U0001F44D\U0001F3FD", 2},                                // skin tone
+		{"\U0001F1FA\U0001F1F8", 2},                                // flag
+		{"1\uFE0F\u20E3", 2},                                       // keycap
+		{"#\uFE0F\u20E3", 2},                                       // keycap
+		{"#\u20E3", 1},                                             // keycap, text presentation
+		{"1\uFE0F\u20E32\uFE0F\u20E3", 4},                          // keycaps
+		{"a\tb\n", 2},                                              // controls
+		{"\x1b[1;31mHi\x1b[0m 世界", 7},                              // escape sequences
+		{"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", 4}, // hyperlink
+	}
+
+	for _, test := range tests {
+		got := graphemes.Width([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%q: expected %d, got %d", test.input, test.expected, got)
+		}
+	}
+
+	// Width should be the sum of the cells
+	text := []byte("\x1b[1;31mHi\x1b[0m 世界\x1b[2J❤\uFE0F1\uFE0F\u20E3\U0001F44D\U0001F3FD\U0001F1FA\U0001F1F8e\u0301\n")
+	seg := graphemes.NewCellSegmenter(text)
+
+	var sum int
+	for seg.Next() {
+		sum += seg.Width()
+	}
+	if got := graphemes.Width(text); got != sum {
+		t.Fatalf("expected %d, got %d", sum, got)
+	}
+}
@@ -0,0 +1,125 @@
+package graphemes
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// incb is the Indic_Conjunct_Break property, for GB9c, see
+// https://www.unicode.org/reports/tr44/#Indic_Conjunct_Break
+type incb uint8
+
+const (
+	incbNone incb = iota
+	incbConsonant
+	incbExtend
+	incbLinker
+)
+
+// The Indic_Conjunct_Break property, and GB9c, were added in Unicode 15.1,
+// while our generated data is 15.0, so the property is specified here by
+// hand, rather than generated from DerivedCoreProperties.txt. The package
+// is therefore a mix: 15.0 data, with one 15.1 rule.
+//
+// Linkers are the viramas of the scripts which form conjuncts, and
+// consonants are the consonants of those scripts, as in 15.1. InCB=Extend
+// is approximated, see incbOf.
+var (
+	linkers = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x094D, Hi: 0x094D, Stride: 1}, // Devanagari
+			{Lo: 0x09CD, Hi: 0x09CD, Stride: 1}, // Bengali
+			{Lo: 0x0ACD, Hi: 0x0ACD, Stride: 1}, // Gujarati
+			{Lo: 0x0B4D, Hi: 0x0B4D, Stride: 1}, // Oriya
+			{Lo: 0x0C4D, Hi: 0x0C4D, Stride: 1}, // Telugu
+			{Lo: 0x0D4D, Hi: 0x0D4D, Stride: 1}, // Malayalam
+		},
+	}
+
+	consonants = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			// Devanagari
+			{Lo: 0x0915, Hi: 0x0939, Stride: 1},
+			{Lo: 0x0958, Hi: 0x095F, Stride: 1},
+			{Lo: 0x0978, Hi: 0x097F, Stride: 1},
+			// Bengali
+			{Lo: 0x0995, Hi: 0x09A8, Stride: 1},
+			{Lo: 0x09AA, Hi: 0x09B0, Stride: 1},
+			{Lo: 0x09B2, Hi: 0x09B2, Stride: 1},
+			{Lo: 0x09B6, Hi: 0x09B9, Stride: 1},
+			{Lo: 0x09DC, Hi: 0x09DD, Stride: 1},
+			{Lo: 0x09DF, Hi: 0x09DF, Stride: 1},
+			{Lo: 0x09F0, Hi: 0x09F1, Stride: 1},
+			// Gujarati
+			{Lo: 0x0A95, Hi: 0x0AA8, Stride: 1},
+			{Lo: 0x0AAA, Hi: 0x0AB0, Stride: 1},
+			{Lo: 0x0AB2, Hi: 0x0AB3, Stride: 1},
+			{Lo: 0x0AB5, Hi: 0x0AB9, Stride: 1},
+			{Lo: 0x0AF9, Hi: 0x0AF9, Stride: 1},
+			// Oriya
+			{Lo: 0x0B15, Hi: 0x0B28, Stride: 1},
+			{Lo: 0x0B2A, Hi: 0x0B30, Stride: 1},
+			{Lo: 0x0B32, Hi: 0x0B33, Stride: 1},
+			{Lo: 0x0B35, Hi: 0x0B39, Stride: 1},
+			{Lo: 0x0B5C, Hi: 0x0B5D, Stride: 1},
+			{Lo: 0x0B5F, Hi: 0x0B5F, Stride: 1},
+			{Lo: 0x0B71, Hi: 0x0B71, Stride: 1},
+			// Telugu
+			{Lo: 0x0C15, Hi: 0x0C28, Stride: 1},
+			{Lo: 0x0C2A, Hi: 0x0C39, Stride: 1},
+			{Lo: 0x0C58, Hi: 0x0C5A, Stride: 1},
+			// Malayalam
+			{Lo: 0x0D15, Hi: 0x0D3A, Stride: 1},
+		},
+	}
+)
+
+// incbOf returns the Indic_Conjunct_Break property of the rune at the start
+// of data, whose grapheme property is p. InCB=Extend is approximated as
+// Extend or ZWJ, other than linkers; in 15.1, it is a subset of those, so a
+// few Extend characters which are not InCB=Extend will continue a conjunct
+// here, where the spec would break.
+func incbOf(data []byte, p property) incb {
+	// Optimization: consonants and linkers are in U+0900 through U+0D7F,
+	// which are encoded as E0 A4 80 through E0 B5 BF
+	if len(data) >= 3 && data[0] == 0xE0 && data[1] >= 0xA4 && data[1] <= 0xB5 {
+		r, _ := utf8.DecodeRune(data)
+		if unicode.Is(linkers, r) {
+			return incbLinker
+		}
+		if unicode.Is(consonants, r) {
+			return incbConsonant
+		}
+	}
+
+	if p.is(_Extend | _ZWJ) {
+		return incbExtend
+	}
+
+	return incbNone
+}
+
+// conjunct is the state of the GB9c pattern,
+// Consonant [Extend Linker]* Linker [Extend Linker]*
+type conjunct uint8
+
+const (
+	conjunctNone   conjunct = iota
+	conjunctBase            // Consonant [Extend Linker]*, without a Linker
+	conjunctLinked          // Consonant [Extend Linker]* Linker [Extend Linker]*
+)
+
+// next returns the state of the GB9c pattern after a rune of property c
+func (state conjunct) next(c incb) conjunct {
+	switch c {
+	case incbConsonant:
+		return conjunctBase
+	case incbLinker:
+		if state != conjunctNone {
+			return conjunctLinked
+		}
+	case incbExtend:
+		return state
+	}
+	return conjunctNone
+}
@@ -0,0 +1,59 @@
+package graphemes_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func TestConjuncts(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	// From https://www.unicode.org/Public/15.1.0/ucd/auxiliary/GraphemeBreakTest.txt
+	tests := []test{
+		// क्ष: consonant, virama, consonant
+		{"क्ष", []string{"क्ष"}},
+		// with ZWJ after the virama
+		{"क्\u200Dत", []string{"क्\u200Dत"}},
+		// with a nukta before the virama
+		{"क़्त", []string{"क़्त"}},
+		// multiple viramas
+		{"क््त", []string{"क््त"}},
+		// a chain of conjuncts
+		{"स्त्र", []string{"स्त्र"}},
+		// no virama
+		{"कत", []string{"क", "त"}},
+		// a vowel sign is not a linker
+		{"कित", []string{"कि", "त"}},
+		// a virama without a preceding consonant
+		{"्त", []string{"्", "त"}},
+		{"a्त", []string{"a्", "त"}},
+		// a virama followed by something other than a consonant
+		{"क् a", []string{"क्", " ", "a"}},
+		// Bengali, Malayalam
+		{"ক্ষ", []string{"ক্ষ"}},
+		{"ക്ക", []string{"ക്ക"}},
+		// Tamil is not InCB, its virama does not join
+		{"க்ஷ", []string{"க்", "ஷ"}},
+		// Hindi
+		{"नमस्ते", []string{"न", "म", "स्ते"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		seg := graphemes.NewSegmenter([]byte(test.input))
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("for %q, expected %q, got %q", test.input, test.expected, got)
+		}
+	}
+}
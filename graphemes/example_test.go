@@ -95,3 +95,42 @@ func ExampleNewScanner() {
 	// "👍"
 	// "🐶"
 }
+
+// CellSegmenter is for text intended for a terminal: it skips ANSI escape
+// sequences, tracks the style they set, and gives the display width of each
+// grapheme.
+func ExampleNewCellSegmenter() {
+	text := []byte("\x1b[1mHi\x1b[0m 世界 👍🏽")
+
+	seg := graphemes.NewCellSegmenter(text)
+
+	var total int
+	for seg.Next() {
+		fmt.Printf("%q width %d bold %t\n", seg.Text(), seg.Width(), seg.Style().Bold)
+		total += seg.Width()
+	}
+	fmt.Println("total width:", total)
+	// Output: "H" width 1 bold true
+	// "i" width 1 bold true
+	// " " width 1 bold false
+	// "世" width 2 bold false
+	// "界" width 2 bold false
+	// " " width 1 bold false
+	// "👍🏽" width 2 bold false
+	// total width: 10
+}
+
+// A Bitmap answers repeated queries about boundaries in O(1).
+func ExampleNewBitmap() {
+	text := []byte("e\u0301a") // e, a combining acute accent, and a
+
+	bitmap := graphemes.NewBitmap(text)
+	for i := 0; i <= len(text); i++ {
+		fmt.Println(i, bitmap.IsBoundary(i))
+	}
+	// Output: 0 true
+	// 1 false
+	// 2 false
+	// 3 true
+	// 4 true
+}
@@ -0,0 +1,14 @@
+package graphemes
+
+import "unicode/utf8"
+
+// IsExtendedPictographic determines if r has the Extended_Pictographic
+// property, per the Unicode data of this package, by which GB11 keeps emoji
+// ZWJ sequences together. It includes emoji, such as "😀" and "©", and code
+// points reserved for future emoji.
+func IsExtendedPictographic(r rune) bool {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	p, _ := trie.lookup(buf[:n])
+	return p.is(_ExtendedPictographic)
+}
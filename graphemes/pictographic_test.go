@@ -0,0 +1,28 @@
+package graphemes_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func TestIsExtendedPictographic(t *testing.T) {
+	t.Parallel()
+
+	tests := map[rune]bool{
+		'\U0001F600': true,  // grinning face
+		'\u00a9':     true,  // copyright
+		'\u2764':     true,  // heart
+		'\U0001FAFF': true,  // reserved for future emoji
+		'a':          false, // letter
+		'1':          false, // keycap base
+		'\U0001F3FD': false, // skin tone modifier, which is Extend
+		'\U0001F1FA': false, // regional indicator
+	}
+
+	for r, expected := range tests {
+		if got := graphemes.IsExtendedPictographic(r); got != expected {
+			t.Errorf("%U: expected %t, got %t", r, expected, got)
+		}
+	}
+}
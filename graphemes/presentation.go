@@ -0,0 +1,55 @@
+package graphemes
+
+import "bytes"
+
+// Presentation is the requested presentation style of a grapheme cluster,
+// as determined by a variation selector, see [PresentationOf].
+type Presentation uint8
+
+const (
+	// DefaultPresentation indicates that the cluster has no variation selector;
+	// the renderer should use the default presentation of the base character.
+	DefaultPresentation Presentation = iota
+	// TextPresentation indicates VS15 (U+FE0E), requesting text (monochrome) style.
+	TextPresentation
+	// EmojiPresentation indicates VS16 (U+FE0F), requesting emoji (color) style.
+	EmojiPresentation
+)
+
+var (
+	vs15 = []byte("\uFE0E")
+	vs16 = []byte("\uFE0F")
+)
+
+// PresentationOf returns the presentation requested by the last variation
+// selector (VS15 or VS16) in the grapheme cluster, so that a renderer can
+// choose a text or emoji font. Typically the selector ends the cluster, as in
+// "❤️", but it may precede a keycap or ZWJ, as in "1️⃣".
+//
+// It does not decode the cluster, and is intended for clusters returned by
+// a Segmenter or Scanner.
+func PresentationOf(cluster []byte) Presentation {
+	// Fast path: the cluster ends with a selector
+	if bytes.HasSuffix(cluster, vs16) {
+		return EmojiPresentation
+	}
+	if bytes.HasSuffix(cluster, vs15) {
+		return TextPresentation
+	}
+
+	// Both selectors are 3 bytes, differing only in the last
+	i := bytes.LastIndex(cluster, vs15[:2])
+	for i >= 0 {
+		if i+2 < len(cluster) {
+			switch cluster[i+2] {
+			case vs16[2]:
+				return EmojiPresentation
+			case vs15[2]:
+				return TextPresentation
+			}
+		}
+		i = bytes.LastIndex(cluster[:i], vs15[:2])
+	}
+
+	return DefaultPresentation
+}
@@ -0,0 +1,52 @@
+package graphemes_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func TestPresentationOf(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected graphemes.Presentation
+	}
+
+	tests := []test{
+		{"a", graphemes.DefaultPresentation},
+		{"", graphemes.DefaultPresentation},
+		{"❤", graphemes.DefaultPresentation},
+		{"❤️", graphemes.EmojiPresentation},
+		{"❤︎", graphemes.TextPresentation},
+		{"1️⃣", graphemes.EmojiPresentation},
+		{"1︎⃣", graphemes.TextPresentation},
+		{"👁️‍🗨️", graphemes.EmojiPresentation},
+		{"👍🏽", graphemes.DefaultPresentation},
+		{"︎️", graphemes.EmojiPresentation},
+		{"\xEF\xB8", graphemes.DefaultPresentation},
+	}
+
+	for _, test := range tests {
+		got := graphemes.PresentationOf([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%q: expected %d, got %d", test.input, test.expected, got)
+		}
+	}
+
+	// Every cluster from the segmenter should agree with the selectors it contains
+	seg := graphemes.NewSegmenter([]byte("Hi ❤️ ❤︎ #️⃣ 🏳️‍🌈"))
+	var emoji, text int
+	for seg.Next() {
+		switch graphemes.PresentationOf(seg.Bytes()) {
+		case graphemes.EmojiPresentation:
+			emoji++
+		case graphemes.TextPresentation:
+			text++
+		}
+	}
+	if emoji != 3 || text != 1 {
+		t.Errorf("expected 3 emoji and 1 text presentation, got %d and %d", emoji, text)
+	}
+}
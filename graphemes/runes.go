@@ -0,0 +1,45 @@
+package graphemes
+
+import "unicode/utf8"
+
+// FromRunes returns the grapheme cluster boundaries of runes, as indices into
+// runes. The first boundary is 0 and the last is len(runes), such that the
+// i'th grapheme cluster is runes[b[i]:b[i+1]]. It returns nil for empty input.
+//
+// It is intended for callers whose native representation is []rune, such as
+// editors. Invalid runes are segmented as though they were utf8.RuneError.
+func FromRunes(runes []rune) []int {
+	if len(runes) == 0 {
+		return nil
+	}
+
+	data := make([]byte, 0, len(runes))
+	for _, r := range runes {
+		data = utf8.AppendRune(data, r)
+	}
+
+	boundaries := []int{0}
+
+	// pos is the byte index into data; i is the corresponding rune index, and
+	// b is the byte index of runes[i]
+	var pos, i, b int
+	for pos < len(data) {
+		advance, _, _ := SplitFunc(data[pos:], true) // can elide the error, see tests
+		if advance == 0 {
+			break
+		}
+		pos += advance
+
+		for b < pos {
+			w := utf8.RuneLen(runes[i])
+			if w < 0 {
+				w = utf8.RuneLen(utf8.RuneError)
+			}
+			b += w
+			i++
+		}
+		boundaries = append(boundaries, i)
+	}
+
+	return boundaries
+}
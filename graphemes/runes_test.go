@@ -0,0 +1,54 @@
+package graphemes_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func TestFromRunesUnicode(t *testing.T) {
+	t.Parallel()
+
+	// From the Unicode test suite; see the gen/ folder.
+	for _, test := range unicodeTests {
+		runes := []rune(string(test.input))
+
+		var expected [][]rune
+		for _, token := range test.expected {
+			expected = append(expected, []rune(string(token)))
+		}
+
+		b := graphemes.FromRunes(runes)
+
+		var got [][]rune
+		for i := 0; i+1 < len(b); i++ {
+			got = append(got, runes[b[i]:b[i+1]])
+		}
+
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf(`
+	for input %v
+	expected  %v
+	got       %v
+	spec      %s`, runes, expected, got, test.comment)
+		}
+	}
+}
+
+func TestFromRunesInvalid(t *testing.T) {
+	t.Parallel()
+
+	// Surrogates and out-of-range runes are segmented as RuneError
+	runes := []rune{'a', 0xD800, 0x110000, 'e', 0x301}
+
+	got := graphemes.FromRunes(runes)
+	expected := []int{0, 1, 2, 3, 5}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	if graphemes.FromRunes(nil) != nil {
+		t.Fatal("expected nil for empty input")
+	}
+}
@@ -1,4 +1,9 @@
 // Package graphemes implements Unicode grapheme cluster boundaries: https://unicode.org/reports/tr29/#Grapheme_Cluster_Boundaries
+//
+// The data is Unicode 15.0.0, plus GB9c from Unicode 15.1, which keeps Indic
+// conjuncts together. Its Indic_Conjunct_Break property is specified by hand
+// for the six scripts which form conjuncts, and approximates InCB=Extend as
+// Extend or ZWJ, so a few rare sequences may differ from 15.1.
 package graphemes
 
 import (
@@ -44,6 +44,11 @@ func TestSegmenterUnicode(t *testing.T) {
 		if !reflect.DeepEqual(all, segmented) {
 			t.Error("calling SegmentAll should be identical to iterating Segmenter")
 		}
+
+		// And Count
+		if c := graphemes.Count(test.input); c != len(segmented) {
+			t.Errorf("calling Count should be identical to counting Segmenter, expected %d, got %d", len(segmented), c)
+		}
 	}
 
 	if len(unicodeTests) != passed+failed {
@@ -79,6 +84,43 @@ func TestSegmenterRoundtrip(t *testing.T) {
 	}
 }
 
+// TestSegmenterVertical tests Mongolian variation selectors and separators, and
+// presentation forms for vertical text, per GraphemeBreakProperty.txt
+func TestSegmenterVertical(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		// Free variation selectors FVS1-4 are Extend
+		{"\u182e\u180b\u1823", []string{"\u182e\u180b", "\u1823"}},
+		{"\u182e\u180d", []string{"\u182e\u180d"}},
+		{"\u182e\u180f", []string{"\u182e\u180f"}},
+		// The vowel separator (MVS) is Cf, i.e. Control
+		{"\u182e\u180e\u1820", []string{"\u182e", "\u180e", "\u1820"}},
+		// Narrow no-break space is not special
+		{"\u1828\u202f\u1833", []string{"\u1828", "\u202f", "\u1833"}},
+		// Vertical presentation forms are single clusters
+		{"\ufe41\u6587\ufe12\ufe42", []string{"\ufe41", "\u6587", "\ufe12", "\ufe42"}},
+		{"\ufe12\u0301", []string{"\ufe12\u0301"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		seg := graphemes.NewSegmenter([]byte(test.input))
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%+q: expected %+q, got %+q", test.input, test.expected, got)
+		}
+	}
+}
+
 func TestSegmenterInvalidUTF8(t *testing.T) {
 	t.Parallel()
 
@@ -157,6 +199,24 @@ func BenchmarkSegmentAll(b *testing.B) {
 	b.Logf("tokens %d, len %d, avg %d", c, len(file), len(file)/c)
 }
 
+func BenchmarkCount(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+
+	if err != nil {
+		b.Error(err)
+	}
+
+	c := graphemes.Count(file)
+	b.ResetTimer()
+	b.SetBytes(int64(len(file)))
+
+	for i := 0; i < b.N; i++ {
+		_ = graphemes.Count(file)
+	}
+
+	b.ReportMetric(float64(c), "tokens")
+}
+
 func BenchmarkUnicodeTests(b *testing.B) {
 	var buf bytes.Buffer
 	for _, test := range unicodeTests {
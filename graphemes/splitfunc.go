@@ -21,6 +21,7 @@ func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	var lastExIgnore property = 0     // "last excluding ignored categories"
 	var lastLastExIgnore property = 0 // "last one before that"
 	var regionalIndicatorCount int
+	var gb9c conjunct // the state of the GB9c pattern
 
 	// https://unicode.org/reports/tr29/#GB1
 	{
@@ -35,6 +36,9 @@ func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			return pos, data[:pos], nil
 		}
 
+		if data[pos] == 0xE0 {
+			gb9c = gb9c.next(incbOf(data[pos:], current))
+		}
 		pos += w
 	}
 
@@ -77,6 +81,15 @@ func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			return 0, nil, nil
 		}
 
+		// Optimization: consonants and linkers begin with E0, see incbOf
+		var cat incb
+		var linked bool
+		if gb9c != conjunctNone || data[pos] == 0xE0 {
+			cat = incbOf(data[pos:], current)
+			linked = gb9c == conjunctLinked
+			gb9c = gb9c.next(cat)
+		}
+
 		// Optimization: no rule can possibly apply
 		if current|last == 0 { // i.e. both are zero
 			break
@@ -131,11 +144,11 @@ func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 		}
 
 		// https://unicode.org/reports/tr29/#GB9c
-		// TODO(clipperhouse):
-		// It appears to be added in Unicode 15.1.0:
-		// https://unicode.org/versions/Unicode15.1.0/#Migration
-		// This package currently supports Unicode 15.0.0, so
-		// out of scope for now
+		// Added in Unicode 15.1.0, see conjunct.go
+		if cat == incbConsonant && linked {
+			pos += w
+			continue
+		}
 
 		// https://unicode.org/reports/tr29/#GB11
 		if current.is(_ExtendedPictographic) && last.is(_ZWJ) && lastLastExIgnore.is(_ExtendedPictographic) {
@@ -0,0 +1,104 @@
+package graphemes
+
+import "github.com/clipperhouse/uax29/ansi"
+
+// Truncate returns the text up to and including its n'th grapheme cluster,
+// so a cluster is never split. If the text has n clusters or fewer, it is
+// returned whole. The result is a subslice of data; it does not allocate.
+func Truncate(data []byte, n int) []byte {
+	pos := 0
+	for i := 0; i < n && pos < len(data); i++ {
+		advance, _, _ := SplitFunc(data[pos:], true) // can elide the error, see tests
+		if advance == 0 {
+			// Interpret as EOF, which should not happen
+			break
+		}
+		pos += advance
+	}
+	return data[:pos]
+}
+
+// TruncateToWidth returns the text truncated to fit in the given number of
+// terminal columns, per [Width], followed by ellipsis (such as "…"), and
+// never splits a grapheme cluster. If the text already fits, it is returned
+// whole, without ellipsis.
+//
+// A wide cluster which would straddle the limit is omitted, so the result
+// may be narrower than cells; use [PadRight] to fill it. ANSI escape
+// sequences occupy no columns, and those preceding the truncation are
+// retained. Note that a sequence which would reset the style might be
+// truncated.
+//
+// If the text does not fit, the result is newly allocated.
+func TruncateToWidth(data []byte, cells int, ellipsis []byte) []byte {
+	if Width(data) <= cells {
+		return data
+	}
+
+	limit := cells - Width(ellipsis)
+	if limit < 0 {
+		limit = 0
+	}
+
+	var pos, width int
+	for pos < len(data) {
+		if n, _, _ := ansi.Parse(data[pos:], ansi.DefaultMaxLength, true); n > 0 {
+			pos += n
+			continue
+		}
+
+		advance, token, _ := SplitFunc(data[pos:], true) // can elide the error, see tests
+		if advance == 0 {
+			// Interpret as EOF, which should not happen
+			break
+		}
+
+		w := cellWidth(token)
+		if width+w > limit {
+			break
+		}
+
+		width += w
+		pos += advance
+	}
+
+	result := make([]byte, 0, pos+len(ellipsis))
+	result = append(result, data[:pos]...)
+	return append(result, ellipsis...)
+}
+
+// PadRight returns the text followed by spaces, so that it occupies the given
+// number of terminal columns, per [Width]. If the text is already as wide or
+// wider, it is returned unchanged; otherwise, the result is newly allocated.
+func PadRight(data []byte, cells int) []byte {
+	n := cells - Width(data)
+	if n <= 0 {
+		return data
+	}
+
+	result := make([]byte, 0, len(data)+n)
+	result = append(result, data...)
+	return appendSpaces(result, n)
+}
+
+// PadLeft returns the text preceded by spaces, so that it occupies the given
+// number of terminal columns, per [Width]. If the text is already as wide or
+// wider, it is returned unchanged; otherwise, the result is newly allocated.
+func PadLeft(data []byte, cells int) []byte {
+	n := cells - Width(data)
+	if n <= 0 {
+		return data
+	}
+
+	result := make([]byte, 0, len(data)+n)
+	result = appendSpaces(result, n)
+	return append(result, data...)
+}
+
+// appendSpaces appends n spaces to dst
+func appendSpaces(dst []byte, n int) []byte {
+	for i := 0; i < n; i++ {
+		dst = append(dst, ' ')
+	}
+	return dst
+}
@@ -0,0 +1,120 @@
+package graphemes_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		n        int
+		expected string
+	}
+
+	tests := []test{
+		{"Hello", 3, "Hel"},
+		{"Hello", 5, "Hello"},
+		{"Hello", 10, "Hello"},
+		{"Hello", 0, ""},
+		{"", 3, ""},
+		{"e\u0301e\u0301e\u0301", 2, "e\u0301e\u0301"},
+		{"\U0001F1FA\U0001F1F8\U0001F1EC\U0001F1E7", 1, "\U0001F1FA\U0001F1F8"},
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467!", 1, "\U0001F468\u200D\U0001F469\u200D\U0001F467"},
+		{"a\r\nb", 2, "a\r\n"},
+	}
+
+	for _, test := range tests {
+		got := graphemes.Truncate([]byte(test.input), test.n)
+		if string(got) != test.expected {
+			t.Errorf("%q, %d: expected %q, got %q", test.input, test.n, test.expected, got)
+		}
+	}
+}
+
+func TestTruncateToWidth(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		cells    int
+		ellipsis string
+		expected string
+	}
+
+	tests := []test{
+		{"Hello, world", 20, "…", "Hello, world"},
+		{"Hello, world", 12, "…", "Hello, world"},
+		{"Hello, world", 8, "…", "Hello, …"},
+		{"Hello, world", 8, "", "Hello, w"},
+		{"Hello, world", 8, "...", "Hello..."},
+		{"Hello", 2, "...", "..."},
+		{"世界你好", 5, "…", "世界…"},
+		{"世界你好", 4, "…", "世…"},
+		{"世界你好", 4, "", "世界"},
+		{"a世界", 2, "", "a"}, // a wide cluster is not split across the limit
+		{"e\u0301e\u0301e\u0301", 2, "", "e\u0301e\u0301"},
+		{"\x1b[1mHello\x1b[0m, world", 5, "…", "\x1b[1mHell…"},
+	}
+
+	for _, test := range tests {
+		got := graphemes.TruncateToWidth([]byte(test.input), test.cells, []byte(test.ellipsis))
+		if string(got) != test.expected {
+			t.Errorf("%q, %d: expected %q, got %q", test.input, test.cells, test.expected, got)
+		}
+
+		if w := graphemes.Width(got); w > test.cells && test.ellipsis == "" {
+			t.Errorf("%q, %d: expected width at most %d, got %d", test.input, test.cells, test.cells, w)
+		}
+	}
+
+	// The result should not overwrite the text
+	text := []byte("Hello, world")
+	got := graphemes.TruncateToWidth(text[:5:5], 3, []byte("…"))
+	got[0] = 'J'
+	if text[0] != 'H' {
+		t.Fatal("TruncateToWidth should not alias the text")
+	}
+}
+
+func TestPad(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input string
+		cells int
+		right string
+		left  string
+	}
+
+	tests := []test{
+		{"abc", 5, "abc  ", "  abc"},
+		{"世界", 5, "世界 ", " 世界"},
+		{"e\u0301", 3, "e\u0301  ", "  e\u0301"},
+		{"\x1b[1mHi\x1b[0m", 3, "\x1b[1mHi\x1b[0m ", " \x1b[1mHi\x1b[0m"},
+		{"abc", 3, "abc", "abc"},
+		{"abcdef", 3, "abcdef", "abcdef"},
+	}
+
+	for _, test := range tests {
+		input := []byte(test.input)
+
+		got := graphemes.PadRight(input, test.cells)
+		if string(got) != test.right {
+			t.Errorf("%q, %d: expected %q, got %q", test.input, test.cells, test.right, got)
+		}
+
+		got = graphemes.PadLeft(input, test.cells)
+		if string(got) != test.left {
+			t.Errorf("%q, %d: expected %q, got %q", test.input, test.cells, test.left, got)
+		}
+
+		if !bytes.Equal(input, []byte(test.input)) {
+			t.Errorf("%q: the input should not be modified", test.input)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package graphemes
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/ansi"
+	"golang.org/x/text/width"
+)
+
+// Width returns the number of terminal columns that the text will occupy,
+// for a monospace font. It is the sum of the widths of its grapheme
+// clusters, as reported by [CellSegmenter.Width]: East Asian Wide and
+// Fullwidth clusters, emoji (including ZWJ sequences) and flags are 2
+// columns, combining marks and controls are 0, and others are 1. ANSI
+// escape sequences are skipped, and occupy no columns.
+//
+// Width does not account for tabs or line breaks; for multi-line text,
+// call it for each line.
+func Width(data []byte) int {
+	var result int
+	for pos := 0; pos < len(data); {
+		if n, _, _ := ansi.Parse(data[pos:], ansi.DefaultMaxLength, true); n > 0 {
+			pos += n
+			continue
+		}
+
+		advance, token, _ := SplitFunc(data[pos:], true) // can elide the error, see tests
+		if advance == 0 {
+			// Interpret as EOF, which should not happen
+			break
+		}
+
+		result += cellWidth(token)
+		pos += advance
+	}
+	return result
+}
+
+// cellWidth returns the number of terminal columns that the grapheme cluster
+// will occupy, for a monospace font: 0, 1 or 2.
+//
+// The width is determined by the first rune of the cluster, per its East
+// Asian Width property: Wide and Fullwidth are 2, others are 1. Exceptions:
+// controls, and lone combining marks and formats, are 0; emoji presentation
+// (VS16) and flags (regional indicator pairs) are 2.
+func cellWidth(cluster []byte) int {
+	r, _ := utf8.DecodeRune(cluster)
+
+	switch {
+	case r < 0x20, r >= 0x7F && r < 0xA0:
+		// control
+		return 0
+	case r < 0x7F:
+		// ASCII fast path, unless followed by VS16, as in a keycap
+		if bytes.HasPrefix(cluster[1:], vs16) {
+			return 2
+		}
+		return 1
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		// regional indicator, i.e. a flag
+		return 2
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+
+	if PresentationOf(cluster) == EmojiPresentation {
+		return 2
+	}
+
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+
+	return 1
+}
@@ -0,0 +1,58 @@
+// Package segtest provides test helpers which are shared by the segmentation
+// packages.
+package segtest
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"github.com/clipperhouse/uax29/iterators"
+	"github.com/clipperhouse/uax29/iterators/filter"
+)
+
+// AssertSegments asserts that split, and filter if not nil, segment input
+// into expected tokens, with a Segmenter, and with a Scanner which reads a
+// byte at a time, so that tokens span reads.
+func AssertSegments(t testing.TB, split bufio.SplitFunc, filter filter.Func, input []byte, expected []string) {
+	t.Helper()
+
+	seg := iterators.NewSegmenter(split)
+	seg.SetText(input)
+	seg.Filter(filter)
+
+	var got []string
+	for seg.Next() {
+		got = append(got, seg.Text())
+	}
+	if err := seg.Err(); err != nil {
+		t.Errorf("%q: %v", input, err)
+	}
+	if !equal(got, expected) {
+		t.Errorf("%q: expected %q, got %q", input, expected, got)
+	}
+
+	sc := iterators.NewScanner(iotest.OneByteReader(bytes.NewReader(input)), split)
+	sc.Filter(filter)
+
+	var scanned []string
+	for sc.Scan() {
+		scanned = append(scanned, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		t.Errorf("%q: scanner: %v", input, err)
+	}
+	if !equal(scanned, expected) {
+		t.Errorf("%q: scanner expected %q, got %q", input, expected, scanned)
+	}
+}
+
+// equal compares tokens, where nil and empty are equal
+func equal(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
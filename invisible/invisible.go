@@ -0,0 +1,114 @@
+// Package invisible identifies characters which do not render visibly, but
+// which may change the meaning or display of text, such as zero-width joiners
+// and bidirectional (bidi) controls. Such characters can be used for spoofing,
+// or to disguise source code, as in "Trojan Source" (CVE-2021-42574).
+package invisible
+
+import "strings"
+
+// Flags indicates which kinds of invisible characters a token contains, see [Of].
+type Flags uint8
+
+const (
+	// ZWJ indicates a zero width joiner (U+200D).
+	ZWJ Flags = 1 << iota
+	// ZWNJ indicates a zero width non-joiner (U+200C).
+	ZWNJ
+	// ZWSP indicates a zero width space (U+200B).
+	ZWSP
+	// Bidi indicates a bidirectional control: an embedding, override or
+	// isolate (U+202A–U+202E, U+2066–U+2069), or a mark (U+061C, U+200E, U+200F).
+	Bidi
+)
+
+// None indicates that a token contains no invisible characters.
+const None Flags = 0
+
+var flagNames = [...]string{"ZWJ", "ZWNJ", "ZWSP", "Bidi"}
+
+// Has determines if f includes all of flags.
+func (f Flags) Has(flags Flags) bool {
+	return f&flags == flags
+}
+
+func (f Flags) String() string {
+	if f == None {
+		return "None"
+	}
+
+	var names []string
+	for i, name := range flagNames {
+		if f.Has(1 << i) {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// Of returns the kinds of invisible characters in token. It is intended for
+// tokens returned by a Segmenter or Scanner, but works on text of any length.
+// It is fast: it examines only the lead bytes of potential matches.
+func Of(token []byte) Flags {
+	var result Flags
+
+	// All of the characters are encoded as D8 9C, E2 80 xx or E2 81 xx; the
+	// lead bytes D8 and E2 can't be continuation bytes, so we needn't decode
+	for i := 0; i < len(token)-1; i++ {
+		switch token[i] {
+		case 0xD8:
+			if token[i+1] == 0x9C {
+				// U+061C
+				result |= Bidi
+			}
+		case 0xE2:
+			if i+2 < len(token) {
+				result |= of(token[i+1], token[i+2])
+			}
+		}
+	}
+
+	return result
+}
+
+// of returns the flag for the rune encoded as E2 b1 b2
+func of(b1, b2 byte) Flags {
+	switch b1 {
+	case 0x80:
+		switch {
+		case b2 == 0x8B:
+			// U+200B
+			return ZWSP
+		case b2 == 0x8C:
+			// U+200C
+			return ZWNJ
+		case b2 == 0x8D:
+			// U+200D
+			return ZWJ
+		case b2 == 0x8E || b2 == 0x8F:
+			// U+200E, U+200F
+			return Bidi
+		case b2 >= 0xAA && b2 <= 0xAE:
+			// U+202A through U+202E
+			return Bidi
+		}
+	case 0x81:
+		if b2 >= 0xA6 && b2 <= 0xA9 {
+			// U+2066 through U+2069
+			return Bidi
+		}
+	}
+	return None
+}
+
+// IsBidiControl determines if r is a bidirectional control, see [Bidi].
+func IsBidiControl(r rune) bool {
+	switch {
+	case r == 0x061C, r == 0x200E, r == 0x200F:
+		return true
+	case r >= 0x202A && r <= 0x202E:
+		return true
+	case r >= 0x2066 && r <= 0x2069:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package invisible_test
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/invisible"
+)
+
+func TestOf(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected invisible.Flags
+	}
+
+	tests := []test{
+		{"", invisible.None},
+		{"hello", invisible.None},
+		{"日本語 👍🏽", invisible.None},
+		{"👩\u200D💻", invisible.ZWJ},
+		{"می\u200Cخواهم", invisible.ZWNJ},
+		{"foo\u200Bbar", invisible.ZWSP},
+		{"access\u202E\u2066level\u2069", invisible.Bidi},
+		{"a\u061Cb", invisible.Bidi},
+		{"a\u200Eb\u200F", invisible.Bidi},
+		{"\u200B\u200D", invisible.ZWSP | invisible.ZWJ},
+		// near misses
+		{"\u2010\u2065\u206A ", invisible.None},
+		// truncated
+		{"\xE2\x80", invisible.None},
+	}
+
+	for _, test := range tests {
+		got := invisible.Of([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%q: expected %s, got %s", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestIsBidiControl(t *testing.T) {
+	t.Parallel()
+
+	// Of should agree with IsBidiControl for every rune
+	for r := rune(0); r <= utf8.MaxRune; r++ {
+		if r >= 0xD800 && r <= 0xDFFF {
+			continue
+		}
+		got := invisible.Of([]byte(string(r))).Has(invisible.Bidi)
+		if got != invisible.IsBidiControl(r) {
+			t.Fatalf("%U: expected %t", r, invisible.IsBidiControl(r))
+		}
+	}
+}
+
+func TestFlagsString(t *testing.T) {
+	t.Parallel()
+
+	if s := invisible.None.String(); s != "None" {
+		t.Errorf("expected None, got %s", s)
+	}
+	if s := (invisible.ZWJ | invisible.Bidi).String(); s != "ZWJ|Bidi" {
+		t.Errorf("expected ZWJ|Bidi, got %s", s)
+	}
+}
@@ -0,0 +1,165 @@
+package invisible
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+// BidiSanitizer is a transform.Transformer which makes bidirectional (bidi)
+// controls safe, for rendering untrusted text. For use with the Transform
+// method of a Segmenter or Scanner, it sanitizes each token.
+//
+// By default, it strips all bidi controls. If Isolate is true, it instead
+// keeps controls which are properly terminated within the token, removes
+// unmatched terminators, and terminates any embeddings, overrides or isolates
+// which remain open at the end of the token, so that they can't affect the
+// display of subsequent text. Marks (U+061C, U+200E, U+200F) are kept.
+//
+// It records the position of each edit, so that a position in the
+// sanitized token can be mapped back to the original, see [BidiSanitizer.Source].
+// A BidiSanitizer is stateful, and not safe for concurrent use.
+type BidiSanitizer struct {
+	// Isolate specifies that bidi controls be terminated, rather than stripped.
+	Isolate bool
+
+	stack    []rune // open embeddings, overrides and isolates, for Isolate
+	edits    []edit
+	src, dst int // the number of bytes consumed and written, across calls to Transform
+}
+
+// edit is a removal or insertion of bytes, at src in the source, and dst in the result
+type edit struct {
+	src, dst          int
+	removed, inserted int
+}
+
+const (
+	pdf = '\u202C' // pop directional formatting
+	pdi = '\u2069' // pop directional isolate
+)
+
+// Reset implements transform.Transformer; it is called for each token.
+func (s *BidiSanitizer) Reset() {
+	s.stack = s.stack[:0]
+	s.edits = s.edits[:0]
+	s.src, s.dst = 0, 0
+}
+
+// Transform implements transform.Transformer.
+func (s *BidiSanitizer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	defer func() {
+		s.src += nSrc
+		s.dst += nDst
+	}()
+
+	for nSrc < len(src) {
+		// Optimization: bidi controls begin with D8 or E2, see Of
+		if b := src[nSrc]; b != 0xD8 && b != 0xE2 {
+			if nDst >= len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+			dst[nDst] = b
+			nDst++
+			nSrc++
+			continue
+		}
+
+		if !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		r, w := utf8.DecodeRune(src[nSrc:])
+
+		if IsBidiControl(r) && !s.keep(r) {
+			s.edits = append(s.edits, edit{src: s.src + nSrc, dst: s.dst + nDst, removed: w})
+			nSrc += w
+			continue
+		}
+
+		if nDst+w > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		copy(dst[nDst:], src[nSrc:nSrc+w])
+		nDst += w
+		nSrc += w
+	}
+
+	if !atEOF {
+		return nDst, nSrc, nil
+	}
+
+	// Terminate whatever remains open, innermost first
+	for len(s.stack) > 0 {
+		closer := pdf
+		if isIsolate(s.stack[len(s.stack)-1]) {
+			closer = pdi
+		}
+
+		w := utf8.RuneLen(closer)
+		if nDst+w > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		utf8.EncodeRune(dst[nDst:], closer)
+		s.edits = append(s.edits, edit{src: s.src + nSrc, dst: s.dst + nDst, inserted: w})
+		nDst += w
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+
+	return nDst, nSrc, nil
+}
+
+// keep determines if the bidi control r should be kept, and updates the
+// stack of open controls
+func (s *BidiSanitizer) keep(r rune) bool {
+	if !s.Isolate {
+		return false
+	}
+
+	switch r {
+	case 0x061C, 0x200E, 0x200F:
+		// Marks are harmless
+		return true
+	case pdf:
+		// Terminates an embedding or override, but not across an isolate
+		if n := len(s.stack); n > 0 && !isIsolate(s.stack[n-1]) {
+			s.stack = s.stack[:n-1]
+			return true
+		}
+		return false
+	case pdi:
+		// Terminates an isolate, and any embeddings within it
+		for i := len(s.stack) - 1; i >= 0; i-- {
+			if isIsolate(s.stack[i]) {
+				s.stack = s.stack[:i]
+				return true
+			}
+		}
+		return false
+	default:
+		s.stack = append(s.stack, r)
+		return true
+	}
+}
+
+// isIsolate determines if r is LRI, RLI or FSI
+func isIsolate(r rune) bool {
+	return r >= 0x2066 && r <= 0x2068
+}
+
+// Source maps pos, a position in the most recently sanitized token, to the
+// corresponding position in the original token. A position within an
+// inserted terminator maps to the end of the original token; a removed
+// control maps to the position following it.
+func (s *BidiSanitizer) Source(pos int) int {
+	delta := 0
+	for _, e := range s.edits {
+		if pos < e.dst {
+			break
+		}
+		if pos < e.dst+e.inserted {
+			return e.src + e.removed
+		}
+		delta = e.src + e.removed - (e.dst + e.inserted)
+	}
+	return pos + delta
+}
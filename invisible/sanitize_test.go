@@ -0,0 +1,109 @@
+package invisible_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/clipperhouse/uax29/invisible"
+	"github.com/clipperhouse/uax29/words"
+	"golang.org/x/text/transform"
+)
+
+func TestBidiSanitizer(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input   string
+		strip   string
+		isolate string
+		comment string
+	}
+
+	tests := []test{
+		{"hello", "hello", "hello", "no controls"},
+		{"a\u202Eb\u202Cc", "abc", "a\u202Eb\u202Cc", "terminated override"},
+		{"a\u202Eb", "ab", "a\u202Eb\u202C", "unterminated override"},
+		{"a\u2067b", "ab", "a\u2067b\u2069", "unterminated isolate"},
+		{"a\u2066\u202Bb\u2069c", "abc", "a\u2066\u202Bb\u2069c", "PDI terminates an embedding within an isolate"},
+		{"a\u202B\u2066b", "ab", "a\u202B\u2066b\u2069\u202C", "nested, innermost first"},
+		{"a\u202Cb\u2069c", "abc", "abc", "unmatched terminators"},
+		{"a\u2066\u202Cb", "ab", "a\u2066b\u2069", "PDF does not terminate across an isolate"},
+		{"a\u200Eb\u061C", "ab", "a\u200Eb\u061C", "marks"},
+		{"日本\u202E語", "日本語", "日本\u202E語\u202C", "multibyte"},
+	}
+
+	for _, test := range tests {
+		for _, isolate := range []bool{false, true} {
+			expected := test.strip
+			if isolate {
+				expected = test.isolate
+			}
+
+			s := &invisible.BidiSanitizer{Isolate: isolate}
+			got, _, err := transform.String(s, test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != expected {
+				t.Errorf("%s, isolate %t: expected %q, got %q", test.comment, isolate, expected, got)
+			}
+
+			// Source should map every byte of the result to the same byte of the
+			// input, other than inserted terminators, which map to the end
+			for i := 0; i < len(got); i++ {
+				j := s.Source(i)
+				if j == len(test.input) {
+					continue
+				}
+				if got[i] != test.input[j] {
+					t.Errorf("%s, isolate %t: position %d maps to %d, %q != %q", test.comment, isolate, i, j, got[i], test.input[j])
+				}
+			}
+			if s.Source(len(got)) != len(test.input) {
+				t.Errorf("%s, isolate %t: expected end to map to end", test.comment, isolate)
+			}
+		}
+	}
+}
+
+func TestBidiSanitizerShortDst(t *testing.T) {
+	t.Parallel()
+
+	// Exercise ErrShortDst, by way of a long input
+	input := strings.Repeat("ab\u202Ecd", 1000)
+	s := &invisible.BidiSanitizer{Isolate: true}
+
+	got, _, err := transform.String(s, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := input + strings.Repeat("\u202C", 1000)
+	if got != expected {
+		t.Error("expected all overrides to be terminated")
+	}
+}
+
+func TestBidiSanitizerSegmenter(t *testing.T) {
+	t.Parallel()
+
+	input := "if access\u202E\u2066 {"
+	s := &invisible.BidiSanitizer{}
+
+	seg := words.NewSegmenter([]byte(input))
+	seg.Transform(s)
+
+	var got []string
+	for seg.Next() {
+		got = append(got, seg.Text())
+
+		// Offsets remain those of the original text
+		if end := seg.Start() + s.Source(len(seg.Bytes())); end != seg.End() {
+			t.Errorf("expected %d, got %d", seg.End(), end)
+		}
+	}
+
+	expected := []string{"if", " ", "access", " ", "{"}
+	if strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !uax29_debug
+// +build !uax29_debug
+
+package iterators
+
+import "bufio"
+
+// checked is a no-op unless built with the uax29_debug tag.
+func checked(split bufio.SplitFunc) bufio.SplitFunc {
+	return split
+}
+
+// check is a no-op unless built with the uax29_debug tag.
+func (seg *Segmenter) check(prevEnd int) {}
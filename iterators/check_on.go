@@ -0,0 +1,60 @@
+//go:build uax29_debug
+// +build uax29_debug
+
+package iterators
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// checked wraps split with assertions of its invariants, which panic with a
+// description of the violation, see the uax29_debug build tag in the README
+func checked(split bufio.SplitFunc) bufio.SplitFunc {
+	if split == nil {
+		return nil
+	}
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := split(data, atEOF)
+		if err != nil {
+			return advance, token, err
+		}
+
+		switch {
+		case advance < 0:
+			panic(fmt.Sprintf("uax29: SplitFunc returned a negative advance %d, for %d bytes (atEOF %t)", advance, len(data), atEOF))
+		case advance > len(data):
+			panic(fmt.Sprintf("uax29: SplitFunc advanced %d bytes, beyond the %d bytes of data (atEOF %t)", advance, len(data), atEOF))
+		case advance == 0 && token != nil:
+			panic(fmt.Sprintf("uax29: SplitFunc returned a %d byte token without advancing, at %q", len(token), head(data)))
+		case advance == 0 && atEOF && len(data) > 0:
+			panic(fmt.Sprintf("uax29: SplitFunc did not advance at EOF, with %d bytes remaining: %q", len(data), head(data)))
+		case len(token) > advance:
+			panic(fmt.Sprintf("uax29: SplitFunc returned a %d byte token, longer than its advance %d, at %q", len(token), advance, head(data)))
+		case len(token) > 0 && &token[0] != &data[0]:
+			panic(fmt.Sprintf("uax29: SplitFunc returned a token which does not alias the start of data, at %q", head(data)))
+		}
+
+		return advance, token, err
+	}
+}
+
+// check asserts the invariants of the Segmenter after a token, see checked
+func (seg *Segmenter) check(prevEnd int) {
+	switch {
+	case seg.start < prevEnd:
+		panic(fmt.Sprintf("uax29: Segmenter token at %d overlaps the previous token, ending at %d", seg.start, prevEnd))
+	case seg.start > seg.end || seg.end > seg.pos || seg.pos > len(seg.data):
+		panic(fmt.Sprintf("uax29: Segmenter positions out of order: start %d, end %d, pos %d, len %d", seg.start, seg.end, seg.pos, len(seg.data)))
+	}
+}
+
+// head returns the start of data, for a panic message
+func head(data []byte) []byte {
+	const max = 32
+	if len(data) > max {
+		return data[:max]
+	}
+	return data
+}
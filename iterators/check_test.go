@@ -0,0 +1,96 @@
+//go:build uax29_debug
+// +build uax29_debug
+
+package iterators_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+func TestChecked(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		name  string
+		split func(data []byte, atEOF bool) (int, []byte, error)
+		panic string
+	}
+
+	tests := []test{
+		{
+			"too far",
+			func(data []byte, atEOF bool) (int, []byte, error) {
+				return len(data) + 1, data, nil
+			},
+			"beyond",
+		},
+		{
+			"zero at EOF",
+			func(data []byte, atEOF bool) (int, []byte, error) {
+				return 0, nil, nil
+			},
+			"did not advance at EOF",
+		},
+		{
+			"token without advance",
+			func(data []byte, atEOF bool) (int, []byte, error) {
+				return 0, data[:1], nil
+			},
+			"without advancing",
+		},
+		{
+			"long token",
+			func(data []byte, atEOF bool) (int, []byte, error) {
+				return 1, data[:2], nil
+			},
+			"longer than its advance",
+		},
+		{
+			"not aliased",
+			func(data []byte, atEOF bool) (int, []byte, error) {
+				return 1, []byte{data[0]}, nil
+			},
+			"does not alias",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				r := recover()
+				msg, _ := r.(string)
+				if !strings.Contains(msg, test.panic) {
+					t.Fatalf("expected a panic containing %q, got %v", test.panic, r)
+				}
+			}()
+
+			seg := iterators.NewSegmenter(test.split)
+			seg.SetText([]byte("Hello, world."))
+			for seg.Next() {
+			}
+		})
+
+		t.Run(test.name+" scanner", func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				r := recover()
+				msg, _ := r.(string)
+				if !strings.Contains(msg, test.panic) {
+					t.Fatalf("expected a panic containing %q, got %v", test.panic, r)
+				}
+			}()
+
+			sc := iterators.NewScanner(bytes.NewReader([]byte("Hello, world.")), test.split)
+			for sc.Scan() {
+			}
+		})
+	}
+}
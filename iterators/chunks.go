@@ -0,0 +1,44 @@
+package iterators
+
+import "bufio"
+
+// Chunks is a source of text in non-contiguous memory, such as a rope or gap
+// buffer. NextChunk returns the next chunk of text, and io.EOF when there
+// are no more chunks. Chunk boundaries are arbitrary: a chunk may end in the
+// middle of a token, or in the middle of a (multi-byte) rune.
+//
+// The returned chunk is only read until the next call to NextChunk, so it may
+// alias the source's internal memory.
+type Chunks interface {
+	NextChunk() ([]byte, error)
+}
+
+// NewChunkScanner creates a new Scanner given a Chunks source and a SplitFunc,
+// allowing text to be segmented without first copying it into contiguous memory.
+// To use the new scanner, iterate while Scan() is true.
+//
+// If your source is an io.ReaderAt, use NewReaderAtScanner instead.
+func NewChunkScanner(c Chunks, split bufio.SplitFunc) *Scanner {
+	return NewScanner(&chunkReader{chunks: c}, split)
+}
+
+// chunkReader adapts Chunks to an io.Reader
+type chunkReader struct {
+	chunks Chunks
+	// remainder of the current chunk
+	chunk []byte
+	err   error
+}
+
+func (cr *chunkReader) Read(p []byte) (int, error) {
+	for len(cr.chunk) == 0 {
+		if cr.err != nil {
+			return 0, cr.err
+		}
+		cr.chunk, cr.err = cr.chunks.NextChunk()
+	}
+
+	n := copy(p, cr.chunk)
+	cr.chunk = cr.chunk[n:]
+	return n, nil
+}
@@ -0,0 +1,76 @@
+package iterators_test
+
+import (
+	"bytes"
+	"io"
+	mathrand "math/rand"
+	"os"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+// rope is a simple Chunks source, which returns text in chunks of random sizes
+type rope struct {
+	chunks [][]byte
+}
+
+func newRope(text []byte) *rope {
+	r := &rope{}
+	for len(text) > 0 {
+		n := mathrand.Intn(64) + 1
+		if n > len(text) {
+			n = len(text)
+		}
+		r.chunks = append(r.chunks, text[:n])
+		text = text[n:]
+	}
+	return r
+}
+
+func (r *rope) NextChunk() ([]byte, error) {
+	if len(r.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := r.chunks[0]
+	r.chunks = r.chunks[1:]
+	return chunk, nil
+}
+
+func TestChunkScannerSameAsSegmenter(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, split := range splitFuncs {
+		seg := iterators.NewSegmenter(split)
+		seg.SetText(file)
+
+		sc := iterators.NewChunkScanner(newRope(file), split)
+
+		for seg.Next() {
+			if !sc.Scan() {
+				t.Fatal("chunk scanner returned fewer tokens than segmenter")
+			}
+			if !bytes.Equal(seg.Bytes(), sc.Bytes()) {
+				t.Fatalf(`
+				ChunkScanner and Segmenter should give identical results
+				ChunkScanner: %q
+				Segmenter:    %q
+				`, sc.Bytes(), seg.Bytes())
+			}
+		}
+		if sc.Scan() {
+			t.Fatal("chunk scanner returned more tokens than segmenter")
+		}
+		if seg.Err() != nil {
+			t.Fatal(seg.Err())
+		}
+		if sc.Err() != nil {
+			t.Fatal(sc.Err())
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package iterators_test
+
+import (
+	"fmt"
+
+	"github.com/clipperhouse/uax29/iterators"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+// Project maps annotations, such as named entities found by another tool, to
+// the tokens they cover.
+func ExampleProject() {
+	text := []byte("I flew to New York City on Monday")
+
+	seg := words.NewSegmenter(text)
+	seg.Filter(filter.Wordlike)
+
+	annotations := []iterators.Span{
+		{Start: 10, End: 23}, // New York City
+		{Start: 27, End: 30}, // Mon, a partial token
+	}
+
+	tokens, coverage := iterators.Project(seg.Segmenter, annotations)
+
+	for i, c := range coverage {
+		a := annotations[i]
+		fmt.Printf("%q: tokens %d to %d, partial %t\n", a.Slice(text), c.First, c.Last, c.Partial)
+		for _, t := range tokens[c.First : c.Last+1] {
+			fmt.Printf("\t%q\n", t.Slice(text))
+		}
+	}
+	// Output: "New York City": tokens 3 to 5, partial false
+	// 	"New"
+	// 	"York"
+	// 	"City"
+	// "Mon": tokens 7 to 7, partial true
+	// 	"Monday"
+}
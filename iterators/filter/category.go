@@ -0,0 +1,116 @@
+package filter
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Category is a set of Unicode general categories, as found in a token, see
+// [CategoriesOf].
+type Category uint8
+
+const (
+	// CategoryLetter is a letter (L)
+	CategoryLetter Category = 1 << iota
+	// CategoryMark is a combining mark (M)
+	CategoryMark
+	// CategoryNumber is a number (N)
+	CategoryNumber
+	// CategoryPunct is punctuation (P)
+	CategoryPunct
+	// CategorySymbol is a symbol (S), such as "$" or an emoji
+	CategorySymbol
+	// CategorySpace is whitespace, as defined by unicode.IsSpace
+	CategorySpace
+	// CategoryOther is anything else, such as a control, or invalid UTF-8,
+	// as distinct from U+FFFD, which is a symbol
+	CategoryOther
+)
+
+// Has determines if c includes any of the categories in mask.
+func (c Category) Has(mask Category) bool {
+	return c&mask != 0
+}
+
+// asciiCategories is a lookup table for the fast path
+var asciiCategories [utf8.RuneSelf]Category
+
+func init() {
+	for r := rune(0); r < utf8.RuneSelf; r++ {
+		asciiCategories[r] = categoryOf(r)
+	}
+}
+
+func categoryOf(r rune) Category {
+	switch {
+	case unicode.IsLetter(r):
+		return CategoryLetter
+	case unicode.IsMark(r):
+		return CategoryMark
+	case unicode.IsNumber(r):
+		return CategoryNumber
+	case unicode.IsPunct(r):
+		return CategoryPunct
+	case unicode.IsSymbol(r):
+		return CategorySymbol
+	case unicode.IsSpace(r):
+		return CategorySpace
+	}
+	return CategoryOther
+}
+
+// CategoriesOf returns the set of categories of the runes in token, in a
+// single pass, so that several properties of the token, such as whether it
+// has letters, or digits, can be tested with a mask, instead of a scan each.
+//
+//	c := filter.CategoriesOf(token)
+//	if c.Has(filter.CategoryLetter) && !c.Has(filter.CategoryNumber) { ... }
+func CategoriesOf(token []byte) Category {
+	var c Category
+	for pos := 0; pos < len(token); {
+		if b := token[pos]; b < utf8.RuneSelf {
+			c |= asciiCategories[b]
+			pos++
+			continue
+		}
+
+		r, w := utf8.DecodeRune(token[pos:])
+		if r == utf8.RuneError && w == 1 {
+			// Invalid UTF-8
+			c |= CategoryOther
+		} else {
+			c |= categoryOf(r)
+		}
+		pos += w
+	}
+	return c
+}
+
+// ContainsCategory returns a filter indicating that a token contains a rune
+// in any of the categories in mask. It stops at the first such rune. For
+// example, ContainsCategory(CategoryLetter|CategoryNumber) is equivalent to
+// AlphaNumeric, for valid UTF-8. Invalid UTF-8 is CategoryOther.
+func ContainsCategory(mask Category) Func {
+	return func(token []byte) bool {
+		for pos := 0; pos < len(token); {
+			if b := token[pos]; b < utf8.RuneSelf {
+				if asciiCategories[b]&mask != 0 {
+					return true
+				}
+				pos++
+				continue
+			}
+
+			r, w := utf8.DecodeRune(token[pos:])
+			c := CategoryOther // invalid UTF-8
+			if r != utf8.RuneError || w > 1 {
+				c = categoryOf(r)
+			}
+			if c&mask != 0 {
+				return true
+			}
+			pos += w
+		}
+		return false
+	}
+}
@@ -0,0 +1,85 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators/filter"
+)
+
+func TestCategoriesOf(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected filter.Category
+	}
+
+	tests := []test{
+		{"", 0},
+		{"Hello", filter.CategoryLetter},
+		{"世界", filter.CategoryLetter},
+		{"a1", filter.CategoryLetter | filter.CategoryNumber},
+		{"٣", filter.CategoryNumber},
+		{"e\u0301", filter.CategoryLetter | filter.CategoryMark},
+		{"can't", filter.CategoryLetter | filter.CategoryPunct},
+		{"$5", filter.CategorySymbol | filter.CategoryNumber},
+		{"👍", filter.CategorySymbol},
+		{" \t\u00a0", filter.CategorySpace},
+		{"\x00", filter.CategoryOther},
+		{"\xff", filter.CategoryOther},
+		{"\ufffd", filter.CategorySymbol},
+		{"—", filter.CategoryPunct},
+	}
+
+	for _, test := range tests {
+		got := filter.CategoriesOf([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%q: expected %08b, got %08b", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestContainsCategory(t *testing.T) {
+	t.Parallel()
+
+	// Valid UTF-8; AlphaNumeric and Wordlike decode invalid bytes as U+FFFD, a symbol
+	inputs := []string{"", "Hello", "a1", "123", "世界", ",", " ", "👍", "$", "e\u0301", "\ufffd", "\x1b[31m", "Hello, 世界"}
+
+	type test struct {
+		name     string
+		f        filter.Func
+		expected filter.Func
+	}
+
+	tests := []test{
+		{"AlphaNumeric", filter.ContainsCategory(filter.CategoryLetter | filter.CategoryNumber), filter.AlphaNumeric},
+		{"Wordlike", filter.ContainsCategory(filter.CategoryLetter | filter.CategoryNumber | filter.CategorySymbol), filter.Wordlike},
+	}
+
+	for _, test := range tests {
+		for _, input := range inputs {
+			token := []byte(input)
+			if got, expected := test.f(token), test.expected(token); got != expected {
+				t.Errorf("%s %q: expected %t, got %t", test.name, input, expected, got)
+			}
+
+			// Agrees with CategoriesOf
+			mask := filter.CategoryLetter | filter.CategoryNumber
+			if got, expected := filter.ContainsCategory(mask)(token), filter.CategoriesOf(token).Has(mask); got != expected {
+				t.Errorf("%q: expected %t, got %t", input, expected, got)
+			}
+		}
+	}
+}
+
+func TestContainsCategoryInvalid(t *testing.T) {
+	t.Parallel()
+
+	token := []byte("\xff")
+	if filter.ContainsCategory(filter.CategorySymbol)(token) {
+		t.Error("invalid UTF-8 should not be a symbol")
+	}
+	if !filter.ContainsCategory(filter.CategoryOther)(token) {
+		t.Error("invalid UTF-8 should be other")
+	}
+}
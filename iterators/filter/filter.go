@@ -9,6 +9,7 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/clipperhouse/uax29/ansi"
 	"github.com/clipperhouse/uax29/iterators/util"
 	"golang.org/x/text/unicode/rangetable"
 )
@@ -75,3 +76,70 @@ var Wordlike Func = func(token []byte) bool {
 
 	return false
 }
+
+// NoANSI is a filter which excludes tokens that are ANSI escape sequences,
+// such as terminal colors. It is intended for a segmenter with the default
+// ANSI options; for others, see [NoANSIWith].
+var NoANSI Func = NoANSIWith(0, false)
+
+// NoANSIWith returns a filter which excludes tokens that are ANSI escape
+// sequences, for a segmenter with the given options, such as words.ANSI.
+// Sequences longer than maxLength are segmented as ordinary text, and so are
+// not excluded; if maxLength is zero, ansi.DefaultMaxLength is used.
+//
+// If payloads is true, the payloads of OSC and DCS sequences are segmented
+// as text, and are not excluded, while their introducers and terminators are.
+func NoANSIWith(maxLength int, payloads bool) Func {
+	if maxLength <= 0 {
+		maxLength = ansi.DefaultMaxLength
+	}
+
+	return func(token []byte) bool {
+		if n, _, _ := ansi.Parse(token, maxLength, true); n > 0 && n == len(token) {
+			return false
+		}
+		if payloads {
+			// The terminator is BEL or ST; ST is a sequence of its own
+			if len(token) == 1 && token[0] == 0x07 || ansi.IsIntroducer(token) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// And returns a filter indicating that a token satisfies all of the given
+// filters. Filters are evaluated in order, and evaluation stops at the first
+// which is false. With no filters, it is always true.
+func And(filters ...Func) Func {
+	return func(token []byte) bool {
+		for _, f := range filters {
+			if !f(token) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a filter indicating that a token satisfies any of the given
+// filters. Filters are evaluated in order, and evaluation stops at the first
+// which is true. With no filters, it is always false.
+func Or(filters ...Func) Func {
+	return func(token []byte) bool {
+		for _, f := range filters {
+			if f(token) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a filter indicating that a token does not satisfy the given
+// filter. For example, Not(Entirely(unicode.White_Space)) excludes whitespace.
+func Not(f Func) Func {
+	return func(token []byte) bool {
+		return !f(token)
+	}
+}
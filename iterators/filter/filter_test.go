@@ -1,10 +1,12 @@
 package filter_test
 
 import (
+	"reflect"
 	"testing"
 	"unicode"
 
 	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
 )
 
 func TestContains(t *testing.T) {
@@ -61,3 +63,130 @@ func TestEntirely(t *testing.T) {
 		}
 	}
 }
+
+func TestNoANSI(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected bool
+	}
+
+	tests := []test{
+		{"Hello", true},
+		{"\x1b[31m", false},
+		{"\x1b]0;title\x07", false},
+		{"\x1b[31mHello", true},
+		{"\x1b", true},
+	}
+
+	for _, test := range tests {
+		got := filter.NoANSI([]byte(test.input))
+
+		if got != test.expected {
+			t.Errorf("%q: expected %t, got %t", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestNoANSIWith(t *testing.T) {
+	t.Parallel()
+
+	// The OSC sequence is 13 bytes, and the second CSI sequence is 19
+	input := []byte("\x1b]0;my title\x07 hi \x1b[31mred\x1b[38;2;255;105;180mX")
+
+	type test struct {
+		options  words.ANSI
+		expected []string
+	}
+
+	tests := []test{
+		{
+			words.ANSI{},
+			[]string{" ", "hi", " ", "red", "X"},
+		},
+		{
+			words.ANSI{Payloads: true},
+			[]string{"my", " ", "title", " ", "hi", " ", "red", "X"},
+		},
+		{
+			words.ANSI{MaxLength: 16, Payloads: true},
+			[]string{"my", " ", "title", " ", "hi", " ", "red", "\x1b", "[", "38;2;255;105;180mX"},
+		},
+		{
+			words.ANSI{MaxLength: 8},
+			[]string{"\x1b", "]", "0", ";", "my", " ", "title", "\a", " ", "hi", " ", "red", "\x1b", "[", "38;2;255;105;180mX"},
+		},
+	}
+
+	for _, test := range tests {
+		seg := words.NewSegmenter(input)
+		seg.ANSI(&test.options)
+		seg.Filter(filter.NoANSIWith(test.options.MaxLength, test.options.Payloads))
+
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%+v: expected %q, got %q", test.options, test.expected, got)
+		}
+	}
+}
+
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	space := filter.Entirely(unicode.White_Space)
+	punct := filter.Entirely(unicode.Punct)
+	upper := filter.Contains(unicode.Upper)
+
+	type test struct {
+		name     string
+		f        filter.Func
+		input    string
+		expected bool
+	}
+
+	tests := []test{
+		{"and", filter.And(filter.Wordlike, upper), "Hello", true},
+		{"and", filter.And(filter.Wordlike, upper), "hello", false},
+		{"and", filter.And(filter.Wordlike, upper), ",", false},
+		{"and none", filter.And(), "", true},
+		{"or", filter.Or(space, punct), " ", true},
+		{"or", filter.Or(space, punct), ",", true},
+		{"or", filter.Or(space, punct), "Hello", false},
+		{"or none", filter.Or(), "Hello", false},
+		{"not", filter.Not(space), " ", false},
+		{"not", filter.Not(space), "Hello", true},
+		{"not or", filter.Not(filter.Or(space, punct)), "Hello", true},
+		{"not or", filter.Not(filter.Or(space, punct)), " \t", false},
+		{"not or", filter.Not(filter.Or(space, punct)), "...", false},
+		{"not or", filter.Not(filter.Or(space, punct)), "👍", true},
+	}
+
+	for _, test := range tests {
+		got := test.f([]byte(test.input))
+
+		if got != test.expected {
+			t.Errorf("%s %q: expected %t, got %t", test.name, test.input, test.expected, got)
+		}
+	}
+}
+
+func TestComposeShortCircuits(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	never := func([]byte) bool {
+		called = true
+		return false
+	}
+
+	filter.And(filter.Not(filter.AlphaNumeric), never)([]byte("Hello"))
+	filter.Or(filter.AlphaNumeric, never)([]byte("Hello"))
+
+	if called {
+		t.Error("expected evaluation to stop at the first decisive filter")
+	}
+}
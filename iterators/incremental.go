@@ -0,0 +1,156 @@
+package iterators
+
+import (
+	"bufio"
+	"errors"
+)
+
+// ErrWriteAfterClose is returned when writing to an Incremental after Close.
+var ErrWriteAfterClose = errors.New("iterators: write after Close")
+
+// Incremental is a push-style iterator: the caller writes chunks of text as
+// they arrive, such as from a network protocol, and drains complete tokens
+// with Next. Chunk boundaries are arbitrary: a chunk may end in the middle of
+// a token, or in the middle of a (multi-byte) rune.
+//
+// The last token written is not complete until more text is written (which
+// might extend it), or Close is called. Therefore, Next may return false, and
+// then true after more text is written.
+//
+// Text is copied into a buffer held by the Incremental, and text which has
+// been returned as tokens is discarded on the next Write, so the buffer
+// holds only the incomplete token. A token is only valid until the next
+// Write; use AppendBytes or Text to retain it.
+//
+// Incremental implements io.WriteCloser, so it can be the destination of
+// io.Copy, though if you have an io.Reader, a Scanner is simpler.
+type Incremental struct {
+	split bufio.SplitFunc
+	buf   []byte
+	token []byte
+	// pos is the position in buf of the text which has not been returned as tokens
+	pos int
+	// offset is the number of bytes which have been discarded from the
+	// front of buf, so that Start and End are positions in the whole text
+	offset int
+	start  int
+	closed bool
+	err    error
+}
+
+// NewIncremental creates a new Incremental given a SplitFunc. To use it, call
+// Write with each chunk of text, then iterate while Next() is true. When
+// there is no more text, call Close, and iterate again for the last token.
+func NewIncremental(split bufio.SplitFunc) *Incremental {
+	return &Incremental{
+		split: split,
+	}
+}
+
+// Write appends p to the text. It returns ErrWriteAfterClose after Close.
+func (inc *Incremental) Write(p []byte) (int, error) {
+	if inc.closed {
+		return 0, ErrWriteAfterClose
+	}
+
+	// Discard the text which has been returned as tokens
+	if inc.pos > 0 {
+		n := copy(inc.buf, inc.buf[inc.pos:])
+		inc.buf = inc.buf[:n]
+		inc.offset += inc.pos
+		inc.pos = 0
+		inc.token = nil
+	}
+
+	inc.buf = append(inc.buf, p...)
+	return len(p), nil
+}
+
+// Close indicates that no more text will be written, so the last token is
+// complete, and will be returned by Next. It always returns nil.
+func (inc *Incremental) Close() error {
+	inc.closed = true
+	return nil
+}
+
+// Next advances Incremental to the next complete token. It returns false when
+// there is no complete token in the text written so far, or an error occurred.
+func (inc *Incremental) Next() bool {
+	for inc.err == nil && inc.pos < len(inc.buf) {
+		data := inc.buf[inc.pos:]
+		advance, token, err := inc.split(data, inc.closed)
+		if err != nil {
+			inc.err = err
+			return false
+		}
+
+		// Guardrails
+		if advance < 0 {
+			inc.err = ErrAdvanceNegative
+			return false
+		}
+		if advance > len(data) {
+			inc.err = ErrAdvanceTooFar
+			return false
+		}
+
+		// Token extends past the text so far, wait for more
+		if advance == 0 {
+			return false
+		}
+
+		inc.start = inc.offset + inc.pos
+		inc.pos += advance
+
+		if len(token) > 0 {
+			inc.token = token
+			return true
+		}
+		// The SplitFunc skipped some text without a token, continue
+	}
+
+	return false
+}
+
+// Reset discards all text and state, so the Incremental can be reused,
+// retaining its buffer and SplitFunc.
+func (inc *Incremental) Reset() {
+	*inc = Incremental{
+		split: inc.split,
+		buf:   inc.buf[:0],
+	}
+}
+
+// Err indicates an error occured when calling Next; Next will return false
+// when an error occurs.
+func (inc *Incremental) Err() error {
+	return inc.err
+}
+
+// Bytes returns the current token. It is only valid until the next Write.
+func (inc *Incremental) Bytes() []byte {
+	return inc.token
+}
+
+// AppendBytes appends the current token to dst, and returns the resulting
+// slice, so that the token may be retained across calls to Write.
+func (inc *Incremental) AppendBytes(dst []byte) []byte {
+	return append(dst, inc.token...)
+}
+
+// Text returns the current token as a newly-allocated string.
+func (inc *Incremental) Text() string {
+	return string(inc.token)
+}
+
+// Start returns the position (byte index) of the current token in the whole
+// text written so far.
+func (inc *Incremental) Start() int {
+	return inc.start
+}
+
+// End returns the position (byte index) of the first byte after the current
+// token, in the whole text written so far.
+func (inc *Incremental) End() int {
+	return inc.start + len(inc.token)
+}
@@ -0,0 +1,107 @@
+package iterators_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+func TestIncrementalSameAsSegmenter(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type token struct {
+		value      string
+		start, end int
+	}
+
+	for _, split := range splitFuncs {
+		var expected []token
+		seg := iterators.NewSegmenter(split)
+		seg.SetText(file)
+		for seg.Next() {
+			expected = append(expected, token{seg.Text(), seg.Start(), seg.End()})
+		}
+
+		var got []token
+		inc := iterators.NewIncremental(split)
+
+		// write in small pieces, which may split tokens and runes
+		for _, chunk := range newRope(file).chunks {
+			if _, err := inc.Write(chunk); err != nil {
+				t.Fatal(err)
+			}
+			for inc.Next() {
+				got = append(got, token{inc.Text(), inc.Start(), inc.End()})
+			}
+		}
+
+		if err := inc.Close(); err != nil {
+			t.Fatal(err)
+		}
+		for inc.Next() {
+			got = append(got, token{inc.Text(), inc.Start(), inc.End()})
+		}
+		if err := inc.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatal("Incremental and Segmenter should give identical results")
+		}
+	}
+}
+
+func TestIncrementalWaitsForCompleteToken(t *testing.T) {
+	t.Parallel()
+
+	inc := iterators.NewIncremental(splitFuncs[0]) // words
+	inc.Write([]byte("Hello wor"))
+
+	var got []string
+	for inc.Next() {
+		got = append(got, inc.Text())
+	}
+
+	// "wor" is incomplete
+	expected := []string{"Hello", " "}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+
+	inc.Write([]byte("ld"))
+	if inc.Next() {
+		t.Fatal("world is incomplete until Close")
+	}
+
+	inc.Close()
+	for inc.Next() {
+		got = append(got, inc.Text())
+		if inc.Start() != 6 || inc.End() != 11 {
+			t.Fatalf("expected world at 6-11, got %d-%d", inc.Start(), inc.End())
+		}
+	}
+
+	expected = []string{"Hello", " ", "world"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+
+	if _, err := inc.Write([]byte("!")); !errors.Is(err, iterators.ErrWriteAfterClose) {
+		t.Fatalf("expected ErrWriteAfterClose, got %v", err)
+	}
+
+	inc.Reset()
+	inc.Write([]byte("Hi"))
+	inc.Close()
+	if !inc.Next() || inc.Text() != "Hi" || inc.Start() != 0 {
+		t.Fatal("expected Reset to start over")
+	}
+}
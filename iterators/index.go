@@ -0,0 +1,120 @@
+package iterators
+
+import (
+	"bufio"
+	"encoding/binary"
+	"sort"
+)
+
+// indexBlock is the number of tokens between checkpoints of an Index, which
+// bounds the decoding per query
+const indexBlock = 64
+
+// Index is the token boundaries of a text, segmented once, for repeated
+// queries on the same text, as in an editor. It stores the length of each
+// token, delta-encoded as a varint (usually a single byte), with a checkpoint
+// every 64 tokens, so memory is a little over a byte per token, and queries
+// are O(log n). See [NewIndex].
+//
+// Boundaries are those of the SplitFunc, without filters or transforms.
+type Index struct {
+	// starts and offsets are the position in the text, and in deltas, of every
+	// indexBlock-th token
+	starts  []int
+	offsets []int
+	deltas  []byte
+	count   int
+	length  int
+}
+
+// NewIndex segments data with split, and returns an Index of the boundaries.
+func NewIndex(data []byte, split bufio.SplitFunc) *Index {
+	x := &Index{
+		length: len(data),
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+	pos := 0
+	for pos < len(data) {
+		advance, _, err := split(data[pos:], true)
+		if err != nil || advance <= 0 || pos+advance > len(data) {
+			// Shouldn't happen at EOF, but be safe against an infinite loop
+			advance = len(data) - pos
+		}
+
+		if x.count%indexBlock == 0 {
+			x.starts = append(x.starts, pos)
+			x.offsets = append(x.offsets, len(x.deltas))
+		}
+		n := binary.PutUvarint(buf[:], uint64(advance))
+		x.deltas = append(x.deltas, buf[:n]...)
+		x.count++
+
+		pos += advance
+	}
+
+	return x
+}
+
+// Count returns the number of tokens.
+func (x *Index) Count() int {
+	return x.count
+}
+
+// Len returns the length of the text, in bytes.
+func (x *Index) Len() int {
+	return x.length
+}
+
+// Nth returns the position of the i-th token, counting from zero. It returns
+// false if i is out of range.
+func (x *Index) Nth(i int) (Span, bool) {
+	if i < 0 || i >= x.count {
+		return Span{}, false
+	}
+
+	b := i / indexBlock
+	start, off := x.starts[b], x.offsets[b]
+	for j := b * indexBlock; ; j++ {
+		length, n := binary.Uvarint(x.deltas[off:])
+		if j == i {
+			return Span{Start: start, End: start + int(length)}, true
+		}
+		start += int(length)
+		off += n
+	}
+}
+
+// At returns the index of the token which contains the byte at pos, as for
+// Nth. It returns false if pos is outside the text.
+func (x *Index) At(pos int) (int, bool) {
+	if pos < 0 || pos >= x.length {
+		return -1, false
+	}
+
+	// The last checkpoint at or before pos
+	b := sort.SearchInts(x.starts, pos+1) - 1
+	start, off := x.starts[b], x.offsets[b]
+	for j := b * indexBlock; ; j++ {
+		length, n := binary.Uvarint(x.deltas[off:])
+		if pos < start+int(length) {
+			return j, true
+		}
+		start += int(length)
+		off += n
+	}
+}
+
+// IsBoundary returns whether pos is a boundary: 0, the end of each token, and
+// the length of the text. For empty text, there are no boundaries.
+func (x *Index) IsBoundary(pos int) bool {
+	if x.count == 0 || pos < 0 || pos > x.length {
+		return false
+	}
+	if pos == x.length {
+		return true
+	}
+	i, _ := x.At(pos)
+	span, _ := x.Nth(i)
+	return span.Start == pos
+}
@@ -0,0 +1,90 @@
+package iterators_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+func TestIndexSameAsSegmenter(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, split := range splitFuncs {
+		var spans []iterators.Span
+		seg := iterators.NewSegmenter(split)
+		seg.SetText(file)
+		for seg.Next() {
+			spans = append(spans, seg.Span())
+		}
+
+		x := iterators.NewIndex(file, split)
+		if x.Count() != len(spans) {
+			t.Fatalf("expected Count %d, got %d", len(spans), x.Count())
+		}
+		if x.Len() != len(file) {
+			t.Fatalf("expected Len %d, got %d", len(file), x.Len())
+		}
+
+		boundaries := map[int]bool{}
+		for i, expected := range spans {
+			got, ok := x.Nth(i)
+			if !ok || got != expected {
+				t.Fatalf("Nth(%d): expected %v, got %v", i, expected, got)
+			}
+			for pos := expected.Start; pos < expected.End; pos++ {
+				if got, ok := x.At(pos); !ok || got != i {
+					t.Fatalf("At(%d): expected %d, got %d", pos, i, got)
+				}
+			}
+			boundaries[expected.Start] = true
+			boundaries[expected.End] = true
+		}
+
+		for pos := -1; pos <= len(file)+1; pos++ {
+			if x.IsBoundary(pos) != boundaries[pos] {
+				t.Fatalf("IsBoundary(%d): expected %t", pos, boundaries[pos])
+			}
+		}
+
+		if _, ok := x.Nth(-1); ok {
+			t.Fatal("expected Nth(-1) to be out of range")
+		}
+		if _, ok := x.Nth(len(spans)); ok {
+			t.Fatal("expected Nth(Count) to be out of range")
+		}
+		if _, ok := x.At(len(file)); ok {
+			t.Fatal("expected At(Len) to be out of range")
+		}
+	}
+}
+
+func TestIndexEmpty(t *testing.T) {
+	t.Parallel()
+
+	x := iterators.NewIndex(nil, splitFuncs[0])
+	if x.Count() != 0 || x.IsBoundary(0) {
+		t.Fatal("expected no tokens or boundaries for empty text")
+	}
+	if _, ok := x.At(0); ok {
+		t.Fatal("expected At(0) to be out of range for empty text")
+	}
+}
+
+func BenchmarkIndexAt(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		b.Error(err)
+	}
+	x := iterators.NewIndex(file, splitFuncs[0])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.At(i % len(file))
+	}
+}
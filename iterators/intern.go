@@ -0,0 +1,109 @@
+package iterators
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// internShards is the number of independently locked parts of an Interner,
+// to reduce contention among goroutines
+const internShards = 16
+
+// Interner is a pool of strings, so that repeated tokens share a single
+// allocation, as when collecting the terms of many documents, where most
+// tokens are repeats. Use it with the Interner method of a Segmenter or
+// Scanner, so that Text returns interned strings, or call Intern directly.
+//
+// It is bounded by the total length of its strings; when full, arbitrary
+// strings are evicted to make room, so frequent tokens tend to remain. An
+// evicted string is not freed while the caller retains it. An Interner is
+// safe for concurrent use, and may be shared across Segmenters and Scanners.
+// A nil *Interner does not intern. This API is experimental.
+type Interner struct {
+	seed   maphash.Seed
+	shards [internShards]internShard
+}
+
+type internShard struct {
+	mu      sync.Mutex
+	strings map[string]string
+	size    int
+	max     int
+}
+
+// NewInterner returns an Interner, whose strings total at most maxBytes, not
+// counting the overhead of each string, which is a few dozen bytes. A token
+// longer than maxBytes/16 is not interned.
+func NewInterner(maxBytes int) *Interner {
+	in := &Interner{
+		seed: maphash.MakeSeed(),
+	}
+	for i := range in.shards {
+		in.shards[i].strings = make(map[string]string)
+		in.shards[i].max = maxBytes / internShards
+	}
+	return in
+}
+
+// Intern returns a string of b, which is shared with previous calls for the
+// same bytes, if it remains in the pool. It does not retain b.
+func (in *Interner) Intern(b []byte) string {
+	if in == nil || len(b) == 0 {
+		return string(b)
+	}
+
+	sh := &in.shards[maphash.Bytes(in.seed, b)%internShards]
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	// The compiler optimizes the conversion, without allocating
+	if s, ok := sh.strings[string(b)]; ok {
+		return s
+	}
+	if len(b) > sh.max {
+		return string(b)
+	}
+
+	// Evict arbitrary strings, as map iteration begins at a random position
+	for sh.size+len(b) > sh.max {
+		for k := range sh.strings {
+			delete(sh.strings, k)
+			sh.size -= len(k)
+			break
+		}
+	}
+
+	s := string(b)
+	sh.strings[s] = s
+	sh.size += len(s)
+	return s
+}
+
+// Len returns the number of strings in the pool.
+func (in *Interner) Len() int {
+	if in == nil {
+		return 0
+	}
+	n := 0
+	for i := range in.shards {
+		sh := &in.shards[i]
+		sh.mu.Lock()
+		n += len(sh.strings)
+		sh.mu.Unlock()
+	}
+	return n
+}
+
+// Reset empties the pool.
+func (in *Interner) Reset() {
+	if in == nil {
+		return
+	}
+	for i := range in.shards {
+		sh := &in.shards[i]
+		sh.mu.Lock()
+		sh.strings = make(map[string]string)
+		sh.size = 0
+		sh.mu.Unlock()
+	}
+}
@@ -0,0 +1,155 @@
+package iterators_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestInterner(t *testing.T) {
+	in := iterators.NewInterner(1 << 20)
+
+	hello := []byte("hello")
+	s := in.Intern(hello)
+	if s != "hello" {
+		t.Errorf("expected %q, got %q", "hello", s)
+	}
+
+	// The pool must not retain the caller's bytes
+	hello[0] = 'j'
+	if got := in.Intern([]byte("hello")); got != "hello" {
+		t.Errorf("expected %q, got %q", "hello", got)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		in.Intern([]byte("hello"))
+	})
+	if allocs != 0 {
+		t.Errorf("expected a repeated token not to allocate, got %f allocations", allocs)
+	}
+
+	if got := in.Intern(nil); got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+	if in.Len() != 1 {
+		t.Errorf("expected 1 string, got %d", in.Len())
+	}
+
+	in.Reset()
+	if in.Len() != 0 {
+		t.Errorf("expected 0 strings after Reset, got %d", in.Len())
+	}
+
+	var none *iterators.Interner
+	if got := none.Intern([]byte("hello")); got != "hello" {
+		t.Errorf("expected a nil Interner to return %q, got %q", "hello", got)
+	}
+	if none.Len() != 0 {
+		t.Error("expected a nil Interner to be empty")
+	}
+}
+
+func TestInternerBounded(t *testing.T) {
+	t.Parallel()
+
+	const max = 16 * 100
+	in := iterators.NewInterner(max)
+
+	for i := 0; i < 10_000; i++ {
+		s := fmt.Sprintf("token%05d", i) // 10 bytes
+		if got := in.Intern([]byte(s)); got != s {
+			t.Fatalf("expected %q, got %q", s, got)
+		}
+	}
+	if n := in.Len(); n > max/10 {
+		t.Errorf("expected at most %d strings, got %d", max/10, n)
+	}
+
+	// Longer than a shard
+	long := strings.Repeat("a", max)
+	if got := in.Intern([]byte(long)); got != long {
+		t.Error("expected a long token to be returned, though not interned")
+	}
+}
+
+func TestInternerConcurrent(t *testing.T) {
+	t.Parallel()
+
+	in := iterators.NewInterner(1 << 16)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 10_000; i++ {
+				s := fmt.Sprintf("t%d", (i*g)%5000)
+				if got := in.Intern([]byte(s)); got != s {
+					t.Errorf("expected %q, got %q", s, got)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestSegmenterInterner(t *testing.T) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected []string
+	seg := words.NewSegmenter(file)
+	for seg.Next() {
+		expected = append(expected, seg.Text())
+	}
+
+	in := iterators.NewInterner(1 << 20)
+
+	var got []string
+	seg.SetText(file)
+	seg.Interner(in)
+	for seg.Next() {
+		got = append(got, seg.Text())
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Error("expected interned Segmenter tokens to be identical")
+	}
+	if in.Len() == 0 || in.Len() >= len(got) {
+		t.Errorf("expected fewer distinct strings than %d tokens, got %d", len(got), in.Len())
+	}
+
+	got = got[:0]
+	sc := words.NewScanner(bytes.NewReader(file))
+	sc.Interner(in)
+	for sc.Scan() {
+		got = append(got, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Error("expected interned Scanner tokens to be identical")
+	}
+
+	// After the first pass, all tokens are in the pool
+	seg.SetText(file)
+	allocs := testing.AllocsPerRun(10, func() {
+		seg.Reset()
+		for seg.Next() {
+			_ = seg.Text()
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected interned tokens not to allocate, got %f allocations", allocs)
+	}
+}
@@ -7,19 +7,50 @@ import (
 	"iter"
 )
 
+// Token is a token (segment) yielded by Iter.
 type Token struct {
-	value []byte
+	value      []byte
+	start, end int
 }
 
+// Value returns the bytes of the token. It is not a copy: for a Segmenter, it
+// aliases the text passed to SetText, and for a Scanner, it aliases the
+// Scanner's internal buffer, which will be overwritten by subsequent tokens.
+// (An exception is when a Transform is applied, which allocates new bytes.)
+//
+// If you will modify or re-use the underlying text, or retain the token
+// beyond the current iteration of a Scanner, use ValueCopy.
 func (t Token) Value() []byte {
 	return t.value
 }
 
+// ValueCopy returns a newly-allocated copy of the bytes of the token, which
+// the caller owns. See Value.
+func (t Token) ValueCopy() []byte {
+	if t.value == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(t.value)), t.value...)
+}
+
+// Start returns the position (byte index) of the token in the original text.
+func (t Token) Start() int {
+	return t.start
+}
+
+// End returns the position (byte index) of the first byte after the token,
+// in the original text.
+func (t Token) End() int {
+	return t.end
+}
+
 // Iter is an iterator that yields the all of the tokens in the segmenter, for use with range
 func (seg *Segmenter) Iter() iter.Seq[Token] {
 	return func(yield func(Token) bool) {
 		for seg.Next() {
-			yield(Token{seg.Bytes()})
+			if !yield(Token{seg.Bytes(), seg.Start(), seg.End()}) {
+				return
+			}
 		}
 	}
 }
@@ -28,10 +59,89 @@ func (seg *Segmenter) Iter() iter.Seq[Token] {
 func (sc *Scanner) Iter() iter.Seq2[Token, error] {
 	return func(yield func(Token, error) bool) {
 		for sc.Scan() {
-			yield(Token{sc.Bytes()}, sc.Err()) // err should be nil here but yield anyway
+			if !yield(Token{sc.Bytes(), sc.Start(), sc.End()}, sc.Err()) { // err should be nil here but yield anyway
+				return
+			}
 		}
 		if sc.Err() != nil {
-			yield(Token{sc.Bytes()}, sc.Err()) // bytes should be irrelevant here but yield anyway
+			yield(Token{sc.Bytes(), sc.Start(), sc.End()}, sc.Err()) // bytes should be irrelevant here but yield anyway
+		}
+	}
+}
+
+// All returns an iterator over the remaining tokens, as with Bytes, for use
+// with range. It calls Next, so it composes with it: a loop which breaks
+// leaves the Segmenter at the last token, and Next or All will resume from
+// there. It does not allocate per token.
+func (seg *Segmenter) All() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		for seg.Next() {
+			if !yield(seg.Bytes()) {
+				return
+			}
+		}
+	}
+}
+
+// AllText returns an iterator over the remaining tokens, as strings, for use
+// with range. See [Segmenter.All]; as with Text, each string is allocated,
+// unless an Interner is set.
+func (seg *Segmenter) AllText() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for seg.Next() {
+			if !yield(seg.Text()) {
+				return
+			}
+		}
+	}
+}
+
+// Indexed returns an iterator over the remaining tokens, with the Index of
+// each, for use with range. See [Segmenter.All].
+func (seg *Segmenter) Indexed() iter.Seq2[int, []byte] {
+	return func(yield func(int, []byte) bool) {
+		for seg.Next() {
+			if !yield(seg.Index(), seg.Bytes()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the remaining tokens, as with Bytes, for use
+// with range. Check Err after the loop. It calls Scan, so it composes with
+// it: a loop which breaks leaves the Scanner at the last token, and Scan or
+// All will resume from there.
+func (sc *Scanner) All() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		for sc.Scan() {
+			if !yield(sc.Bytes()) {
+				return
+			}
+		}
+	}
+}
+
+// AllText returns an iterator over the remaining tokens, as strings, for use
+// with range. See [Scanner.All].
+func (sc *Scanner) AllText() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for sc.Scan() {
+			if !yield(sc.Text()) {
+				return
+			}
+		}
+	}
+}
+
+// Indexed returns an iterator over the remaining tokens, with the Index of
+// each, for use with range. See [Scanner.All].
+func (sc *Scanner) Indexed() iter.Seq2[int, []byte] {
+	return func(yield func(int, []byte) bool) {
+		for sc.Scan() {
+			if !yield(sc.Index(), sc.Bytes()) {
+				return
+			}
 		}
 	}
 }
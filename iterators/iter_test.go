@@ -7,9 +7,11 @@ import (
 	"errors"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/clipperhouse/uax29/iterators"
+	"github.com/clipperhouse/uax29/words"
 )
 
 func TestIterMatchesSegmenter(t *testing.T) {
@@ -112,3 +114,186 @@ func TestScannerIterErr(t *testing.T) {
 		}
 	}
 }
+
+func TestTokenValueAliases(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello world")
+
+	seg := iterators.NewSegmenter(words.SplitFunc)
+	seg.SetText(text)
+
+	var values, copies [][]byte
+	for token := range seg.Iter() {
+		values = append(values, token.Value())
+		copies = append(copies, token.ValueCopy())
+	}
+
+	// Modifying the underlying text...
+	copy(text, "Jello")
+
+	// ...is reflected in Value, which aliases it
+	if string(values[0]) != "Jello" {
+		t.Fatalf("Value should alias the text, expected %q, got %q", "Jello", values[0])
+	}
+
+	// ...but not in ValueCopy
+	if string(copies[0]) != "Hello" {
+		t.Fatalf("ValueCopy should not alias the text, expected %q, got %q", "Hello", copies[0])
+	}
+}
+
+func TestIterBreak(t *testing.T) {
+	t.Parallel()
+
+	seg := iterators.NewSegmenter(words.SplitFunc)
+	seg.SetText([]byte("Hello world"))
+
+	for range seg.Iter() {
+		break // should not panic
+	}
+
+	if seg.Text() != "Hello" {
+		t.Fatalf("expected the segmenter to stop at the first token, got %q", seg.Text())
+	}
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, world. How are you?")
+
+	var expected []string
+	seg := iterators.NewSegmenter(words.SplitFunc)
+	seg.SetText(text)
+	for seg.Next() {
+		expected = append(expected, seg.Text())
+	}
+
+	{
+		seg.SetText(text)
+		var got []string
+		for token := range seg.All() {
+			got = append(got, string(token))
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("All: expected %q, got %q", expected, got)
+		}
+	}
+
+	{
+		seg.SetText(text)
+		var got []string
+		for token := range seg.AllText() {
+			got = append(got, token)
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("AllText: expected %q, got %q", expected, got)
+		}
+	}
+
+	{
+		seg.SetText(text)
+		var got []string
+		for i, token := range seg.Indexed() {
+			if i != len(got) {
+				t.Fatalf("Indexed: expected index %d, got %d", len(got), i)
+			}
+			got = append(got, string(token))
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Indexed: expected %q, got %q", expected, got)
+		}
+	}
+
+	{
+		// Composes with Next
+		seg.SetText(text)
+		var got []string
+		for token := range seg.AllText() {
+			got = append(got, token)
+			if token == "world" {
+				break
+			}
+		}
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("composed: expected %q, got %q", expected, got)
+		}
+	}
+
+	{
+		sc := iterators.NewScanner(strings.NewReader(string(text)), words.SplitFunc)
+		var got []string
+		for i, token := range sc.Indexed() {
+			if i != len(got) {
+				t.Fatalf("Scanner Indexed: expected index %d, got %d", len(got), i)
+			}
+			got = append(got, string(token))
+		}
+		if err := sc.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Scanner Indexed: expected %q, got %q", expected, got)
+		}
+
+		sc.Reset(strings.NewReader(string(text)))
+		got = nil
+		for token := range sc.AllText() {
+			got = append(got, token)
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Scanner AllText: expected %q, got %q", expected, got)
+		}
+
+		sc.Reset(strings.NewReader(string(text)))
+		got = nil
+		for token := range sc.All() {
+			got = append(got, string(token))
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Scanner All: expected %q, got %q", expected, got)
+		}
+	}
+}
+
+func TestAllDoesNotAllocate(t *testing.T) {
+	text := []byte("Hello, world. How are you?")
+	seg := iterators.NewSegmenter(words.SplitFunc)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		seg.SetText(text)
+		for range seg.All() {
+		}
+	})
+	if allocs > 0 {
+		t.Fatalf("expected no allocations, got %f", allocs)
+	}
+}
+
+func TestTokenStartEnd(t *testing.T) {
+	t.Parallel()
+
+	text := "Hello, 世界. Nice dog!"
+
+	seg := iterators.NewSegmenter(words.SplitFunc)
+	seg.SetText([]byte(text))
+	for token := range seg.Iter() {
+		if text[token.Start():token.End()] != string(token.Value()) {
+			t.Fatalf("Segmenter: Start and End should match the token %q", token.Value())
+		}
+	}
+
+	sc := iterators.NewScanner(strings.NewReader(text), words.SplitFunc)
+	for token, err := range sc.Iter() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if text[token.Start():token.End()] != string(token.Value()) {
+			t.Fatalf("Scanner: Start and End should match the token %q", token.Value())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package iterators
+
+import "sort"
+
+// Coverage is the tokens covered by an annotation, see [Project].
+type Coverage struct {
+	// First and Last are the indices of the first and last tokens which the
+	// annotation overlaps, as in Segmenter.Index. If the annotation overlaps
+	// no tokens, both are -1.
+	First, Last int
+	// Partial indicates that the annotation does not align with token
+	// boundaries: the first or last token is only partly covered.
+	Partial bool
+}
+
+// Project maps annotations of the original text (such as named entities), as
+// byte ranges, to the tokens which they cover. It iterates seg to the end,
+// and returns the spans of all tokens, and the coverage of each annotation,
+// in the same order as annotations. Check seg.Err() after calling.
+//
+// Tokens and annotations are merged in a single pass. Annotations may be in
+// any order, and may overlap one another. Filtered tokens are not counted.
+func Project(seg *Segmenter, annotations []Span) (tokens []Span, coverage []Coverage) {
+	coverage = make([]Coverage, len(annotations))
+	for i := range coverage {
+		coverage[i] = Coverage{First: -1, Last: -1}
+	}
+
+	// Annotations in order of start
+	order := make([]int, len(annotations))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return annotations[order[i]].Start < annotations[order[j]].Start
+	})
+
+	var active []int // annotations which may overlap the current token
+	next := 0
+
+	for seg.Next() {
+		token := seg.Span()
+		index := len(tokens)
+		tokens = append(tokens, token)
+
+		for next < len(order) && annotations[order[next]].Start < token.End {
+			active = append(active, order[next])
+			next++
+		}
+
+		remaining := active[:0]
+		for _, a := range active {
+			annotation := annotations[a]
+
+			if token.Overlaps(annotation.Start, annotation.End) {
+				c := &coverage[a]
+				if c.First == -1 {
+					c.First = index
+				}
+				c.Last = index
+				if token.Start < annotation.Start || token.End > annotation.End {
+					c.Partial = true
+				}
+			}
+
+			// Keep it if it might overlap subsequent tokens
+			if annotation.End > token.End {
+				remaining = append(remaining, a)
+			}
+		}
+		active = remaining
+	}
+
+	return tokens, coverage
+}
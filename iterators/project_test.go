@@ -0,0 +1,71 @@
+package iterators_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestProject(t *testing.T) {
+	t.Parallel()
+
+	//               0         1         2         3
+	//               0123456789012345678901234567890123
+	text := []byte("Ada Lovelace met Charles Babbage.")
+
+	annotations := []iterators.Span{
+		{Start: 17, End: 32}, // Charles Babbage
+		{Start: 0, End: 12},  // Ada Lovelace
+		{Start: 4, End: 8},   // Love, partial
+		{Start: 0, End: 3},   // Ada, nested in the above
+		{Start: 13, End: 13}, // empty
+		{Start: 40, End: 50}, // out of range
+		{Start: 11, End: 19}, // across tokens, partial at both ends
+	}
+
+	expected := []iterators.Coverage{
+		{First: 6, Last: 8},
+		{First: 0, Last: 2},
+		{First: 2, Last: 2, Partial: true},
+		{First: 0, Last: 0},
+		{First: -1, Last: -1},
+		{First: -1, Last: -1},
+		{First: 2, Last: 6, Partial: true},
+	}
+
+	seg := words.NewSegmenter(text)
+	tokens, got := iterators.Project(seg.Segmenter, annotations)
+	if err := seg.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+
+	if string(tokens[6].Slice(text)) != "Charles" {
+		t.Fatalf("expected token 6 to be Charles, got %q", tokens[6].Slice(text))
+	}
+}
+
+func TestProjectFiltered(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Ada Lovelace met Charles Babbage.")
+
+	seg := words.NewSegmenter(text)
+	seg.Filter(filter.Wordlike)
+
+	tokens, got := iterators.Project(seg.Segmenter, []iterators.Span{{Start: 17, End: 32}})
+
+	expected := []iterators.Coverage{{First: 3, Last: 4}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+	if len(tokens) != 5 {
+		t.Fatalf("expected 5 tokens, got %d", len(tokens))
+	}
+}
@@ -0,0 +1,132 @@
+package iterators
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+// TokenReader presents the tokens of a Segmenter or Scanner, concatenated, as
+// an io.Reader and io.RuneScanner, so that APIs which consume a reader can be
+// given tokens without building an intermediate string. Tokens are those which
+// pass the filter of the Segmenter or Scanner, so that, for example, only
+// words are read, see [filter.Wordlike]. An optional separator is read between
+// tokens.
+//
+// Reading advances the underlying Segmenter or Scanner, which should not
+// otherwise be used while reading.
+type TokenReader struct {
+	next  func() bool
+	bytes func() []byte
+	err   func() error
+	sep   []byte
+	// current is the token or separator being read, from off, and queued is
+	// the token which follows a separator
+	current []byte
+	off     int
+	queued  []byte
+	// started indicates that a token has been read, so a separator precedes
+	// the next one
+	started bool
+	// last is the width of the most recent rune from ReadRune, for
+	// UnreadRune, or -1 if there is none
+	last int
+}
+
+func newTokenReader(next func() bool, bytes func() []byte, err func() error, sep string) *TokenReader {
+	return &TokenReader{
+		next:  next,
+		bytes: bytes,
+		err:   err,
+		sep:   []byte(sep),
+		last:  -1,
+	}
+}
+
+// Reader returns a TokenReader over the remaining tokens of the Segmenter,
+// with sep between them, which may be empty.
+func (seg *Segmenter) Reader(sep string) *TokenReader {
+	return newTokenReader(seg.Next, seg.Bytes, seg.Err, sep)
+}
+
+// Reader returns a TokenReader over the remaining tokens of the Scanner,
+// with sep between them, which may be empty.
+func (sc *Scanner) Reader(sep string) *TokenReader {
+	return newTokenReader(sc.Scan, sc.Bytes, sc.Err, sep)
+}
+
+// fill ensures that there is something left of current, advancing to the
+// next separator or token as necessary. It returns io.EOF, or the error of
+// the Segmenter or Scanner, when there are no more tokens.
+func (r *TokenReader) fill() error {
+	for r.off == len(r.current) {
+		if len(r.queued) > 0 {
+			r.current, r.off, r.queued = r.queued, 0, nil
+			continue
+		}
+		if !r.next() {
+			if err := r.err(); err != nil {
+				return err
+			}
+			return io.EOF
+		}
+		token := r.bytes()
+		if r.started && len(r.sep) > 0 {
+			r.current, r.queued = r.sep, token
+		} else {
+			r.current = token
+		}
+		r.off = 0
+		r.started = true
+	}
+	return nil
+}
+
+// Read reads up to len(p) bytes of the concatenated tokens into p.
+func (r *TokenReader) Read(p []byte) (int, error) {
+	r.last = -1
+
+	n := 0
+	for n < len(p) {
+		if err := r.fill(); err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		c := copy(p[n:], r.current[r.off:])
+		r.off += c
+		n += c
+	}
+	return n, nil
+}
+
+// ReadRune reads a single rune of the concatenated tokens. Invalid UTF-8 is
+// read as utf8.RuneError, with a size of 1.
+func (r *TokenReader) ReadRune() (rune, int, error) {
+	r.last = -1
+
+	if err := r.fill(); err != nil {
+		return 0, 0, err
+	}
+
+	// Tokens from the SplitFuncs in this module are whole runes, so a rune
+	// does not span tokens
+	ru, w := utf8.DecodeRune(r.current[r.off:])
+	r.off += w
+	r.last = w
+	return ru, w, nil
+}
+
+// UnreadRune unreads the most recent rune from ReadRune. It returns
+// bufio.ErrInvalidUnreadRune if the most recent read was not ReadRune.
+func (r *TokenReader) UnreadRune() error {
+	if r.last < 0 {
+		return bufio.ErrInvalidUnreadRune
+	}
+
+	// The rune is immediately before off, in the same token or separator
+	r.off -= r.last
+	r.last = -1
+	return nil
+}
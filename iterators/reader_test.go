@@ -0,0 +1,101 @@
+package iterators_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"testing/iotest"
+	"unicode"
+
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestTokenReader(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, 世界. Don’t panic!")
+
+	tests := []struct {
+		sep      string
+		expected string
+	}{
+		{"", "Hello世界Don’tpanic"},
+		{" ", "Hello 世 界 Don’t panic"},
+		{" | ", "Hello | 世 | 界 | Don’t | panic"},
+	}
+
+	for _, test := range tests {
+		seg := words.NewSegmenter(text)
+		seg.Filter(filter.Wordlike)
+		got, err := io.ReadAll(seg.Reader(test.sep))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+
+		sc := words.NewScanner(bytes.NewReader(text))
+		sc.Filter(filter.Wordlike)
+		got, err = io.ReadAll(iotest.OneByteReader(sc.Reader(test.sep)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != test.expected {
+			t.Errorf("Scanner: expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestTokenReaderRunes(t *testing.T) {
+	t.Parallel()
+
+	seg := words.NewSegmenter([]byte("Hi, 世界"))
+	seg.Filter(filter.Wordlike)
+	r := seg.Reader(" ")
+
+	if err := r.UnreadRune(); !errors.Is(err, bufio.ErrInvalidUnreadRune) {
+		t.Fatalf("expected ErrInvalidUnreadRune before ReadRune, got %v", err)
+	}
+
+	var got []rune
+	for {
+		ru, _, err := r.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, ru)
+
+		// Unread and read again, which should give the same rune
+		if err := r.UnreadRune(); err != nil {
+			t.Fatal(err)
+		}
+		again, _, _ := r.ReadRune()
+		if again != ru {
+			t.Fatalf("expected %q after UnreadRune, got %q", ru, again)
+		}
+	}
+
+	expected := "Hi 世 界"
+	if string(got) != expected {
+		t.Fatalf("expected %q, got %q", expected, string(got))
+	}
+
+	// A RuneScanner is accepted by, for example, fmt.Fscan
+	seg = words.NewSegmenter([]byte("Go, 1.23!"))
+	seg.Filter(filter.Contains(unicode.L, unicode.N))
+	var word, version string
+	if _, err := fmt.Fscan(seg.Reader(" "), &word, &version); err != nil {
+		t.Fatal(err)
+	}
+	if word != "Go" || version != "1.23" {
+		t.Fatalf("expected Go and 1.23, got %q and %q", word, version)
+	}
+}
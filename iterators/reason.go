@@ -0,0 +1,26 @@
+package iterators
+
+// BreakReason is the reason that a token ended, see [Scanner.BreakReason]
+// and [Segmenter.BreakReason]. This API is experimental.
+type BreakReason uint8
+
+const (
+	// SpecBoundary indicates that the token ended at a boundary per the
+	// SplitFunc, i.e. per the spec.
+	SpecBoundary BreakReason = iota
+	// ForcedMaxLength indicates that the token would have exceeded the maximum
+	// token size, and was broken, see [Scanner.BreakLongTokens]. The next token
+	// continues it, and would be joined to it by an unbounded buffer.
+	ForcedMaxLength
+	// EndOfInput indicates that the token ended at the end of the text.
+	EndOfInput
+)
+
+var breakReasonNames = [...]string{"SpecBoundary", "ForcedMaxLength", "EndOfInput"}
+
+func (r BreakReason) String() string {
+	if int(r) < len(breakReasonNames) {
+		return breakReasonNames[r]
+	}
+	return "Unknown"
+}
@@ -0,0 +1,18 @@
+package iterators
+
+import "unicode/utf8"
+
+// appendRunes appends the decoded runes of data to dst
+func appendRunes(dst []rune, data []byte) []rune {
+	for pos := 0; pos < len(data); {
+		if b := data[pos]; b < utf8.RuneSelf {
+			dst = append(dst, rune(b))
+			pos++
+			continue
+		}
+		r, w := utf8.DecodeRune(data[pos:])
+		dst = append(dst, r)
+		pos += w
+	}
+	return dst
+}
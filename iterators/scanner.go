@@ -3,6 +3,7 @@ package iterators
 import (
 	"bufio"
 	"io"
+	"unicode/utf8"
 
 	"github.com/clipperhouse/uax29/iterators/filter"
 	"golang.org/x/text/transform"
@@ -15,9 +16,23 @@ type Scanner struct {
 	s
 	// token overrides (hides) the token of the underlying bufio.Scanner
 	token       []byte
+	split       bufio.SplitFunc
 	filter      filter.Func
 	transformer transform.Transformer
+	interner    *Interner
+	trace       io.Writer
+	count       int
 	err         error
+	// buf and max are retained from Buffer, for Reset
+	buf []byte
+	max int
+	// breakLong is set by BreakLongTokens
+	breakLong bool
+	// reason is the BreakReason of the most recent token
+	reason BreakReason
+	// pos is the number of bytes consumed by the SplitFunc, and start and end
+	// are the position of the most recent token
+	pos, start, end int
 }
 
 // NewScanner creates a new Scanner given an io.Reader and bufio.SplitFunc. To use the new scanner,
@@ -26,19 +41,159 @@ func NewScanner(r io.Reader, split bufio.SplitFunc) *Scanner {
 	sc := &Scanner{
 		s: bufio.NewScanner(r),
 	}
-	sc.s.Split(split)
+	sc.Split(split)
 
 	return sc
 }
 
-// Bytes returns the current token, which results from calling Scan.
+// Split sets the SplitFunc for the Scanner. As with bufio.Scanner, it panics
+// if it is called after scanning has started.
+func (sc *Scanner) Split(split bufio.SplitFunc) {
+	sc.split = split
+	sc.s.Split(sc.traced(sc.positioned(checked(split))))
+}
+
+// positioned wraps split to track the position of each token in the
+// original text. As with Segmenter, it assumes that split does not skip
+// bytes before a token.
+func (sc *Scanner) positioned(split bufio.SplitFunc) bufio.SplitFunc {
+	if split == nil {
+		return nil
+	}
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := split(data, atEOF)
+		reason := SpecBoundary
+		if sc.breakLong && advance == 0 && err == nil && !atEOF && len(data) >= sc.maxTokenSize() {
+			// The token would exceed the maximum, break it, see BreakLongTokens
+			advance = lastRuneBoundary(data)
+			token = data[:advance]
+			reason = ForcedMaxLength
+		}
+		if token != nil {
+			sc.start = sc.pos
+			sc.end = sc.pos + len(token)
+			if atEOF && advance == len(data) {
+				reason = EndOfInput
+			}
+			sc.reason = reason
+		}
+		if advance > 0 {
+			sc.pos += advance
+		}
+		return advance, token, err
+	}
+}
+
+// Buffer sets the initial buffer and the maximum token size, as with
+// bufio.Scanner. The settings are retained by Reset.
+func (sc *Scanner) Buffer(buf []byte, max int) {
+	sc.buf, sc.max = buf, max
+	sc.s.Buffer(buf, max)
+}
+
+// BreakLongTokens forces a break in a token which would exceed the maximum
+// token size (see Buffer), rather than failing with bufio.ErrTooLong, such as
+// a very long sentence, or a line of text with no spaces. The token is broken
+// at the last rune boundary within the maximum, which is not a boundary per
+// the spec, so the remainder of the token is segmented as if it were the
+// start of the text. The setting is retained by Reset.
+func (sc *Scanner) BreakLongTokens() {
+	sc.breakLong = true
+}
+
+// BreakReason returns the reason that the current token ended: at a boundary
+// per the spec, at a break forced by BreakLongTokens, or at the end of the
+// input. A consumer might join a forced break to the subsequent token, say,
+// when reassembling text. This API is experimental.
+func (sc *Scanner) BreakReason() BreakReason {
+	return sc.reason
+}
+
+// maxTokenSize is the maximum token size of the underlying bufio.Scanner
+func (sc *Scanner) maxTokenSize() int {
+	if sc.max == 0 {
+		return bufio.MaxScanTokenSize
+	}
+	if cap(sc.buf) > sc.max {
+		return cap(sc.buf)
+	}
+	return sc.max
+}
+
+// lastRuneBoundary returns the position of the last rune boundary in data,
+// i.e. excluding a trailing incomplete rune
+func lastRuneBoundary(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if i > 0 && !utf8.FullRune(data[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(data)
+}
+
+// Reset sets the reader for the Scanner, and resets all state, so that it
+// can be reused, as from a sync.Pool. The SplitFunc, filter, transforms,
+// Interner, any Buffer settings and BreakLongTokens are retained.
+func (sc *Scanner) Reset(r io.Reader) {
+	sc.s = bufio.NewScanner(r)
+	sc.s.Split(sc.traced(sc.positioned(checked(sc.split))))
+	if sc.max > 0 {
+		sc.s.Buffer(sc.buf, sc.max)
+	}
+	sc.token = nil
+	sc.count = 0
+	sc.err = nil
+	sc.pos, sc.start, sc.end = 0, 0, 0
+	sc.reason = SpecBoundary
+}
+
+// Bytes returns the current token, which results from calling Scan. As with
+// bufio.Scanner, it is not a copy, and may be overwritten by the next call to Scan.
 func (sc *Scanner) Bytes() []byte {
 	return sc.token
 }
 
+// AppendBytes appends the current token to dst, and returns the result. Unlike
+// Bytes, the result will not be overwritten by the next call to Scan, and
+// unlike copying Bytes to a new slice, the caller controls allocation: dst
+// might be a reused buffer, or an arena of tokens.
+func (sc *Scanner) AppendBytes(dst []byte) []byte {
+	return append(dst, sc.token...)
+}
+
+// Runes decodes the current token into buf, reusing its capacity, and
+// returns the result. It allocates only if buf is too small, so a buffer
+// can be reused across tokens. Invalid UTF-8 is decoded as utf8.RuneError.
+func (sc *Scanner) Runes(buf []rune) []rune {
+	return appendRunes(buf[:0], sc.token)
+}
+
+// Categories returns the set of Unicode general categories of the runes in
+// the current token, see filter.CategoriesOf.
+func (sc *Scanner) Categories() filter.Category {
+	return filter.CategoriesOf(sc.token)
+}
+
 // Text returns the current token as a string, which results from calling Scan.
+// If an Interner is set, the string is shared with other tokens.
 func (sc *Scanner) Text() string {
-	return string(sc.token)
+	return sc.interner.Intern(sc.token)
+}
+
+// First returns up to n tokens, as would be returned by calling Scan, and
+// stops, so the rest of the reader is not read, beyond what is buffered.
+// Subsequent calls to Scan or First continue from there. Filters and
+// transforms are applied. Unlike Bytes, the tokens are copies, which will not
+// be overwritten by the next call to Scan. Check Err after calling.
+func (sc *Scanner) First(n int) [][]byte {
+	var result [][]byte
+	for len(result) < n && sc.Scan() {
+		result = append(result, sc.AppendBytes(nil))
+	}
+	return result
 }
 
 // Err returns any error that resulted from calling Scan.
@@ -50,6 +205,13 @@ func (sc *Scanner) Err() error {
 	return sc.s.Err()
 }
 
+// Interner sets a pool of strings for Text (and AllText), so that repeated
+// tokens share an allocation. A nil Interner, the default, allocates each
+// string.
+func (sc *Scanner) Interner(in *Interner) {
+	sc.interner = in
+}
+
 // Filter applies one or more filters (predicates) to all tokens, only returning those
 // where all filters evaluate true. Filters are applied after Transformers.
 func (sc *Scanner) Filter(filter filter.Func) {
@@ -84,8 +246,29 @@ func (sc *Scanner) Scan() bool {
 			continue
 		}
 
+		sc.count++
 		return true
 	}
 
 	return false
 }
+
+// Start returns the position (byte index) of the current token in the
+// original text, i.e. counting from the start of the reader.
+func (sc *Scanner) Start() int {
+	return sc.start
+}
+
+// End returns the position (byte index) of the first byte after the current
+// token, in the original text. If a Transform is applied, End - Start is the
+// length of the original token, which may differ from the length of Bytes.
+func (sc *Scanner) End() int {
+	return sc.end
+}
+
+// Index returns the ordinal (zero-based) of the current token. Tokens omitted
+// by a Filter are not counted. Index returns -1 if Scan has not yet returned
+// a token.
+func (sc *Scanner) Index() int {
+	return sc.count - 1
+}
@@ -4,8 +4,11 @@ import (
 	"bufio"
 	"bytes"
 	"crypto/rand"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/clipperhouse/uax29/graphemes"
 	"github.com/clipperhouse/uax29/iterators"
@@ -93,3 +96,288 @@ func TestScannerTransformIsApplied(t *testing.T) {
 		}
 	}
 }
+
+func TestScannerIndex(t *testing.T) {
+	t.Parallel()
+
+	text := "Hello, 世界, how are you? Nice dog aha! 👍🐶"
+	r := strings.NewReader(text)
+	sc := iterators.NewScanner(r, bufio.ScanWords)
+	sc.Filter(startsWithH)
+
+	if sc.Index() != -1 {
+		t.Fatalf("index should be -1 before calling Scan, got %d", sc.Index())
+	}
+
+	expected := 0
+	for sc.Scan() {
+		if sc.Index() != expected {
+			t.Fatalf("expected index %d, got %d", expected, sc.Index())
+		}
+		expected++
+	}
+
+	if expected != 2 {
+		t.Fatalf("scanner should have found 2 results, got %d", expected)
+	}
+}
+
+func TestScannerTraceIsTransparent(t *testing.T) {
+	t.Parallel()
+
+	text := "Hello, 世界, how are you? Nice dog aha! 👍🐶"
+
+	sc1 := iterators.NewScanner(strings.NewReader(text), words.SplitFunc)
+
+	var trace bytes.Buffer
+	sc2 := iterators.NewScanner(strings.NewReader(text), words.SplitFunc)
+	sc2.Trace(&trace)
+
+	for sc1.Scan() {
+		if !sc2.Scan() {
+			t.Fatal("traced scanner returned fewer tokens")
+		}
+		if !bytes.Equal(sc1.Bytes(), sc2.Bytes()) {
+			t.Fatalf("traced scanner should give identical results, expected %q, got %q", sc1.Bytes(), sc2.Bytes())
+		}
+	}
+	if sc2.Scan() {
+		t.Fatal("traced scanner returned more tokens")
+	}
+}
+
+func TestScannerReset(t *testing.T) {
+	t.Parallel()
+
+	sc := iterators.NewScanner(strings.NewReader("Hello, world"), words.SplitFunc)
+	sc.Filter(startsWithH)
+	sc.Transform(transformer.Lower)
+	sc.Buffer(make([]byte, 0, 2), 16)
+
+	for sc.Scan() {
+	}
+
+	texts := []string{"Hi, how are you?", "Hey, hello"}
+	expected := [][]string{{"hi", "how"}, {"hey", "hello"}}
+
+	for i, text := range texts {
+		sc.Reset(strings.NewReader(text))
+		if sc.Index() != -1 {
+			t.Fatalf("Reset should reset the index, got %d", sc.Index())
+		}
+
+		var got []string
+		for sc.Scan() {
+			got = append(got, sc.Text())
+		}
+		if err := sc.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Join(got, " ") != strings.Join(expected[i], " ") {
+			t.Fatalf("expected %q, got %q", expected[i], got)
+		}
+	}
+
+	// The Buffer settings should be retained
+	sc.Reset(strings.NewReader(strings.Repeat("a", 32)))
+	for sc.Scan() {
+	}
+	if sc.Err() != bufio.ErrTooLong {
+		t.Fatalf("expected ErrTooLong, got %v", sc.Err())
+	}
+}
+
+func TestScannerAppendBytes(t *testing.T) {
+	t.Parallel()
+
+	text := "Hello, 世界. Nice dog! 👍🐶"
+
+	sc := iterators.NewScanner(strings.NewReader(text), words.SplitFunc)
+	sc.Buffer(make([]byte, 0, 4), 1024)
+
+	var arena []byte
+	var ends []int
+	for sc.Scan() {
+		arena = sc.AppendBytes(arena)
+		ends = append(ends, len(arena))
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(arena) != text {
+		t.Fatalf("expected arena to contain the text, got %q", arena)
+	}
+
+	seg := words.NewSegmenter([]byte(text))
+	start := 0
+	for i := 0; seg.Next(); i++ {
+		if !bytes.Equal(arena[start:ends[i]], seg.Bytes()) {
+			t.Fatalf("expected token %q, got %q", seg.Bytes(), arena[start:ends[i]])
+		}
+		start = ends[i]
+	}
+}
+
+func TestScannerStartEnd(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, split := range splitFuncs {
+		seg := iterators.NewSegmenter(split)
+		seg.SetText(file)
+		seg.Filter(startsWithH)
+
+		sc := iterators.NewScanner(bytes.NewReader(file), split)
+		sc.Buffer(make([]byte, 0, 16), 64*1024)
+		sc.Filter(startsWithH)
+
+		for i := 0; i < 2; i++ {
+			for seg.Next() {
+				if !sc.Scan() {
+					t.Fatal("Scanner should have as many tokens as Segmenter")
+				}
+				if sc.Start() != seg.Start() || sc.End() != seg.End() {
+					t.Fatalf("expected Start and End %d:%d, got %d:%d", seg.Start(), seg.End(), sc.Start(), sc.End())
+				}
+				if !bytes.Equal(file[sc.Start():sc.End()], sc.Bytes()) {
+					t.Fatal("Start and End should match the token")
+				}
+			}
+			if sc.Scan() {
+				t.Fatal("Scanner should have as many tokens as Segmenter")
+			}
+
+			// Again, after Reset
+			seg.Reset()
+			sc.Reset(bytes.NewReader(file))
+		}
+	}
+}
+
+func TestScannerRunes(t *testing.T) {
+	t.Parallel()
+
+	text := "Hello, 世界. Nice dog! 👍🐶 e\u0301 \U0001F1FA\U0001F1F8"
+	sc := iterators.NewScanner(strings.NewReader(text), graphemes.SplitFunc)
+
+	var buf []rune
+	var got []rune
+	for sc.Scan() {
+		buf = sc.Runes(buf)
+		expected := []rune(sc.Text())
+		if !reflect.DeepEqual(buf, expected) {
+			t.Fatalf("expected %q, got %q", expected, buf)
+		}
+		got = append(got, buf...)
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != text {
+		t.Fatalf("expected %q, got %q", text, string(got))
+	}
+}
+
+func TestScannerFirst(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, 世界. Nice dog! 👍🐶")
+	all := words.SegmentAll(text)
+
+	for _, n := range []int{0, 1, 3, len(all), len(all) + 10} {
+		// A small buffer, to ensure tokens are copied before being overwritten
+		sc := words.NewScanner(bytes.NewReader(text))
+		sc.Buffer(make([]byte, 0, 4), 64)
+		got := sc.First(n)
+		if err := sc.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := all
+		if n < len(all) {
+			expected = all[:n]
+		}
+		if len(got) != len(expected) {
+			t.Fatalf("n %d: expected %d tokens, got %d", n, len(expected), len(got))
+		}
+		for i := range got {
+			if !bytes.Equal(got[i], expected[i]) {
+				t.Errorf("n %d: expected %q, got %q", n, expected[i], got[i])
+			}
+		}
+	}
+}
+
+func TestScannerBreakLongTokens(t *testing.T) {
+	t.Parallel()
+
+	// A very long sentence, of multi-byte runes
+	text := strings.Repeat("世界", 10000) + "。 Next."
+	const max = 1024
+
+	sc := iterators.NewScanner(strings.NewReader(text), sentences.SplitFunc)
+	sc.Buffer(make([]byte, 0, 64), max)
+	for sc.Scan() {
+	}
+	if sc.Err() != bufio.ErrTooLong {
+		t.Fatalf("expected ErrTooLong, got %v", sc.Err())
+	}
+
+	sc.BreakLongTokens()
+
+	for i := 0; i < 2; i++ {
+		sc.Reset(strings.NewReader(text))
+
+		var output []byte
+		var last string
+		var reasons []iterators.BreakReason
+		for sc.Scan() {
+			token := sc.Bytes()
+			if len(token) > max {
+				t.Fatalf("expected tokens of at most %d bytes, got %d", max, len(token))
+			}
+			reasons = append(reasons, sc.BreakReason())
+			if !utf8.Valid(token) {
+				t.Fatalf("expected tokens to be broken at rune boundaries, got %q", token)
+			}
+			if text[sc.Start():sc.End()] != string(token) {
+				t.Fatal("Start and End should match the token")
+			}
+			output = append(output, token...)
+			last = sc.Text()
+		}
+		if err := sc.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if string(output) != text {
+			t.Fatal("input bytes are not the same as scanned bytes")
+		}
+		if last != "Next." {
+			t.Fatalf("expected segmentation to resume after the long token, got %q", last)
+		}
+
+		// 60,000 bytes of the long sentence are broken into tokens of at most
+		// max bytes, whose remainder ends at a boundary
+		forced := 60000 / (max - max%3)
+		for j, reason := range reasons {
+			expected := iterators.SpecBoundary
+			switch {
+			case j < forced:
+				expected = iterators.ForcedMaxLength
+			case j == len(reasons)-1:
+				expected = iterators.EndOfInput
+			}
+			if reason != expected {
+				t.Fatalf("token %d: expected %s, got %s", j, expected, reason)
+			}
+		}
+	}
+}
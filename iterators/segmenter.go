@@ -21,10 +21,16 @@ type Segmenter struct {
 	split       bufio.SplitFunc
 	filter      filter.Func
 	transformer transform.Transformer
+	interner    *Interner
 	data        []byte
 	token       []byte
 	start       int
+	end         int
 	pos         int
+	count       int
+	prev        []byte
+	prevStart   int
+	prevEnd     int
 	err         error
 }
 
@@ -36,7 +42,7 @@ type Segmenter struct {
 // bringing your own SplitFunc.
 func NewSegmenter(split bufio.SplitFunc) *Segmenter {
 	return &Segmenter{
-		split: split,
+		split: checked(split),
 	}
 }
 
@@ -45,13 +51,33 @@ func NewSegmenter(split bufio.SplitFunc) *Segmenter {
 func (seg *Segmenter) SetText(data []byte) {
 	seg.data = data
 	seg.token = nil
+	seg.start = 0
+	seg.end = 0
 	seg.pos = 0
+	seg.count = 0
+	seg.prev = nil
+	seg.prevStart = 0
+	seg.prevEnd = 0
 	seg.err = nil
 }
 
+// Reset rewinds the Segmenter to the start of the current text, and resets
+// all state. The SplitFunc, filter, transforms and Interner are retained. To reuse a
+// Segmenter for new text, as from a sync.Pool, use SetText.
+func (seg *Segmenter) Reset() {
+	seg.SetText(seg.data)
+}
+
 // Split sets the SplitFunc for the Segmenter
 func (seg *Segmenter) Split(split bufio.SplitFunc) {
-	seg.split = split
+	seg.split = checked(split)
+}
+
+// Interner sets a pool of strings for Text (and AllText), so that repeated
+// tokens share an allocation. A nil Interner, the default, allocates each
+// string.
+func (seg *Segmenter) Interner(in *Interner) {
+	seg.interner = in
 }
 
 // Filter applies a filter (predicate) to all tokens, returning only those
@@ -74,6 +100,8 @@ var ErrAdvanceTooFar = errors.New("SplitFunc advanced beyond the end of the data
 // Next advances Segmenter to the next token (segment). It returns false when there
 // are no remaining segments, or an error occurred.
 func (seg *Segmenter) Next() bool {
+	current, currentStart, currentEnd := seg.token, seg.start, seg.end
+
 	for seg.pos < len(seg.data) {
 		seg.start = seg.pos
 
@@ -106,6 +134,9 @@ func (seg *Segmenter) Next() bool {
 			return false
 		}
 
+		// End is the position in the original text, before any transform
+		seg.end = seg.start + len(seg.token)
+
 		if seg.transformer != nil {
 			seg.token, _, seg.err = transform.Bytes(seg.transformer, seg.token)
 			if seg.err != nil {
@@ -117,6 +148,18 @@ func (seg *Segmenter) Next() bool {
 			continue
 		}
 
+		if seg.count > 0 {
+			seg.prev = current
+			seg.prevStart = currentStart
+			seg.prevEnd = currentEnd
+		} else {
+			seg.prev = nil
+			seg.prevStart = 0
+			seg.prevEnd = 0
+		}
+
+		seg.count++
+		seg.check(currentEnd)
 		return true
 	}
 
@@ -129,14 +172,51 @@ func (seg *Segmenter) Err() error {
 	return seg.err
 }
 
-// Bytes returns the current token.
+// Bytes returns the current token. It is not a copy; it aliases the text
+// passed to SetText, so modifying that text will modify the token. (An
+// exception is when a Transform is applied, which allocates new bytes.)
 func (seg *Segmenter) Bytes() []byte {
 	return seg.token
 }
 
-// Text returns the current token as a newly-allocated string.
+// AppendBytes appends the current token to dst, and returns the result, which
+// does not alias the text passed to SetText. The caller controls allocation:
+// dst might be a reused buffer, or an arena of tokens.
+func (seg *Segmenter) AppendBytes(dst []byte) []byte {
+	return append(dst, seg.token...)
+}
+
+// Runes decodes the current token into buf, reusing its capacity, and
+// returns the result. It allocates only if buf is too small, so a buffer
+// can be reused across tokens. Invalid UTF-8 is decoded as utf8.RuneError.
+func (seg *Segmenter) Runes(buf []rune) []rune {
+	return appendRunes(buf[:0], seg.token)
+}
+
+// Categories returns the set of Unicode general categories of the runes in
+// the current token, see filter.CategoriesOf.
+func (seg *Segmenter) Categories() filter.Category {
+	return filter.CategoriesOf(seg.token)
+}
+
+// Text returns the current token as a newly-allocated string, or as a
+// shared string, if an Interner is set.
 func (seg *Segmenter) Text() string {
-	return string(seg.token)
+	return seg.interner.Intern(seg.token)
+}
+
+// First returns up to n tokens, as would be returned by calling Next, and
+// stops, so the rest of the text is not segmented. On a new Segmenter, these
+// are the first n tokens of the text, which is useful for a preview of a
+// large document. Subsequent calls to Next or First continue from there.
+// Filters and transforms are applied. Tokens alias the text, as with Bytes.
+// Check Err after calling.
+func (seg *Segmenter) First(n int) [][]byte {
+	var result [][]byte
+	for len(result) < n && seg.Next() {
+		result = append(result, seg.Bytes())
+	}
+	return result
 }
 
 // These extensive comments are here because someone is gonna be surprised by
@@ -168,9 +248,65 @@ func (seg *Segmenter) Start() int {
 // End returns the position (byte index) of the first byte after the current token,
 // in the original text.
 //
-// In other words, segmenter.Bytes() == original[segmenter.Start():segmenter.End()]
+// In other words, segmenter.Bytes() == original[segmenter.Start():segmenter.End()],
+// unless a Transform is applied, in which case End - Start is the length of the
+// original token, which may differ from the length of Bytes.
 func (seg *Segmenter) End() int {
-	return seg.start + len(seg.token)
+	return seg.end
+}
+
+// BreakReason returns the reason that the current token ended: at a boundary
+// per the spec, or at the end of the text. Unlike a Scanner, a Segmenter does
+// not force breaks, see [Scanner.BreakReason]. This API is experimental.
+func (seg *Segmenter) BreakReason() BreakReason {
+	if seg.end == len(seg.data) {
+		return EndOfInput
+	}
+	return SpecBoundary
+}
+
+// Peek returns the token that would result from the next call to Next, without
+// advancing the Segmenter. It returns nil if there are no remaining tokens, or
+// an error would occur. Transforms and filters are applied, as with Next.
+//
+// Peek does not cache its result; the token is segmented again on the next
+// call to Next.
+func (seg *Segmenter) Peek() []byte {
+	saved := *seg
+	defer func() {
+		*seg = saved
+	}()
+
+	if !seg.Next() {
+		return nil
+	}
+	return seg.token
+}
+
+// Previous returns the token prior to the current token, i.e. the token that
+// was current before the most recent call to Next. It returns nil if the
+// current token is the first.
+func (seg *Segmenter) Previous() []byte {
+	return seg.prev
+}
+
+// PreviousStart returns the position (byte index) of the previous token in the
+// original text. See [Segmenter.Previous].
+func (seg *Segmenter) PreviousStart() int {
+	return seg.prevStart
+}
+
+// PreviousEnd returns the position (byte index) of the first byte after the
+// previous token, in the original text. See [Segmenter.Previous].
+func (seg *Segmenter) PreviousEnd() int {
+	return seg.prevEnd
+}
+
+// Index returns the ordinal (zero-based) of the current token, counting from
+// the most recent SetText. Tokens omitted by a Filter are not counted. Index
+// returns -1 if Next has not yet returned a token.
+func (seg *Segmenter) Index() int {
+	return seg.count - 1
 }
 
 // All iterates through all tokens and collect them into a [][]byte. It is a
@@ -198,3 +334,29 @@ func All(src []byte, dest *[][]byte, split bufio.SplitFunc) error {
 
 	return nil
 }
+
+// Count returns the number of tokens in src, as split by split. It is
+// equivalent to counting the tokens of a Segmenter, without its state, and
+// without collecting the tokens, as All does.
+func Count(src []byte, split bufio.SplitFunc) (int, error) {
+	n := 0
+	for pos := 0; pos < len(src); {
+		advance, token, err := split(src[pos:], true)
+		if err != nil {
+			return n, err
+		}
+
+		if advance == 0 {
+			break
+		}
+		pos += advance
+
+		if len(token) == 0 {
+			break
+		}
+
+		n++
+	}
+
+	return n, nil
+}
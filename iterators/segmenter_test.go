@@ -9,6 +9,7 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/clipperhouse/uax29/graphemes"
 	"github.com/clipperhouse/uax29/iterators"
 	"github.com/clipperhouse/uax29/iterators/filter"
 	"github.com/clipperhouse/uax29/iterators/transformer"
@@ -223,4 +224,308 @@ func TestSegmenterEnd(t *testing.T) {
 			t.Fatalf("end failed for filter.AlphaNumeric, expected %v, got %v", expected, got)
 		}
 	}
+	{
+		// A transform may change the length of the token, but not its position
+		text := []byte("Açaí bowl")
+		seg := words.NewSegmenter(text)
+		seg.Transform(transformer.Diacritics)
+
+		expected := []int{6, 7, len(text)}
+		var got []int
+		var prev []int
+		for seg.Next() {
+			got = append(got, seg.End())
+			prev = append(prev, seg.PreviousEnd())
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("end failed for transformer.Diacritics, expected %v, got %v", expected, got)
+		}
+		if expected := []int{0, 6, 7}; !reflect.DeepEqual(prev, expected) {
+			t.Fatalf("previous end failed for transformer.Diacritics, expected %v, got %v", expected, prev)
+		}
+	}
+}
+
+func TestSegmenterIndex(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello world")
+
+	{
+		seg := words.NewSegmenter(text)
+		if seg.Index() != -1 {
+			t.Fatalf("index should be -1 before calling Next, got %d", seg.Index())
+		}
+
+		expected := []int{0, 1, 2}
+		var got []int
+		for seg.Next() {
+			got = append(got, seg.Index())
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("index failed for words.SplitFunc, expected %v, got %v", expected, got)
+		}
+
+		seg.SetText(text)
+		if seg.Index() != -1 {
+			t.Fatalf("index should be reset by SetText, got %d", seg.Index())
+		}
+	}
+
+	{
+		seg := words.NewSegmenter(text)
+		seg.Filter(filter.AlphaNumeric)
+
+		expected := []int{0, 1}
+		var got []int
+		for seg.Next() {
+			got = append(got, seg.Index())
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("index failed for filter.AlphaNumeric, expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestSegmenterPeek(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, world. Nice dog!")
+
+	seg := words.NewSegmenter(text)
+	seg.Filter(filter.AlphaNumeric)
+
+	expected := []string{"Hello", "world", "Nice", "dog"}
+
+	for i := 0; ; i++ {
+		peeked := seg.Peek()
+		if !bytes.Equal(peeked, seg.Peek()) {
+			t.Fatal("peek should not advance the segmenter")
+		}
+
+		if !seg.Next() {
+			if peeked != nil {
+				t.Fatalf("peek returned %q, but next returned false", peeked)
+			}
+			break
+		}
+
+		if !bytes.Equal(peeked, seg.Bytes()) {
+			t.Fatalf("expected peek to return %q, got %q", seg.Bytes(), peeked)
+		}
+		if seg.Text() != expected[i] {
+			t.Fatalf("expected %q, got %q", expected[i], seg.Text())
+		}
+	}
+}
+
+func TestSegmenterPrevious(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, world")
+
+	seg := words.NewSegmenter(text)
+	if seg.Previous() != nil {
+		t.Fatal("previous should be nil before calling Next")
+	}
+
+	var lastStart, lastEnd int
+	var last []byte
+	for i := 0; seg.Next(); i++ {
+		if i == 0 {
+			if seg.Previous() != nil {
+				t.Fatal("previous should be nil for the first token")
+			}
+		} else {
+			if !bytes.Equal(seg.Previous(), last) {
+				t.Fatalf("expected previous to be %q, got %q", last, seg.Previous())
+			}
+			if seg.PreviousStart() != lastStart || seg.PreviousEnd() != lastEnd {
+				t.Fatalf("expected previous span [%d:%d], got [%d:%d]", lastStart, lastEnd, seg.PreviousStart(), seg.PreviousEnd())
+			}
+			if !bytes.Equal(text[seg.PreviousStart():seg.PreviousEnd()], seg.Previous()) {
+				t.Fatal("previous span should match previous token")
+			}
+		}
+		last, lastStart, lastEnd = seg.Bytes(), seg.Start(), seg.End()
+	}
+}
+
+func TestSegmenterReset(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, 世界. Nice dog!")
+
+	for _, split := range splitFuncs {
+		seg := iterators.NewSegmenter(split)
+		seg.SetText(text)
+		seg.Filter(filter.Wordlike)
+
+		var first [][]byte
+		for seg.Next() {
+			first = append(first, seg.Bytes())
+		}
+
+		seg.Reset()
+		if seg.Index() != -1 || seg.Start() != 0 || seg.Previous() != nil {
+			t.Fatal("Reset should reset all state")
+		}
+
+		var second [][]byte
+		for seg.Next() {
+			second = append(second, seg.Bytes())
+		}
+
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("Reset should rewind to the same tokens, with the filter retained, got %q and %q", first, second)
+		}
+	}
+}
+
+func TestSegmenterAppendBytes(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, world")
+	seg := words.NewSegmenter(text)
+
+	buf := make([]byte, 0, 64)
+	for seg.Next() {
+		buf = seg.AppendBytes(buf[:0])
+		if !bytes.Equal(buf, seg.Bytes()) {
+			t.Fatalf("expected %q, got %q", seg.Bytes(), buf)
+		}
+	}
+
+	// The result should not alias the text
+	seg.SetText(text)
+	seg.Next()
+	buf = seg.AppendBytes(nil)
+	buf[0] = 'J'
+	if text[0] != 'H' {
+		t.Fatal("AppendBytes should not alias the text")
+	}
+}
+
+func TestSegmenterRunes(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, 世界. Nice dog! 👍🐶 e\u0301 \U0001F1FA\U0001F1F8 \xff")
+	seg := graphemes.NewSegmenter(text)
+
+	buf := make([]rune, 0, 8)
+	for seg.Next() {
+		buf = seg.Runes(buf)
+		expected := []rune(string(seg.Bytes()))
+		if !reflect.DeepEqual(buf, expected) {
+			t.Fatalf("expected %q, got %q", expected, buf)
+		}
+	}
+}
+
+func TestSegmenterRunesDoesNotAllocate(t *testing.T) {
+	text := []byte("Hello, 世界. Nice dog! 👍🐶 e\u0301")
+	seg := graphemes.NewSegmenter(text)
+	buf := make([]rune, 0, 8)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		seg.SetText(text)
+		for seg.Next() {
+			buf = seg.Runes(buf)
+		}
+	})
+	if allocs > 0 {
+		t.Fatalf("expected no allocations, got %f", allocs)
+	}
+}
+
+func TestSegmenterFirst(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, 世界. Nice dog! 👍🐶")
+
+	all := words.SegmentAll(text)
+	seg := words.NewSegmenter(text)
+
+	for _, n := range []int{0, 1, 3, len(all), len(all) + 10} {
+		seg.SetText(text)
+		got := seg.First(n)
+
+		expected := all
+		if n < len(all) {
+			expected = all[:n]
+		}
+		if len(got) != len(expected) {
+			t.Fatalf("n %d: expected %d tokens, got %d", n, len(expected), len(got))
+		}
+		for i := range got {
+			if !bytes.Equal(got[i], expected[i]) {
+				t.Errorf("n %d: expected %q, got %q", n, expected[i], got[i])
+			}
+		}
+	}
+
+	// Subsequent calls continue, and filters are applied
+	seg.SetText(text)
+	seg.Filter(filter.Wordlike)
+
+	first := seg.First(2)
+	next := seg.First(2)
+	expected := []string{"Hello", "世", "界", "Nice"}
+	var got []string
+	for _, token := range append(first, next...) {
+		got = append(got, string(token))
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if seg.Index() != 3 {
+		t.Errorf("expected Index 3, got %d", seg.Index())
+	}
+}
+
+func TestSegmenterCategories(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, a1 👍")
+	seg := words.NewSegmenter(text)
+
+	for seg.Next() {
+		got := seg.Categories()
+		expected := filter.CategoriesOf(seg.Bytes())
+		if got != expected {
+			t.Errorf("%q: expected %08b, got %08b", seg.Bytes(), expected, got)
+		}
+	}
+}
+
+func TestSegmenterBreakReason(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, world.")
+	expected := []iterators.BreakReason{
+		iterators.SpecBoundary, // Hello
+		iterators.SpecBoundary, // ,
+		iterators.SpecBoundary, // space
+		iterators.SpecBoundary, // world
+		iterators.EndOfInput,   // .
+	}
+
+	seg := iterators.NewSegmenter(words.SplitFunc)
+	seg.SetText(text)
+	sc := iterators.NewScanner(bytes.NewReader(text), words.SplitFunc)
+
+	var got, scanned []iterators.BreakReason
+	for seg.Next() {
+		got = append(got, seg.BreakReason())
+	}
+	for sc.Scan() {
+		scanned = append(scanned, sc.BreakReason())
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Segmenter: expected %s, got %s", expected, got)
+	}
+	if !reflect.DeepEqual(scanned, expected) {
+		t.Errorf("Scanner: expected %s, got %s", expected, scanned)
+	}
 }
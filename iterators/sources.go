@@ -0,0 +1,69 @@
+package iterators
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+// NewReaderAtScanner creates a new Scanner which segments the n bytes of r
+// starting at off, such as a section of a file or a database blob. Start and
+// End are positions in r, i.e. they begin at off, rather than zero. To use
+// the new scanner, iterate while Scan() is true.
+//
+// As with any Scanner, the text is read into a buffer. If the text is
+// already in memory, such as a memory-mapped file, use a Segmenter instead,
+// which does not copy.
+func NewReaderAtScanner(r io.ReaderAt, off, n int64, split bufio.SplitFunc) *Scanner {
+	sc := NewScanner(io.NewSectionReader(r, off, n), split)
+	sc.pos = int(off)
+	return sc
+}
+
+// NewRuneScanner creates a new Scanner given an io.RuneReader and a
+// SplitFunc, for sources which produce runes, rather than bytes. The runes
+// are encoded as UTF-8, and Start and End are byte positions in that
+// encoding. To use the new scanner, iterate while Scan() is true.
+//
+// If r is also an io.Reader, such as a bufio.Reader or strings.Reader, use
+// NewScanner instead, which reads bytes directly, so invalid UTF-8 is
+// preserved; here, it will have been decoded as utf8.RuneError.
+func NewRuneScanner(r io.RuneReader, split bufio.SplitFunc) *Scanner {
+	return NewScanner(&runeReader{runes: r}, split)
+}
+
+// runeReader adapts an io.RuneReader to an io.Reader
+type runeReader struct {
+	runes io.RuneReader
+	// pending is the remainder of an encoded rune which did not fit in p
+	pending []byte
+	buf     [utf8.UTFMax]byte
+}
+
+func (rr *runeReader) Read(p []byte) (int, error) {
+	n := copy(p, rr.pending)
+	rr.pending = rr.pending[n:]
+
+	for n < len(p) {
+		r, _, err := rr.runes.ReadRune()
+		if err != nil {
+			if n > 0 && err == io.EOF {
+				// Return the bytes we have; the next Read will return EOF
+				return n, nil
+			}
+			return n, err
+		}
+
+		if len(p)-n >= utf8.UTFMax || r < utf8.RuneSelf {
+			n += utf8.EncodeRune(p[n:], r)
+			continue
+		}
+
+		w := utf8.EncodeRune(rr.buf[:], r)
+		c := copy(p[n:], rr.buf[:w])
+		rr.pending = rr.buf[c:w]
+		n += c
+	}
+
+	return n, nil
+}
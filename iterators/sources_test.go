@@ -0,0 +1,104 @@
+package iterators_test
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+// runesOnly is an io.RuneReader which is not an io.Reader
+type runesOnly struct {
+	r io.RuneReader
+}
+
+func (r runesOnly) ReadRune() (rune, int, error) {
+	return r.r.ReadRune()
+}
+
+func TestRuneScannerSameAsSegmenter(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, split := range splitFuncs {
+		for _, size := range []int{1, 4096} {
+			seg := iterators.NewSegmenter(split)
+			seg.SetText(file)
+
+			sc := iterators.NewRuneScanner(runesOnly{strings.NewReader(string(file))}, split)
+			// A small buffer, so that runes are split across reads
+			sc.Buffer(make([]byte, size), bufio.MaxScanTokenSize)
+
+			for seg.Next() {
+				if !sc.Scan() {
+					t.Fatal("rune scanner returned fewer tokens than segmenter")
+				}
+				if !bytes.Equal(seg.Bytes(), sc.Bytes()) {
+					t.Fatalf("expected %q, got %q", seg.Bytes(), sc.Bytes())
+				}
+				if seg.Start() != sc.Start() || seg.End() != sc.End() {
+					t.Fatalf("expected position %d-%d, got %d-%d", seg.Start(), seg.End(), sc.Start(), sc.End())
+				}
+			}
+			if sc.Scan() {
+				t.Fatal("rune scanner returned more tokens than segmenter")
+			}
+			if sc.Err() != nil {
+				t.Fatal(sc.Err())
+			}
+		}
+	}
+}
+
+func TestReaderAtScanner(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A section of the file, at a token boundary (a newline)
+	off := int64(bytes.IndexByte(file, '\n') + 1)
+	n := int64(1000)
+	section := file[off : off+n]
+
+	for _, split := range splitFuncs {
+		seg := iterators.NewSegmenter(split)
+		seg.SetText(section)
+
+		sc := iterators.NewReaderAtScanner(bytes.NewReader(file), off, n, split)
+
+		for seg.Next() {
+			if !sc.Scan() {
+				t.Fatal("reader at scanner returned fewer tokens than segmenter")
+			}
+			if !bytes.Equal(seg.Bytes(), sc.Bytes()) {
+				t.Fatalf("expected %q, got %q", seg.Bytes(), sc.Bytes())
+			}
+
+			// Positions are global, i.e. relative to the start of the file
+			start, end := seg.Start()+int(off), seg.End()+int(off)
+			if sc.Start() != start || sc.End() != end {
+				t.Fatalf("expected position %d-%d, got %d-%d", start, end, sc.Start(), sc.End())
+			}
+			if !bytes.Equal(file[sc.Start():sc.End()], sc.Bytes()) {
+				t.Fatalf("expected %q at %d, got %q", sc.Bytes(), sc.Start(), file[sc.Start():sc.End()])
+			}
+		}
+		if sc.Scan() {
+			t.Fatal("reader at scanner returned more tokens than segmenter")
+		}
+		if sc.Err() != nil {
+			t.Fatal(sc.Err())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package iterators
+
+// Span is the position of a token in the original text, as byte indices. It is
+// half-open: Start is the index of the first byte, and End is the index of the
+// first byte after the token. It is useful for correlating tokens with other
+// annotations of the same text, such as named entities.
+type Span struct {
+	Start, End int
+}
+
+// Span returns the position of the current token in the original text. See
+// Start and End for caveats.
+func (seg *Segmenter) Span() Span {
+	return Span{seg.Start(), seg.End()}
+}
+
+// Len returns the length of the span, in bytes.
+func (s Span) Len() int {
+	return s.End - s.Start
+}
+
+// Contains returns whether the byte at pos is within the span.
+func (s Span) Contains(pos int) bool {
+	return s.Start <= pos && pos < s.End
+}
+
+// Overlaps returns whether the span shares at least one byte with the
+// half-open range [start, end). An empty span or range overlaps nothing.
+func (s Span) Overlaps(start, end int) bool {
+	return s.Start < end && start < s.End && s.Start < s.End && start < end
+}
+
+// Slice returns the bytes of the span within src, which should be the original
+// text. It is not a copy. It panics if the span is out of range for src.
+func (s Span) Slice(src []byte) []byte {
+	return src[s.Start:s.End]
+}
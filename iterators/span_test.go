@@ -0,0 +1,67 @@
+package iterators_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestSpan(t *testing.T) {
+	t.Parallel()
+
+	s := iterators.Span{Start: 3, End: 7}
+
+	if s.Len() != 4 {
+		t.Errorf("expected Len 4, got %d", s.Len())
+	}
+
+	contains := map[int]bool{2: false, 3: true, 6: true, 7: false}
+	for pos, expected := range contains {
+		if got := s.Contains(pos); got != expected {
+			t.Errorf("Contains(%d): expected %t, got %t", pos, expected, got)
+		}
+	}
+
+	type overlap struct {
+		start, end int
+		expected   bool
+	}
+
+	overlaps := []overlap{
+		{0, 3, false},
+		{0, 4, true},
+		{4, 5, true},
+		{6, 10, true},
+		{7, 10, false},
+		{0, 10, true},
+		{5, 5, false},
+	}
+
+	for _, o := range overlaps {
+		if got := s.Overlaps(o.start, o.end); got != o.expected {
+			t.Errorf("Overlaps(%d, %d): expected %t, got %t", o.start, o.end, o.expected, got)
+		}
+	}
+
+	if (iterators.Span{Start: 3, End: 3}).Overlaps(0, 10) {
+		t.Error("an empty span should overlap nothing")
+	}
+}
+
+func TestSegmenterSpan(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hello, 世界. Nice dog! 👍🐶")
+
+	seg := words.NewSegmenter(text)
+	for seg.Next() {
+		span := seg.Span()
+		if string(span.Slice(text)) != seg.Text() {
+			t.Fatalf("expected %q, got %q", seg.Text(), span.Slice(text))
+		}
+		if span.Start != seg.Start() || span.End != seg.End() {
+			t.Fatalf("expected span to match Start and End")
+		}
+	}
+}
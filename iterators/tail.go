@@ -0,0 +1,131 @@
+package iterators
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// Tail is an iterator over text which grows by appending, such as a log line
+// being built in a bytes.Buffer or strings.Builder. Each call to Next segments
+// only the text appended since the previous token, and returns true while
+// there is a complete token.
+//
+// The last token of the text is not complete until more text is appended
+// (which might extend it), or Flush is called. Therefore, Next may return
+// false, and then true after more text is appended.
+//
+// The underlying text must only be appended to. Resetting, truncating or
+// reading from it while iterating has undefined results.
+type Tail struct {
+	split bufio.SplitFunc
+	text  func() []byte
+	token []byte
+	start int
+	pos   int
+	final bool
+	err   error
+}
+
+// NewTail creates a new Tail given a func, which returns the current text,
+// and a SplitFunc. To use the new Tail, iterate while Next() is true, and
+// call Next again after appending to the text.
+func NewTail(text func() []byte, split bufio.SplitFunc) *Tail {
+	return &Tail{
+		split: split,
+		text:  text,
+	}
+}
+
+// NewBufferTail creates a new Tail over the contents of a bytes.Buffer. Tokens
+// are not copied; they alias the Buffer, until it is next written to.
+func NewBufferTail(buf *bytes.Buffer, split bufio.SplitFunc) *Tail {
+	return NewTail(buf.Bytes, split)
+}
+
+// NewBuilderTail creates a new Tail over the contents of a strings.Builder.
+// Because a Builder does not expose its bytes, appended text is copied
+// once, incrementally, into a buffer held by the Tail.
+func NewBuilderTail(b *strings.Builder, split bufio.SplitFunc) *Tail {
+	var data []byte
+	text := func() []byte {
+		if b.Len() > len(data) {
+			data = append(data, b.String()[len(data):]...)
+		}
+		return data
+	}
+	return NewTail(text, split)
+}
+
+// Next advances Tail to the next complete token. It returns false when there
+// is no complete token in the text appended so far, or an error occurred.
+func (t *Tail) Next() bool {
+	if t.err != nil {
+		return false
+	}
+
+	data := t.text()
+	if t.pos >= len(data) {
+		return false
+	}
+
+	advance, token, err := t.split(data[t.pos:], t.final)
+	if err != nil {
+		t.err = err
+		return false
+	}
+
+	// Guardrails
+	if advance < 0 {
+		t.err = ErrAdvanceNegative
+		return false
+	}
+	if t.pos+advance > len(data) {
+		t.err = ErrAdvanceTooFar
+		return false
+	}
+
+	// Token extends past the text so far, wait for more
+	if advance == 0 || len(token) == 0 {
+		return false
+	}
+
+	t.start = t.pos
+	t.pos += advance
+	t.token = token
+
+	return true
+}
+
+// Flush indicates that no more text will be appended, so the last token
+// in the text is complete, and will be returned by Next.
+func (t *Tail) Flush() {
+	t.final = true
+}
+
+// Err indicates an error occured when calling Next; Next will return false
+// when an error occurs.
+func (t *Tail) Err() error {
+	return t.err
+}
+
+// Bytes returns the current token.
+func (t *Tail) Bytes() []byte {
+	return t.token
+}
+
+// Text returns the current token as a newly-allocated string.
+func (t *Tail) Text() string {
+	return string(t.token)
+}
+
+// Start returns the position (byte index) of the current token in the text.
+func (t *Tail) Start() int {
+	return t.start
+}
+
+// End returns the position (byte index) of the first byte after the current
+// token, in the text.
+func (t *Tail) End() int {
+	return t.start + len(t.token)
+}
@@ -0,0 +1,99 @@
+package iterators_test
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+func TestTailSameAsSegmenter(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, split := range splitFuncs {
+		var expected [][]byte
+		seg := iterators.NewSegmenter(split)
+		seg.SetText(file)
+		for seg.Next() {
+			expected = append(expected, seg.Bytes())
+		}
+
+		var buf bytes.Buffer
+		var b strings.Builder
+		tails := []*iterators.Tail{
+			iterators.NewBufferTail(&buf, split),
+			iterators.NewBuilderTail(&b, split),
+		}
+
+		got := make([][][]byte, len(tails))
+
+		// append in small pieces, which may split tokens and runes
+		for _, chunk := range newRope(file).chunks {
+			buf.Write(chunk)
+			b.Write(chunk)
+
+			for i, tail := range tails {
+				for tail.Next() {
+					got[i] = append(got[i], append([]byte(nil), tail.Bytes()...))
+				}
+			}
+		}
+
+		for i, tail := range tails {
+			tail.Flush()
+			for tail.Next() {
+				got[i] = append(got[i], append([]byte(nil), tail.Bytes()...))
+			}
+			if err := tail.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got[i], expected) {
+				t.Fatal("Tail and Segmenter should give identical results")
+			}
+		}
+	}
+}
+
+func TestTailWaitsForCompleteToken(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	tail := iterators.NewBuilderTail(&b, splitFuncs[0]) // words
+
+	b.WriteString("Hello wor")
+
+	var got []string
+	for tail.Next() {
+		got = append(got, tail.Text())
+	}
+
+	// "wor" is incomplete
+	expected := []string{"Hello", " "}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+
+	b.WriteString("ld")
+	tail.Flush()
+
+	for tail.Next() {
+		got = append(got, tail.Text())
+		if tail.Text() != b.String()[tail.Start():tail.End()] {
+			t.Fatal("start and end should match the token")
+		}
+	}
+
+	expected = []string{"Hello", " ", "world"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,17 @@
+package iterators
+
+import "io"
+
+// Trace sets a writer to receive diagnostic events from the Scanner: buffer
+// refills, token sizes, and retractions (where the SplitFunc requests more
+// data before it can return a token). It is intended for debugging streaming
+// issues, which are otherwise hard to reproduce.
+//
+// Tracing is compiled out by default, in which case Trace is a no-op. Build
+// with the uax29trace tag to enable it, e.g. go test -tags uax29trace.
+//
+// As with Split, it panics if it is called after scanning has started.
+func (sc *Scanner) Trace(w io.Writer) {
+	sc.trace = w
+	sc.Split(sc.split)
+}
@@ -0,0 +1,11 @@
+//go:build !uax29trace
+// +build !uax29trace
+
+package iterators
+
+import "bufio"
+
+// traced is a no-op unless built with the uax29trace tag, see Trace.
+func (sc *Scanner) traced(split bufio.SplitFunc) bufio.SplitFunc {
+	return split
+}
@@ -0,0 +1,43 @@
+//go:build uax29trace
+// +build uax29trace
+
+package iterators
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// traced wraps split, writing diagnostic events to the Scanner's trace writer, see Trace.
+func (sc *Scanner) traced(split bufio.SplitFunc) bufio.SplitFunc {
+	w := sc.trace
+	if w == nil || split == nil {
+		return split
+	}
+
+	// remaining is the number of unconsumed bytes after the previous call; if
+	// data is longer than that on the next call, the buffer was refilled
+	var remaining int
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if len(data) > remaining {
+			fmt.Fprintf(w, "refill: %d bytes buffered (+%d), atEOF %t\n", len(data), len(data)-remaining, atEOF)
+		}
+
+		advance, token, err := split(data, atEOF)
+
+		switch {
+		case err != nil:
+			fmt.Fprintf(w, "error: %v\n", err)
+		case advance == 0 && !atEOF:
+			fmt.Fprintf(w, "retract: token extends past %d buffered bytes, requesting more\n", len(data))
+		case advance == 0 && token == nil:
+			fmt.Fprintf(w, "eof\n")
+		default:
+			fmt.Fprintf(w, "token: %d bytes, advance %d\n", len(token), advance)
+		}
+
+		remaining = len(data) - advance
+		return advance, token, err
+	}
+}
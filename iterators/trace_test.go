@@ -0,0 +1,46 @@
+//go:build uax29trace
+// +build uax29trace
+
+package iterators_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/clipperhouse/uax29/iterators"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestScannerTrace(t *testing.T) {
+	t.Parallel()
+
+	text := "Hello, 世界. Nice dog! 👍🐶"
+
+	// OneByteReader ensures that tokens span reads, so we see retractions
+	r := iotest.OneByteReader(strings.NewReader(text))
+	sc := iterators.NewScanner(r, words.SplitFunc)
+
+	var trace bytes.Buffer
+	sc.Trace(&trace)
+
+	var tokens int
+	for sc.Scan() {
+		tokens++
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := trace.String()
+	for _, event := range []string{"refill:", "retract:", "token:"} {
+		if !strings.Contains(got, event) {
+			t.Fatalf("expected trace to contain %q events, got:\n%s", event, got)
+		}
+	}
+
+	if c := strings.Count(got, "token:"); c != tokens {
+		t.Fatalf("expected %d token events, got %d", tokens, c)
+	}
+}
@@ -0,0 +1,62 @@
+package lines
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+var trie = newLinesTrie(0)
+
+// is determines if lookup intersects propert(ies)
+func (lookup property) is(properties property) bool {
+	return (lookup & properties) != 0
+}
+
+// resolve returns the Line_Break class of r, per https://unicode.org/reports/tr14/#Properties,
+// given its lookup, after the resolution of LB1: AI, SG and XX are resolved
+// to AL, CJ to NS, and SA to CM if r is a mark (Mn or Mc, per the same
+// UCD version), AL otherwise. The result is exactly one class.
+func resolve(lookup property, r rune) property {
+	lookup &^= _ExtendedPictographicCn
+
+	switch lookup {
+	case 0, _AI, _XX:
+		// Surrogates (SG) are not in the trie, and invalid UTF-8 is 0
+		return _AL
+	case _CJ:
+		return _NS
+	case _SA | _SAMark:
+		return _CM
+	case _SA:
+		return _AL
+	}
+
+	return lookup
+}
+
+// classOf returns the line break class of r, after LB1
+func classOf(r rune) property {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	lookup, _ := trie.lookup(buf[:n])
+	return resolve(lookup, r)
+}
+
+// isPictographicCn determines if r is an unassigned Extended_Pictographic
+// character, per LB30b
+func isPictographicCn(r rune) bool {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	lookup, _ := trie.lookup(buf[:n])
+	return lookup.is(_ExtendedPictographicCn)
+}
+
+// isEastAsian determines if r is East Asian Wide, Fullwidth or Halfwidth, per LB30
+func isEastAsian(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth, width.EastAsianHalfwidth:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package lines_test
+
+import (
+	"fmt"
+
+	"github.com/clipperhouse/uax29/lines"
+)
+
+func ExampleNewSegmenter() {
+	text := []byte("The quick (“brown”) fox can’t jump 32.3 feet, right?\nYes.")
+
+	seg := lines.NewSegmenter(text)
+
+	for seg.Next() {
+		fmt.Printf("%q mandatory: %t\n", seg.Bytes(), lines.IsMandatory(seg.Bytes()))
+	}
+	// Output: "The " mandatory: false
+	// "quick " mandatory: false
+	// "(“brown”) " mandatory: false
+	// "fox " mandatory: false
+	// "can’t " mandatory: false
+	// "jump " mandatory: false
+	// "32.3 " mandatory: false
+	// "feet, " mandatory: false
+	// "right?\n" mandatory: true
+	// "Yes." mandatory: false
+}
@@ -0,0 +1,24 @@
+// Package lines implements Unicode line breaking opportunities: https://unicode.org/reports/tr14/
+//
+// Each token is the text up to a break opportunity, i.e. a position at which
+// a line may be wrapped, including trailing spaces. Use [IsMandatory] to
+// determine if a token ends in a hard line break.
+//
+// The rules (LB2 through LB31) and the Line_Break property, generated from
+// LineBreak.txt, are those of Unicode 15.0.0, with LB25 tailored for numbers,
+// as LineBreakTest.txt is. Complex-context scripts (SA, such as Thai) are not
+// segmented by dictionary; per LB1, they are treated as letters and marks. This package is experimental.
+package lines
+
+import (
+	"io"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+// NewScanner returns a Scanner, to tokenize line break opportunities per https://unicode.org/reports/tr14/.
+// Iterate through tokens by calling Scan() until false, then check Err(). See also the bufio.Scanner docs.
+func NewScanner(r io.Reader) *iterators.Scanner {
+	sc := iterators.NewScanner(r, SplitFunc)
+	return sc
+}
@@ -0,0 +1,46 @@
+package lines
+
+import (
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+// NewSegmenter retuns a Segmenter, which is an iterator over the source text.
+// Iterate while Next() is true, and access the segmented tokens via Bytes().
+func NewSegmenter(data []byte) *iterators.Segmenter {
+	seg := iterators.NewSegmenter(SplitFunc)
+	seg.SetText(data)
+	return seg
+}
+
+// SegmentAll will iterate through all tokens and collect them into a [][]byte.
+// This is a convenience method -- if you will be allocating such a slice anyway,
+// this will save you some code. The downside is that this allocation is
+// unbounded -- O(n) on the number of tokens. Use Segmenter for more bounded
+// memory usage.
+func SegmentAll(data []byte) [][]byte {
+	// Optimization: guesstimate that the average token is 6 bytes,
+	// allocate a large enough array to avoid resizing
+	result := make([][]byte, 0, len(data)/6)
+
+	_ = iterators.All(data, &result, SplitFunc) // can elide the error, see tests
+	return result
+}
+
+// Count returns the number of line segments in data, as would be returned by
+// SegmentAll or a Segmenter, without collecting them.
+func Count(data []byte) int {
+	n, _ := iterators.Count(data, SplitFunc) // can elide the error, see tests
+	return n
+}
+
+// IsMandatory determines if a token ends in a mandatory break, i.e. a line
+// break such as "\n", "\r\n" or U+2028, as opposed to a break opportunity.
+func IsMandatory(token []byte) bool {
+	r, _ := utf8.DecodeLastRune(token)
+	if r == utf8.RuneError {
+		return false
+	}
+	return classOf(r).is(_BK | _CR | _LF | _NL)
+}
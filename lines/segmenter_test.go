@@ -0,0 +1,285 @@
+package lines_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	mathrand "math/rand"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/internal/segtest"
+	"github.com/clipperhouse/uax29/lines"
+)
+
+func TestSegmenterUnicode(t *testing.T) {
+	t.Parallel()
+
+	// From the Unicode test suite; see the gen/ folder.
+	var passed, failed int
+	for _, test := range unicodeTests {
+		test := test
+
+		var segmented [][]byte
+		segmenter := lines.NewSegmenter(test.input)
+		for segmenter.Next() {
+			segmented = append(segmented, segmenter.Bytes())
+		}
+
+		if err := segmenter.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(segmented, test.expected) {
+			failed++
+			t.Errorf(`
+	for input %v
+	expected  %v
+	got       %v
+	spec      %s`, test.input, test.expected, segmented, test.comment)
+		} else {
+			passed++
+		}
+
+		// Test SegmentAll while we're here
+		all := lines.SegmentAll(test.input)
+		if !reflect.DeepEqual(all, segmented) {
+			t.Error("calling SegmentAll should be identical to iterating Segmenter")
+		}
+
+		// And Count
+		if c := lines.Count(test.input); c != len(segmented) {
+			t.Errorf("calling Count should be identical to counting Segmenter, expected %d, got %d", len(segmented), c)
+		}
+	}
+
+	if len(unicodeTests) != passed+failed {
+		t.Errorf("Incomplete %d tests: passed %d, failed %d", len(unicodeTests), passed, failed)
+	}
+}
+
+func TestSegmenter(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+		rule     string
+	}
+
+	tests := []test{
+		{"", nil, "LB2"},
+		{"Hello world", []string{"Hello ", "world"}, "LB18"},
+		{"Hello   world", []string{"Hello   ", "world"}, "LB7, LB18"},
+		{"one\ntwo", []string{"one\n", "two"}, "LB5, LB6"},
+		{"one\r\ntwo", []string{"one\r\n", "two"}, "LB5"},
+		{"one\n\ntwo", []string{"one\n", "\n", "two"}, "LB5"},
+		{"one\u2028two", []string{"one\u2028", "two"}, "LB4, LB6"},
+		{"a\u200Bb", []string{"a\u200B", "b"}, "LB8"},
+		{"a\u200B b", []string{"a\u200B ", "b"}, "LB8"},
+		{"a\u2060b", []string{"a\u2060b"}, "LB11"},
+		{"10\u00A0kg", []string{"10\u00A0kg"}, "LB12"},
+		{"Hello, world!", []string{"Hello, ", "world!"}, "LB13"},
+		{"( x )", []string{"( x )"}, "LB13, LB14"},
+		{"(hello) world", []string{"(hello) ", "world"}, "LB14, LB18"},
+		{"say \"hi\" ok", []string{"say ", "\"hi\" ", "ok"}, "LB19"},
+		{"well-known", []string{"well-", "known"}, "LB21"},
+		{"a—b", []string{"a", "—", "b"}, "LB31"},
+		{"a —— b", []string{"a ", "—— ", "b"}, "LB17"},
+		{"wait...", []string{"wait..."}, "LB22"},
+		{"A1", []string{"A1"}, "LB23"},
+		{"$100 or 25%", []string{"$100 ", "or ", "25%"}, "LB25"},
+		{"1,000.50", []string{"1,000.50"}, "LB25"},
+		{"-5", []string{"-5"}, "LB25"},
+		{"a.2", []string{"a.", "2"}, "LB25"},
+		{"1)%", []string{"1)%"}, "LB25"},
+		{")%", []string{")", "%"}, "LB25"},
+		{"$(5)", []string{"$(5)"}, "LB25"},
+		{"$(x)", []string{"$", "(x)"}, "LB25"},
+		{"and/or", []string{"and/", "or"}, "LB13, LB31"},
+		{"e.g.", []string{"e.g."}, "LB29"},
+		{"f(x)", []string{"f(x)"}, "LB30"},
+		{"日本語", []string{"日", "本", "語"}, "LB31"},
+		{"日本。語", []string{"日", "本。", "語"}, "LB13"},
+		{"「日本」", []string{"「日", "本」"}, "LB13, LB14"},
+		{"한국어", []string{"한", "국", "어"}, "LB31"},
+		{"각", []string{"각"}, "LB26"},
+		{"🇺🇸🇫🇷", []string{"🇺🇸", "🇫🇷"}, "LB30a"},
+		{"👍🏽👍", []string{"👍🏽", "👍"}, "LB30b"},
+		{"a\u0301b c", []string{"a\u0301b ", "c"}, "LB9"},
+		{" \u0301a", []string{" ", "\u0301a"}, "LB10"},
+		{"\u0301a", []string{"\u0301a"}, "LB10"},
+		{"ab\u200D日", []string{"ab\u200D日"}, "LB8a"},
+		{"שלום-עולם", []string{"שלום-עולם"}, "LB21a"},
+		{"a\uFFFCb", []string{"a", "\uFFFC", "b"}, "LB20"},
+	}
+
+	for _, test := range tests {
+		var got []string
+		seg := lines.NewSegmenter([]byte(test.input))
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: for %q, expected %q, got %q", test.rule, test.input, test.expected, got)
+		}
+	}
+}
+
+func TestNumberAhead(t *testing.T) {
+	t.Parallel()
+
+	// LB25 looks past OP or HY for NU, which a Scanner may not have read yet
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"$(5)", []string{"$(5)"}},
+		{"$(\u03015)", []string{"$(\u03015)"}},
+		{"$-5 $-x", []string{"$-5 ", "$-", "x"}},
+		{"$(", []string{"$", "("}},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, lines.SplitFunc, nil, []byte(test.input), test.expected)
+	}
+}
+
+func TestIsMandatory(t *testing.T) {
+	t.Parallel()
+
+	input := "one two\nthree\r\nfour\u2028five"
+	expected := []bool{false, true, true, true, false}
+
+	var got []bool
+	seg := lines.NewSegmenter([]byte(input))
+	for seg.Next() {
+		got = append(got, lines.IsMandatory(seg.Bytes()))
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if lines.IsMandatory(nil) {
+		t.Error("expected an empty token not to be mandatory")
+	}
+}
+
+func TestSegmenterRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	const runs = 2000
+
+	seg := lines.NewSegmenter(nil)
+
+	for i := 0; i < runs; i++ {
+		input := getRandomBytes()
+		seg.SetText(input)
+
+		var output []byte
+		for seg.Next() {
+			output = append(output, seg.Bytes()...)
+		}
+
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(output, input) {
+			t.Fatal("input bytes are not the same as segmented bytes")
+		}
+	}
+}
+
+func TestSegmenterInvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	input, err := os.ReadFile("../testdata/UTF-8-test.txt")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if utf8.Valid(input) {
+		t.Error("input file should not be valid utf8")
+	}
+
+	seg := lines.NewSegmenter(input)
+
+	var output []byte
+	c := 0
+	for seg.Next() {
+		output = append(output, seg.Bytes()...)
+		c++
+	}
+	if err := seg.Err(); err != nil {
+		t.Error(err)
+	}
+
+	if !bytes.Equal(output, input) {
+		t.Fatalf("input bytes are not the same as segmented bytes")
+	}
+
+	if got := lines.Count(input); got != c {
+		t.Errorf("calling Count should be identical to counting Segmenter, expected %d, got %d", c, got)
+	}
+}
+
+func TestScanner(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := lines.SegmentAll(file)
+
+	var got [][]byte
+	sc := lines.NewScanner(strings.NewReader(string(file)))
+	sc.Buffer(make([]byte, 16), 64*1024)
+	for sc.Scan() {
+		got = append(got, append([]byte(nil), sc.Bytes()...))
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Error("expected Scanner to be identical to SegmentAll")
+	}
+}
+
+func getRandomBytes() []byte {
+	const max = 10000
+	const min = 1
+
+	len := mathrand.Intn(max-min) + min
+	b := make([]byte, len)
+	rand.Read(b)
+
+	return b
+}
+
+func BenchmarkSegmenter(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		b.Error(err)
+	}
+
+	b.ResetTimer()
+	b.SetBytes(int64(len(file)))
+
+	seg := lines.NewSegmenter(file)
+	for i := 0; i < b.N; i++ {
+		seg.SetText(file)
+		for seg.Next() {
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package lines
+
+import (
+	"unicode/utf8"
+)
+
+// SplitFunc is a bufio.SplitFunc implementation of line break opportunities,
+// for use with bufio.Scanner. Each token is the text up to (and including)
+// a break opportunity, including any trailing spaces or line break.
+func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	// These vars are stateful across loop iterations
+	var pos, w int
+	var r rune
+
+	var left property     // the class of the last character, after LB9 and LB10
+	var prevLeft property // the class of the character before that
+	var beforeSP property // the class of the last character which is not SP
+	var lastRaw property  // the class of the last character, before LB9 and LB10
+	var leftRune rune     // the last character, after LB9
+	var regionals int     // the number of consecutive RI, for LB30a
+	var numeric int       // the progress of a number, for LB25: 1 after NU (NU|SY|IS)*, 2 after a closing CL or CP
+
+	for {
+		if pos == len(data) && !atEOF {
+			// Token extends past current data, request more
+			return 0, nil, nil
+		}
+
+		if pos == len(data) {
+			// LB3
+			break
+		}
+
+		if !atEOF && !utf8.FullRune(data[pos:]) {
+			// Rune extends past current data, request more
+			return 0, nil, nil
+		}
+
+		r, w = utf8.DecodeRune(data[pos:])
+		lookup, _ := trie.lookup(data[pos:])
+		raw := resolve(lookup, r)
+		current := raw
+
+		if pos == 0 {
+			// LB2, and LB10 at the start of text
+			if current.is(_CM | _ZWJ) {
+				current = _AL
+			}
+			left, beforeSP, lastRaw, leftRune = current, current, raw, r
+			if current == _RI {
+				regionals = 1
+			}
+			if current == _NU {
+				numeric = 1
+			}
+			pos += w
+			continue
+		}
+
+		// LB4
+		if left == _BK {
+			break
+		}
+
+		// LB5
+		if left == _CR && raw == _LF {
+			pos += w
+			left, lastRaw = _LF, _LF
+			continue
+		}
+		if left.is(_CR | _LF | _NL) {
+			break
+		}
+
+		// LB6
+		if raw.is(_BK | _CR | _LF | _NL) {
+			pos += w
+			prevLeft, left, beforeSP, lastRaw, leftRune = left, raw, raw, raw, r
+			numeric = 0
+			continue
+		}
+
+		// LB7
+		if raw.is(_SP | _ZW) {
+			pos += w
+			prevLeft, left, lastRaw, leftRune = left, raw, raw, r
+			if raw != _SP {
+				beforeSP = raw
+			}
+			regionals = 0
+			numeric = 0
+			continue
+		}
+
+		// LB8
+		if beforeSP == _ZW {
+			break
+		}
+
+		// LB9
+		if raw.is(_CM|_ZWJ) && left != _SP {
+			pos += w
+			lastRaw = raw
+			continue
+		}
+
+		// LB10
+		if raw.is(_CM | _ZWJ) {
+			current = _AL
+		}
+
+		// LB25 looks past OP or HY, for a number following PR or PO
+		var numberAhead bool
+		if left.is(_PR|_PO) && current.is(_OP|_HY) {
+			found, more := isNumberAhead(data[pos+w:], atEOF)
+			if more {
+				return 0, nil, nil
+			}
+			numberAhead = found
+		}
+
+		if breaks(left, current, prevLeft, beforeSP, lastRaw, leftRune, r, regionals, numeric, numberAhead) {
+			break
+		}
+
+		pos += w
+		prevLeft, left, lastRaw, leftRune = left, current, raw, r
+		if current != _SP {
+			beforeSP = current
+		}
+		if current == _RI {
+			regionals++
+		} else {
+			regionals = 0
+		}
+		switch {
+		case current == _NU:
+			numeric = 1
+		case numeric == 1 && current.is(_SY|_IS):
+		case numeric == 1 && current.is(_CL|_CP):
+			numeric = 2
+		default:
+			numeric = 0
+		}
+	}
+
+	return pos, data[:pos], nil
+}
+
+// isNumberAhead determines if the first character of data, after any CM or
+// ZWJ (LB9), is NU. If more is true, data ends before it can tell.
+func isNumberAhead(data []byte, atEOF bool) (found, more bool) {
+	pos := 0
+	for {
+		if pos == len(data) || (!atEOF && !utf8.FullRune(data[pos:])) {
+			return false, !atEOF
+		}
+
+		r, w := utf8.DecodeRune(data[pos:])
+		lookup, _ := trie.lookup(data[pos:])
+		current := resolve(lookup, r)
+		if !current.is(_CM | _ZWJ) {
+			return current == _NU, false
+		}
+		pos += w
+	}
+}
+
+// breaks determines if there is a break opportunity between left and right,
+// per LB8a and LB11 through LB31
+func breaks(left, right, prevLeft, beforeSP, lastRaw property, leftRune, rightRune rune, regionals, numeric int, numberAhead bool) bool {
+	// LB8a
+	if lastRaw == _ZWJ {
+		return false
+	}
+
+	// LB11
+	if left == _WJ || right == _WJ {
+		return false
+	}
+
+	// LB12
+	if left == _GL {
+		return false
+	}
+
+	// LB12a
+	if right == _GL && !left.is(_SP|_BA|_HY) {
+		return false
+	}
+
+	// LB13
+	if right.is(_CL | _CP | _EX | _IS | _SY) {
+		return false
+	}
+
+	// LB14
+	if beforeSP == _OP {
+		return false
+	}
+
+	// LB15
+	if beforeSP == _QU && right == _OP {
+		return false
+	}
+
+	// LB16
+	if beforeSP.is(_CL|_CP) && right == _NS {
+		return false
+	}
+
+	// LB17
+	if beforeSP == _B2 && right == _B2 {
+		return false
+	}
+
+	// LB18
+	if left == _SP {
+		return true
+	}
+
+	// LB19
+	if left == _QU || right == _QU {
+		return false
+	}
+
+	// LB20
+	if left == _CB || right == _CB {
+		return true
+	}
+
+	// LB21
+	if right.is(_BA|_HY|_NS) || left == _BB {
+		return false
+	}
+
+	// LB21a
+	if prevLeft == _HL && left.is(_HY|_BA) {
+		return false
+	}
+
+	// LB21b
+	if left == _SY && right == _HL {
+		return false
+	}
+
+	// LB22
+	if right == _IN {
+		return false
+	}
+
+	// LB23
+	if left.is(_AL|_HL) && right == _NU {
+		return false
+	}
+	if left == _NU && right.is(_AL|_HL) {
+		return false
+	}
+
+	// LB23a
+	if left == _PR && right.is(_ID|_EB|_EM) {
+		return false
+	}
+	if left.is(_ID|_EB|_EM) && right == _PO {
+		return false
+	}
+
+	// LB24
+	if left.is(_PR|_PO) && right.is(_AL|_HL) {
+		return false
+	}
+	if left.is(_AL|_HL) && right.is(_PR|_PO) {
+		return false
+	}
+
+	// LB25, as tailored in https://unicode.org/reports/tr14/#Examples (example 7),
+	// which LineBreakTest.txt follows:
+	// (PR | PO) × ( OP | HY )? NU
+	if left.is(_PR|_PO) && (right == _NU || (right.is(_OP|_HY) && numberAhead)) {
+		return false
+	}
+	// ( OP | HY ) × NU
+	if left.is(_OP|_HY) && right == _NU {
+		return false
+	}
+	// NU ( NU | SY | IS )* × ( NU | SY | IS | CL | CP )
+	if numeric == 1 && right.is(_NU|_SY|_IS|_CL|_CP) {
+		return false
+	}
+	// NU ( NU | SY | IS )* ( CL | CP )? × ( PO | PR )
+	if numeric > 0 && right.is(_PO|_PR) {
+		return false
+	}
+
+	// LB26
+	if left == _JL && right.is(_JL|_JV|_H2|_H3) {
+		return false
+	}
+	if left.is(_JV|_H2) && right.is(_JV|_JT) {
+		return false
+	}
+	if left.is(_JT|_H3) && right == _JT {
+		return false
+	}
+
+	// LB27
+	if left.is(_JL|_JV|_JT|_H2|_H3) && right == _PO {
+		return false
+	}
+	if left == _PR && right.is(_JL|_JV|_JT|_H2|_H3) {
+		return false
+	}
+
+	// LB28
+	if left.is(_AL|_HL) && right.is(_AL|_HL) {
+		return false
+	}
+
+	// LB29
+	if left == _IS && right.is(_AL|_HL) {
+		return false
+	}
+
+	// LB30
+	if left.is(_AL|_HL|_NU) && right == _OP && !isEastAsian(rightRune) {
+		return false
+	}
+	if left == _CP && !isEastAsian(leftRune) && right.is(_AL|_HL|_NU) {
+		return false
+	}
+
+	// LB30a
+	if left == _RI && right == _RI && regionals%2 == 1 {
+		return false
+	}
+
+	// LB30b
+	if right == _EM && (left == _EB || (left == _ID && isPictographicCn(leftRune))) {
+		return false
+	}
+
+	// LB31
+	return true
+}
@@ -0,0 +1,83 @@
+package lines_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/clipperhouse/uax29/lines"
+)
+
+// unassigned determines if r is unassigned, per package unicode, whose
+// version must be at least that of the tables
+func unassigned(r rune) bool {
+	return !unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z,
+		unicode.Cc, unicode.Cf, unicode.Co, unicode.Cs)
+}
+
+// eachUnassigned calls f with unassigned code points: all of them in the
+// first three planes and in the tags block, and a sample of the rest
+func eachUnassigned(t *testing.T, f func(r rune)) {
+	major, _ := strconv.Atoi(strings.Split(unicode.Version, ".")[0])
+	if major < 15 {
+		t.Skipf("package unicode is version %s, older than the tables", unicode.Version)
+	}
+
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		if r >= 0x30000 && (r < 0xE0000 || r > 0xE0FFF) && r%256 != 0 {
+			continue
+		}
+		if r >= 0xD800 && r <= 0xDFFF || !unassigned(r) {
+			continue
+		}
+		f(r)
+	}
+}
+
+func TestUnassigned(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+		rule     string
+	}
+
+	tests := []test{
+		{"\U00040000\U00040000", []string{"\U00040000\U00040000"}, "AL, LB28"},
+		{"a\u0378b c", []string{"a\u0378b ", "c"}, "AL, LB28"},
+		{"\U00040000\u0301", []string{"\U00040000\u0301"}, "AL, LB9"},
+		{"\U0002FFFD\U0002FFFD", []string{"\U0002FFFD", "\U0002FFFD"}, "ID, LB31"},
+		{"\U0001FAFF\U0001FAFF", []string{"\U0001FAFF", "\U0001FAFF"}, "ID, LB31"},
+		{"\U0001FAFF\U0001F3FB", []string{"\U0001FAFF\U0001F3FB"}, "LB30b"},
+		{"\U0001FAFF\u200D\U0001FAFF", []string{"\U0001FAFF\u200D\U0001FAFF"}, "LB8a"},
+	}
+
+	for _, test := range tests {
+		var got []string
+		seg := lines.NewSegmenter([]byte(test.input))
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: for %+q, expected %+q, got %+q", test.rule, test.input, test.expected, got)
+		}
+	}
+
+	seg := lines.NewSegmenter(nil)
+	eachUnassigned(t, func(r rune) {
+		s := string(r)
+
+		seg.SetText([]byte(s + "\u0301 a"))
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		expected := []string{s + "\u0301 ", "a"}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("for %U, expected %+q, got %+q", r, expected, got)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package paragraphs_test
+
+import (
+	"fmt"
+
+	"github.com/clipperhouse/uax29/paragraphs"
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+func ExampleNewSegmenter() {
+	text := []byte("This is the first paragraph.\nIt is hard-wrapped.\n\nThis is the second. It has two sentences.")
+
+	seg := paragraphs.NewSegmenter(text)
+
+	for seg.Next() {
+		fmt.Printf("%q\n", seg.Bytes())
+
+		sents := sentences.NewSegmenter(seg.Bytes())
+		for sents.Next() {
+			fmt.Printf("\t%q\n", sents.Bytes())
+		}
+	}
+	// Output: "This is the first paragraph.\nIt is hard-wrapped.\n\n"
+	// 	"This is the first paragraph.\n"
+	// 	"It is hard-wrapped.\n"
+	// 	"\n"
+	// "This is the second. It has two sentences."
+	// 	"This is the second. "
+	// 	"It has two sentences."
+}
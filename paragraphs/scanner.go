@@ -0,0 +1,26 @@
+// Package paragraphs implements paragraph boundaries, for segmenting text
+// before sentences and words.
+//
+// Paragraphs are not defined by UAX #29; this package follows the
+// conventions of plain text. A paragraph ends at a blank line, i.e. two or
+// more line breaks with only whitespace between them, or at a paragraph
+// separator (U+2029). A line break is LF, CR, CRLF, NEL (U+0085) or a line
+// separator (U+2028); a single line break does not end a paragraph, as in
+// hard-wrapped text. Each token includes the blank lines or separator which
+// end it, so that tokens are contiguous. This package is experimental.
+package paragraphs
+
+import (
+	"io"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+// NewScanner returns a Scanner, to tokenize paragraphs. Iterate through
+// paragraphs by calling Scan() until false, then check Err(). See also the
+// bufio.Scanner docs; a paragraph longer than the Scanner's maximum token
+// size is an error, unless BreakLongTokens is used.
+func NewScanner(r io.Reader) *iterators.Scanner {
+	sc := iterators.NewScanner(r, SplitFunc)
+	return sc
+}
@@ -0,0 +1,35 @@
+package paragraphs
+
+import (
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+// NewSegmenter retuns a Segmenter, which is an iterator over the source text.
+// Iterate while Next() is true, and access the segmented paragraphs via Bytes().
+func NewSegmenter(data []byte) *iterators.Segmenter {
+	seg := iterators.NewSegmenter(SplitFunc)
+	seg.SetText(data)
+	return seg
+}
+
+// SegmentAll will iterate through all tokens and collect them into a [][]byte.
+// This is a convenience method -- if you will be allocating such a slice anyway,
+// this will save you some code. The downside is that this allocation is
+// unbounded -- O(n) on the number of tokens. Use Segmenter for more bounded
+// memory usage.
+func SegmentAll(data []byte) [][]byte {
+	// Optimization: guesstimate that the average paragraph is 256 bytes,
+	// allocate a large enough array to avoid resizing
+	result := make([][]byte, 0, len(data)/256)
+
+	_ = iterators.All(data, &result, SplitFunc) // can elide the error, see tests
+	return result
+}
+
+// Count returns the number of paragraphs in data, as would be returned by
+// SegmentAll or a Segmenter, without collecting them. Note that leading
+// blank lines, if any, are a token, and are counted.
+func Count(data []byte) int {
+	n, _ := iterators.Count(data, SplitFunc) // can elide the error, see tests
+	return n
+}
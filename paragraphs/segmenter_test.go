@@ -0,0 +1,218 @@
+package paragraphs_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	mathrand "math/rand"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/paragraphs"
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+func TestSegmenter(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+		comment  string
+	}
+
+	tests := []test{
+		{"", nil, "empty"},
+		{"Hello.", []string{"Hello."}, "one paragraph"},
+		{"One.\nTwo.", []string{"One.\nTwo."}, "single line break"},
+		{"One.\n\nTwo.", []string{"One.\n\n", "Two."}, "blank line"},
+		{"One.\n\n\n\nTwo.", []string{"One.\n\n\n\n", "Two."}, "blank lines"},
+		{"One.\n  \t\nTwo.", []string{"One.\n  \t\n", "Two."}, "whitespace line"},
+		{"One.\n\n  Two.", []string{"One.\n\n", "  Two."}, "indentation"},
+		{"One.\r\n\r\nTwo.", []string{"One.\r\n\r\n", "Two."}, "CRLF"},
+		{"One.\r\rTwo.", []string{"One.\r\r", "Two."}, "CR"},
+		{"One.\r\nTwo.", []string{"One.\r\nTwo."}, "single CRLF"},
+		{"One.\u0085\u0085Two.", []string{"One.\u0085\u0085", "Two."}, "NEL"},
+		{"One.\u2028Two.", []string{"One.\u2028Two."}, "line separator"},
+		{"One.\u2028\u2028Two.", []string{"One.\u2028\u2028", "Two."}, "line separators"},
+		{"One.\u2029Two.", []string{"One.\u2029", "Two."}, "paragraph separator"},
+		{"One.\u2029\nTwo.", []string{"One.\u2029\n", "Two."}, "paragraph separator and blank line"},
+		{"\n\nOne.", []string{"\n\n", "One."}, "leading blank lines"},
+		{"\nOne.", []string{"\n", "One."}, "leading line break"},
+		{"  One.", []string{"  One."}, "leading indentation"},
+		{"One.\n\n  ", []string{"One.\n\n  "}, "trailing whitespace"},
+		{"One.\n", []string{"One.\n"}, "trailing line break"},
+		{" \n ", []string{" \n "}, "only whitespace"},
+	}
+
+	for _, test := range tests {
+		var got []string
+		seg := paragraphs.NewSegmenter([]byte(test.input))
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: for %q, expected %q, got %q", test.comment, test.input, test.expected, got)
+		}
+	}
+}
+
+func TestSegmenterRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	const runs = 2000
+
+	seg := paragraphs.NewSegmenter(nil)
+
+	for i := 0; i < runs; i++ {
+		input := getRandomBytes()
+		seg.SetText(input)
+
+		var output []byte
+		for seg.Next() {
+			output = append(output, seg.Bytes()...)
+		}
+
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(output, input) {
+			t.Fatal("input bytes are not the same as segmented bytes")
+		}
+	}
+}
+
+func TestSegmenterInvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	input, err := os.ReadFile("../testdata/UTF-8-test.txt")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if utf8.Valid(input) {
+		t.Error("input file should not be valid utf8")
+	}
+
+	seg := paragraphs.NewSegmenter(input)
+
+	var output []byte
+	c := 0
+	for seg.Next() {
+		output = append(output, seg.Bytes()...)
+		c++
+	}
+	if err := seg.Err(); err != nil {
+		t.Error(err)
+	}
+
+	if !bytes.Equal(output, input) {
+		t.Fatalf("input bytes are not the same as segmented bytes")
+	}
+
+	if got := paragraphs.Count(input); got != c {
+		t.Errorf("calling Count should be identical to counting Segmenter, expected %d, got %d", c, got)
+	}
+}
+
+// TestScanner ensures that tokens are the same when data arrives in small
+// pieces, which exercises the requests for more data
+func TestScanner(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"One.\r\n\r\nTwo.\r\rThree.\u2029Four.\u2028\u2028Five.\n \n \n  Six.\n",
+		strings.Repeat("A line.\n \t\n\n", 50),
+	}
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs = append(inputs, string(file))
+
+	for _, input := range inputs {
+		expected := paragraphs.SegmentAll([]byte(input))
+
+		var got [][]byte
+		sc := paragraphs.NewScanner(strings.NewReader(input))
+		sc.Buffer(make([]byte, 1), 64*1024)
+		for sc.Scan() {
+			got = append(got, append([]byte(nil), sc.Bytes()...))
+		}
+		if err := sc.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected Scanner to be identical to SegmentAll, for %.40q", input)
+		}
+	}
+}
+
+// TestSentences ensures that paragraph boundaries are sentence boundaries,
+// so that sentences may be segmented within paragraphs
+func TestSentences(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := [][]byte{
+		file,
+		[]byte("One.\n  \t\nTwo.\r\n\r\nThree\u2029four.\u2028\u2028Five\u0085\u0085six.\n\n  "),
+	}
+	for i := 0; i < 100; i++ {
+		// Invalid UTF-8 is undefined behavior for sentences
+		inputs = append(inputs, bytes.ToValidUTF8(getRandomBytes(), []byte("\uFFFD")))
+	}
+
+	for _, input := range inputs {
+		expected := sentences.SegmentAll(input)
+
+		var got [][]byte
+		seg := paragraphs.NewSegmenter(input)
+		for seg.Next() {
+			got = append(got, sentences.SegmentAll(seg.Bytes())...)
+		}
+
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected sentences within paragraphs to be identical to sentences, for %.40q", input)
+		}
+	}
+}
+
+func getRandomBytes() []byte {
+	const max = 10000
+	const min = 1
+
+	len := mathrand.Intn(max-min) + min
+	b := make([]byte, len)
+	rand.Read(b)
+
+	return b
+}
+
+func BenchmarkSegmenter(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		b.Error(err)
+	}
+
+	b.ResetTimer()
+	b.SetBytes(int64(len(file)))
+
+	seg := paragraphs.NewSegmenter(file)
+	for i := 0; i < b.N; i++ {
+		seg.SetText(file)
+		for seg.Next() {
+		}
+	}
+}
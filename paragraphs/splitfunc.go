@@ -0,0 +1,114 @@
+package paragraphs
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitFunc is a bufio.SplitFunc implementation of paragraph boundaries, for
+// use with bufio.Scanner. Each token is a paragraph, including the blank lines
+// or paragraph separator which end it. Blank lines at the start of the text
+// are a token of their own.
+func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	// Blank lines at the start of text, as if preceded by a line break
+	n, more := blankLines(data, atEOF)
+	if more {
+		return 0, nil, nil
+	}
+	if n > 0 {
+		return n, data[:n], nil
+	}
+
+	pos := 0
+	for {
+		if pos == len(data) {
+			if !atEOF {
+				// Token extends past current data, request more
+				return 0, nil, nil
+			}
+			return pos, data[:pos], nil
+		}
+
+		w, sep, more := lineBreak(data[pos:], atEOF)
+		if more {
+			return 0, nil, nil
+		}
+		if w == 0 {
+			_, w = utf8.DecodeRune(data[pos:])
+			pos += w
+			continue
+		}
+		pos += w
+
+		n, more := blankLines(data[pos:], atEOF)
+		if more {
+			return 0, nil, nil
+		}
+		if n > 0 || sep {
+			pos += n
+			return pos, data[:pos], nil
+		}
+	}
+}
+
+// lineBreak returns the width of the line break or paragraph separator at the
+// start of data, or 0 if there is none. sep is true for a paragraph
+// separator. more is true if data ends before it can be determined.
+func lineBreak(data []byte, atEOF bool) (w int, sep, more bool) {
+	if !atEOF && !utf8.FullRune(data) {
+		return 0, false, true
+	}
+
+	r, w := utf8.DecodeRune(data)
+	switch r {
+	case '\r':
+		if len(data) == 1 && !atEOF {
+			// Might be CRLF
+			return 0, false, true
+		}
+		if len(data) > 1 && data[1] == '\n' {
+			return 2, false, false
+		}
+		return 1, false, false
+	case '\n', '\u0085', '\u2028':
+		return w, false, false
+	case '\u2029':
+		return w, true, false
+	}
+	return 0, false, false
+}
+
+// blankLines returns the width of the lines at the start of data which are
+// blank, i.e. only whitespace and a line break. Trailing whitespace at EOF is
+// included. more is true if data ends before it can be determined.
+func blankLines(data []byte, atEOF bool) (n int, more bool) {
+	pos := 0
+	for {
+		if pos == len(data) {
+			if atEOF {
+				return pos, false
+			}
+			return 0, true
+		}
+
+		w, _, more := lineBreak(data[pos:], atEOF)
+		if more {
+			return 0, true
+		}
+		if w > 0 {
+			pos += w
+			n = pos
+			continue
+		}
+
+		r, w := utf8.DecodeRune(data[pos:])
+		if !unicode.IsSpace(r) {
+			return n, false
+		}
+		pos += w
+	}
+}
@@ -0,0 +1,52 @@
+package phrases_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/clipperhouse/uax29/phrases"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// TestGolden segments a corpus of informal text, and compares the phrases
+// with a golden file, so that changes in behavior show up as diffs. To
+// accept changes, run go test -run Golden -update.
+func TestGolden(t *testing.T) {
+	t.Parallel()
+
+	input, err := os.ReadFile("../testdata/chat.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seg := phrases.NewSegmenter(input)
+
+	var got bytes.Buffer
+	for seg.Next() {
+		got.WriteString(strconv.Quote(seg.Text()))
+		got.WriteByte('\n')
+	}
+
+	path := filepath.Join("..", "testdata", "golden", "phrases.txt")
+
+	if *update {
+		if err := os.WriteFile(path, got.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got.Bytes(), expected) {
+		t.Error("phrases differ from the golden file; if the change is intended, run go test -run Golden -update")
+	}
+}
@@ -0,0 +1,47 @@
+package phrases
+
+import (
+	"bufio"
+	"unicode/utf8"
+)
+
+// Joiners allows specification of characters (runes) which will join phrases
+// (tokens) rather than breaking them. For example, "—" breaks phrases by
+// default, but you might wish to keep an aside, as in "a nice — and totally
+// adorable — dog", within a phrase.
+type Joiners struct {
+	// Middle specifies which characters (runes) should join phrases where
+	// they would otherwise be split, in the middle of a phrase, i.e. between
+	// words or numbers, ignoring spaces.
+	//
+	// For example, specifying "—…" will join "nice — and totally adorable"
+	// and "well… maybe". A joiner which ends a phrase, as in "dog—", or which
+	// begins one, is still split.
+	Middle []rune
+}
+
+// SplitFunc returns a bufio.SplitFunc implementation of phrase segmentation
+// with the Joiners, for use with a Segmenter or Scanner:
+//
+//	seg := phrases.NewSegmenter(text)
+//	seg.Split(j.SplitFunc())
+func (j *Joiners) SplitFunc() bufio.SplitFunc {
+	return j.splitFunc
+}
+
+// middle determines if the rune at the start of data is a Middle joiner
+func (j *Joiners) middle(data []byte) bool {
+	if j == nil || len(j.Middle) == 0 {
+		return false
+	}
+	r, w := utf8.DecodeRune(data)
+	if r == utf8.RuneError && w <= 1 {
+		return false
+	}
+	for _, m := range j.Middle {
+		if m == r {
+			return true
+		}
+	}
+	return false
+}
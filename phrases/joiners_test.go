@@ -0,0 +1,59 @@
+package phrases_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/internal/segtest"
+	"github.com/clipperhouse/uax29/phrases"
+)
+
+func TestJoinersMiddle(t *testing.T) {
+	t.Parallel()
+
+	joiners := &phrases.Joiners{
+		Middle: []rune("—…"),
+	}
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{"Nice — and totally adorable — dog; perhaps", []string{"Nice — and totally adorable — dog", ";", " perhaps"}},
+		{"Well… maybe", []string{"Well… maybe"}},
+		{"a—b", []string{"a—b"}},
+		{"Born 1990—2020", []string{"Born 1990—2020"}},
+		// A joiner at the end or start of a phrase is split
+		{"Wait for it—", []string{"Wait for it", "—"}},
+		{"dog —, cat", []string{"dog ", "—", ",", " cat"}},
+		{"— and", []string{"—", " and"}},
+		// Other punctuation is unaffected
+		{"Hello, world", []string{"Hello", ",", " world"}},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, joiners.SplitFunc(), nil, []byte(test.input), test.expected)
+	}
+}
+
+func TestJoinersNone(t *testing.T) {
+	t.Parallel()
+
+	// Zero Joiners should be identical to SplitFunc
+	text := []byte("Hello, 世界. Nice — and totally adorable — dog; perhaps the “best one”! 🏆 🐶")
+
+	expected := phrases.SegmentAll(text)
+	for _, joiners := range []*phrases.Joiners{nil, {}} {
+		var got [][]byte
+		seg := phrases.NewSegmenter(text)
+		seg.Split(joiners.SplitFunc())
+		for seg.Next() {
+			got = append(got, seg.Bytes())
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("%+v: expected %q, got %q", joiners, expected, got)
+		}
+	}
+}
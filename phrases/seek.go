@@ -1,6 +1,9 @@
 package phrases
 
-import "unicode/utf8"
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
 
 // previousIndex works backward until it hits a rune in properties,
 // ignoring runes with the _Ignore property (per WB4), and returns
@@ -73,3 +76,51 @@ func subsequent(properties property, data []byte, atEOF bool) (found bool, more
 	// More to evaluate
 	return false, true
 }
+
+// subsequentWord looks ahead in the buffer until it hits a rune which is a
+// word or number, ignoring spaces and runes with the _Ignore property, see
+// Joiners.Middle
+func subsequentWord(data []byte, atEOF bool) (found bool, more bool) {
+	i := 0
+	for i < len(data) {
+		lookup, w := trie.lookup(data[i:])
+		if w == 0 {
+			if atEOF {
+				// Nothing more to evaluate
+				return false, false
+			}
+			// More to evaluate
+			return false, true
+		}
+
+		if lookup.is(_Ignore | _WSegSpace) {
+			i += w
+			continue
+		}
+
+		return lookup.is(_Numeric | _AHLetter), false
+	}
+
+	if atEOF {
+		// Nothing more to evaluate
+		return false, false
+	}
+	// More to evaluate
+	return false, true
+}
+
+// spaces returns the length of the run of ASCII spaces at the start of data.
+// It compares eight bytes at a time, which is much faster than a trie lookup
+// per space, for the long runs of spaces in formatted text.
+func spaces(data []byte) int {
+	const eight = 0x2020202020202020 // eight spaces
+
+	n := 0
+	for len(data)-n >= 8 && binary.LittleEndian.Uint64(data[n:]) == eight {
+		n += 8
+	}
+	for n < len(data) && data[n] == ' ' {
+		n++
+	}
+	return n
+}
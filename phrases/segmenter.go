@@ -25,3 +25,11 @@ func SegmentAll(data []byte) [][]byte {
 	_ = iterators.All(data, &result, SplitFunc) // can elide the error, see tests
 	return result
 }
+
+// Count returns the number of phrases in data, as would be returned by
+// SegmentAll or a Segmenter, without collecting them. Note that all segments
+// are counted, including whitespace and punctuation.
+func Count(data []byte) int {
+	n, _ := iterators.Count(data, SplitFunc) // can elide the error, see tests
+	return n
+}
@@ -3,11 +3,13 @@ package phrases_test
 import (
 	"bytes"
 	"os"
+	"strings"
 	"testing"
 	"time"
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/clipperhouse/uax29/internal/segtest"
 	"github.com/clipperhouse/uax29/iterators"
 	"github.com/clipperhouse/uax29/iterators/filter"
 	"github.com/clipperhouse/uax29/phrases"
@@ -74,8 +76,10 @@ func TestSegmenterInvalidUTF8(t *testing.T) {
 	sc := phrases.NewSegmenter(input)
 
 	var output []byte
+	c := 0
 	for sc.Next() {
 		output = append(output, sc.Bytes()...)
+		c++
 	}
 	if err := sc.Err(); err != nil {
 		t.Error(err)
@@ -84,6 +88,10 @@ func TestSegmenterInvalidUTF8(t *testing.T) {
 	if !bytes.Equal(output, input) {
 		t.Fatalf("input bytes are not the same as segmented bytes")
 	}
+
+	if got := phrases.Count(input); got != c {
+		t.Errorf("calling Count should be identical to counting Segmenter, expected %d, got %d", c, got)
+	}
 }
 
 var exists = struct{}{}
@@ -121,6 +129,32 @@ func TestPhraseBoundaries(t *testing.T) {
 	}
 }
 
+func TestSpaceRuns(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{"a" + strings.Repeat(" ", 17) + "b", []string{"a" + strings.Repeat(" ", 17) + "b"}},
+		{strings.Repeat(" ", 8) + "indented", []string{strings.Repeat(" ", 8) + "indented"}},
+		{"a," + strings.Repeat(" ", 9) + "b", []string{"a", ",", strings.Repeat(" ", 9) + "b"}},
+		{"end" + strings.Repeat(" ", 7), []string{"end" + strings.Repeat(" ", 7)}},
+		{"a   \nb", []string{"a   ", "\n", "b"}},
+		{",        \u0301,", []string{",", "        \u0301", ","}},
+		{"a        \u0301b", []string{"a        \u0301b"}},
+		{"a \u3000        b", []string{"a \u3000        b"}},
+		{"a         \U0001F44D", []string{"a         \U0001F44D"}},
+		{"a\t        b", []string{"a", "\t", "        b"}},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, phrases.SplitFunc, nil, []byte(test.input), test.expected)
+	}
+}
+
 func BenchmarkSegmenter(b *testing.B) {
 	file, err := os.ReadFile("../testdata/sample.txt")
 
@@ -180,3 +214,39 @@ func BenchmarkSegmentAll(b *testing.B) {
 		b.ReportMetric(float64(c), "tokens")
 	}
 }
+
+// indented returns sample.txt with each line indented, as in formatted text
+// such as code, or plain-text documents with hard-wrapped, aligned columns
+func indented(b *testing.B) []byte {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var result []byte
+	for i, line := range bytes.Split(file, []byte("\n")) {
+		result = append(result, bytes.Repeat([]byte(" "), 4*(i%8))...)
+		result = append(result, line...)
+		result = append(result, "          \n"...)
+	}
+	return result
+}
+
+func BenchmarkSegmenterIndented(b *testing.B) {
+	file := indented(b)
+
+	b.ResetTimer()
+	b.SetBytes(int64(len(file)))
+	seg := phrases.NewSegmenter(file)
+
+	for i := 0; i < b.N; i++ {
+		seg.SetText(file)
+
+		for seg.Next() {
+		}
+
+		if err := seg.Err(); err != nil {
+			b.Error(err)
+		}
+	}
+}
@@ -15,6 +15,13 @@ const (
 
 // SplitFunc is a bufio.SplitFunc implementation of phrase segmentation, for use with bufio.Scanner.
 func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return none.splitFunc(data, atEOF)
+}
+
+// none is no Joiners, i.e. the default
+var none *Joiners
+
+func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	if len(data) == 0 {
 		return 0, nil, nil
 	}
@@ -102,6 +109,11 @@ func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 		// https://unicode.org/reports/tr29/#WB3d
 		if (current & last).is(_WSegSpace) {
 			pos += w
+			if n := spaces(data[pos:]); n > 0 {
+				// Optimization: the run continues per WB3d, without lookups
+				pos += n
+				lastExIgnore = _WSegSpace
+			}
 			continue
 		}
 
@@ -175,6 +187,13 @@ func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 		// _WSegSpace is added for phrases: treat spaces adjacent to words as non-breaking.
 		if current.is(_Numeric|_AHLetter|_WSegSpace) && lastExIgnore.is(_Numeric|_AHLetter|_WSegSpace) {
 			pos += w
+			if current.is(_WSegSpace) {
+				if n := spaces(data[pos:]); n > 0 {
+					// Optimization: the run continues per WB3d, without lookups
+					pos += n
+					lastExIgnore = _WSegSpace
+				}
+			}
 			continue
 		}
 
@@ -229,6 +248,24 @@ func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			}
 		}
 
+		// Custom joiners, see Joiners.Middle. A joiner joins if it follows, and
+		// precedes, a word or number, ignoring spaces; thereafter it is
+		// treated as a space, so the subsequent word joins per WB8-10 above.
+		if lastExIgnore.is(_Numeric|_AHLetter|_WSegSpace) && j.middle(data[pos:]) {
+			found, more := subsequentWord(data[pos+w:], atEOF)
+
+			if more {
+				// Token extends past current data, request more
+				return 0, nil, nil
+			}
+
+			if found {
+				current = _WSegSpace
+				pos += w
+				continue
+			}
+		}
+
 		// https://unicode.org/reports/tr29/#WB999
 		// If we fall through all the above rules, it's a phrase break
 		break
@@ -0,0 +1,44 @@
+package sentences
+
+// IsBoundary determines if position i of data is a sentence boundary, as
+// would be found by a Segmenter, without segmenting from the start of data.
+// Positions 0 and len(data) are boundaries for non-empty data; positions
+// outside data are not.
+//
+// It looks back from i to the nearest line break, which is always followed
+// by a boundary (SB4), and segments forward from there. In the worst case (a
+// long text with no line breaks), that is the start of data.
+//
+// Data is assumed to be valid UTF-8. A Segmenter returns the remainder of
+// invalid text from an incomplete rune onward as a single token, which
+// IsBoundary does not look back far enough to see.
+func IsBoundary(data []byte, i int) bool {
+	if len(data) == 0 || i < 0 || i > len(data) {
+		return false
+	}
+	if i == 0 || i == len(data) {
+		return true
+	}
+
+	pos := i
+	for pos > 0 && !knownBoundary(data, pos) {
+		pos--
+	}
+
+	for pos < i {
+		advance, _, _ := SplitFunc(data[pos:], true) // can elide the error, see tests
+		if advance <= 0 {
+			return false
+		}
+		pos += advance
+	}
+
+	return pos == i
+}
+
+// knownBoundary determines if 0 < pos < len(data) is a boundary, by the
+// bytes on either side of it. It may return false for a boundary.
+func knownBoundary(data []byte, pos int) bool {
+	a, b := data[pos-1], data[pos]
+	return a == '\n' || (a == '\r' && b != '\n')
+}
@@ -0,0 +1,80 @@
+package sentences_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+// boundaries returns the boundaries of data, as found by a Segmenter, indexed by position
+func boundaries(data []byte) []bool {
+	result := make([]bool, len(data)+1)
+	if len(data) == 0 {
+		return result
+	}
+
+	pos := 0
+	result[pos] = true
+	seg := sentences.NewSegmenter(data)
+	for seg.Next() {
+		pos += len(seg.Bytes())
+		result[pos] = true
+	}
+
+	return result
+}
+
+func testIsBoundary(t *testing.T, data []byte) {
+	t.Helper()
+
+	expected := boundaries(data)
+	for i, b := range expected {
+		if got := sentences.IsBoundary(data, i); got != b {
+			t.Fatalf("at %d of %q: expected %t, got %t", i, data, b, got)
+		}
+	}
+}
+
+func TestIsBoundary(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range unicodeTests {
+		testIsBoundary(t, test.input)
+	}
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testIsBoundary(t, file)
+
+	for i := 0; i < 100; i++ {
+		// An incomplete rune consumes the remainder of the text, see IsBoundary
+		testIsBoundary(t, bytes.ToValidUTF8(getRandomBytes(), []byte("\uFFFD")))
+	}
+
+	data := []byte("Hello, world.")
+	for _, i := range []int{-1, len(data) + 1} {
+		if sentences.IsBoundary(data, i) {
+			t.Errorf("expected %d to be out of range", i)
+		}
+	}
+	if sentences.IsBoundary(nil, 0) {
+		t.Error("expected empty data to have no boundaries")
+	}
+}
+
+func BenchmarkIsBoundary(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	i := len(file) / 2
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sentences.IsBoundary(file, i)
+	}
+}
@@ -0,0 +1,90 @@
+package sentences_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/clipperhouse/uax29/internal/segtest"
+	"github.com/clipperhouse/uax29/iterators"
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+func TestBoundedSplitFunc(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		limit    int
+		expected []string
+	}
+
+	tests := []test{
+		{"Fig. 1 2 3 4 5 6 7 8 9 and more.", 0, []string{"Fig. 1 2 3 4 5 6 7 8 9 and more."}},
+		{"Fig. 1 2 3 4 5 6 7 8 9 and more.", 100, []string{"Fig. 1 2 3 4 5 6 7 8 9 and more."}},
+		{"Fig. 1 2 3 4 5 6 7 8 9 and more.", 4, []string{"Fig. ", "1 2 3 4 5 6 7 8 9 and more."}},
+		{"Fig. 1 and more.", 4, []string{"Fig. 1 and more."}},
+		{"e.g. this is one sentence. This is another.", 4, []string{"e.g. this is one sentence. ", "This is another."}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("limit %d", test.limit), func(t *testing.T) {
+			segtest.AssertSegments(t, sentences.BoundedSplitFunc(test.limit), nil, []byte(test.input), test.expected)
+		})
+	}
+}
+
+func TestBoundedSplitFuncUnbounded(t *testing.T) {
+	t.Parallel()
+
+	// With no limit, should be identical to SplitFunc
+	seg1 := sentences.NewSegmenter(nil)
+	seg2 := sentences.NewSegmenter(nil)
+	seg2.Split(sentences.BoundedSplitFunc(0))
+
+	for _, test := range unicodeTests {
+		seg1.SetText(test.input)
+		seg2.SetText(test.input)
+
+		for seg1.Next() {
+			if !seg2.Next() || !bytes.Equal(seg1.Bytes(), seg2.Bytes()) {
+				t.Fatalf("%q: expected %q, got %q", test.input, seg1.Bytes(), seg2.Bytes())
+			}
+		}
+		if seg2.Next() {
+			t.Fatalf("%q: unexpected token %q", test.input, seg2.Bytes())
+		}
+	}
+}
+
+// BenchmarkBoundedAdversarial segments long runs after an ATerm, in which
+// the SB8 lookahead is evaluated at every position, up to the limit
+func BenchmarkBoundedAdversarial(b *testing.B) {
+	inputs := []struct {
+		name, prefix, unit string
+	}{
+		{"Sp", "a.", " "},
+		{"Close", "a.", ")"},
+		{"Numeric", "a. ", "1 "},
+	}
+
+	for _, limit := range []int{0, 256, 4096} {
+		split := sentences.BoundedSplitFunc(limit)
+
+		for _, input := range inputs {
+			data := append([]byte(input.prefix), bytes.Repeat([]byte(input.unit), 100_000/len(input.unit))...)
+
+			b.Run(fmt.Sprintf("%s/%d", input.name, limit), func(b *testing.B) {
+				seg := iterators.NewSegmenter(split)
+				b.SetBytes(int64(len(data)))
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					seg.SetText(data)
+					for seg.Next() {
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package sentences
+
+import (
+	"hash/maphash"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Dedup finds duplicate sentences, such as boilerplate headers and footers,
+// across a set of documents. Sentences are hashed, not retained, so it uses a
+// small, fixed amount of memory per distinct sentence, and sentences may alias
+// the text of a Segmenter or Scanner. The zero value is ready to use.
+//
+// Hashes are 128 bits, so the chance that distinct sentences are mistaken for
+// duplicates is negligible, even for billions of sentences. Hashes are seeded
+// randomly, so they are not stable across Dedups or processes.
+//
+// A Dedup is not safe for concurrent use. This API is experimental.
+type Dedup struct {
+	// Space ignores leading and trailing whitespace, and treats runs of
+	// whitespace as a single space, so "Hello  world. " is a duplicate of
+	// "Hello world.".
+	Space bool
+
+	// Case ignores case, comparing the lower case of each rune, so
+	// "HELLO world." is a duplicate of "Hello World.".
+	Case bool
+
+	hashes [2]maphash.Hash
+	counts map[[2]uint64]int
+}
+
+func (d *Dedup) init() {
+	if d.counts != nil {
+		return
+	}
+	d.counts = make(map[[2]uint64]int)
+	for i := range d.hashes {
+		d.hashes[i].SetSeed(maphash.MakeSeed())
+	}
+}
+
+// key returns the hash of the sentence, after normalization
+func (d *Dedup) key(sentence []byte) [2]uint64 {
+	d.init()
+	for i := range d.hashes {
+		d.hashes[i].Reset()
+	}
+
+	if !d.Space && !d.Case {
+		// Optimization: hash the sentence directly
+		d.write(sentence)
+		return d.sum()
+	}
+
+	var buf [utf8.UTFMax]byte
+	space := false // a space is pending, which is written before the next non-space
+	written := false
+	for pos := 0; pos < len(sentence); {
+		r, w := rune(sentence[pos]), 1
+		if r >= utf8.RuneSelf {
+			r, w = utf8.DecodeRune(sentence[pos:])
+		}
+
+		if d.Space && unicode.IsSpace(r) {
+			space = written
+			pos += w
+			continue
+		}
+		if space {
+			d.write(spaceBytes)
+			space = false
+		}
+
+		switch {
+		case d.Case && r < utf8.RuneSelf:
+			buf[0] = sentence[pos]
+			if 'A' <= r && r <= 'Z' {
+				buf[0] += 'a' - 'A'
+			}
+			d.write(buf[:1])
+		case d.Case && r != utf8.RuneError:
+			d.write(buf[:utf8.EncodeRune(buf[:], unicode.ToLower(r))])
+		default:
+			// Write the original bytes, so invalid UTF-8 is distinguished
+			d.write(sentence[pos : pos+w])
+		}
+		written = true
+		pos += w
+	}
+
+	return d.sum()
+}
+
+var spaceBytes = []byte{' '}
+
+func (d *Dedup) write(b []byte) {
+	for i := range d.hashes {
+		d.hashes[i].Write(b)
+	}
+}
+
+func (d *Dedup) sum() [2]uint64 {
+	return [2]uint64{d.hashes[0].Sum64(), d.hashes[1].Sum64()}
+}
+
+// Seen records the sentence, and determines if an equivalent sentence was
+// recorded before. To remove duplicates, keep the sentences which were not
+// Seen.
+func (d *Dedup) Seen(sentence []byte) bool {
+	k := d.key(sentence)
+	n := d.counts[k]
+	d.counts[k] = n + 1
+	return n > 0
+}
+
+// Count returns the number of times an equivalent sentence has been recorded,
+// without recording it. For example, to remove boilerplate, record the
+// sentences of every document with Seen, and then remove those with a Count
+// greater than some threshold.
+func (d *Dedup) Count(sentence []byte) int {
+	return d.counts[d.key(sentence)]
+}
+
+// Len returns the number of distinct sentences recorded.
+func (d *Dedup) Len() int {
+	return len(d.counts)
+}
+
+// Unique records the sentence, and returns true if it was not Seen before. It
+// is a filter.Func, for use with the Filter method of a Segmenter or Scanner,
+// to remove duplicate sentences. Because it records each sentence, it must
+// not be combined with Peek, which applies the filter a second time.
+func (d *Dedup) Unique(sentence []byte) bool {
+	return !d.Seen(sentence)
+}
+
+// Reset forgets all recorded sentences.
+func (d *Dedup) Reset() {
+	d.counts = nil
+}
@@ -0,0 +1,108 @@
+package sentences_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+func TestDedup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dedup    sentences.Dedup
+		a, b     string
+		expected bool
+	}{
+		{sentences.Dedup{}, "Hello world.", "Hello world.", true},
+		{sentences.Dedup{}, "Hello world.", "Hello world. ", false},
+		{sentences.Dedup{}, "Hello world.", "hello world.", false},
+		{sentences.Dedup{Space: true}, "Hello world.", " Hello \t world.\n", true},
+		{sentences.Dedup{Space: true}, "Hello world.", "Helloworld.", false},
+		{sentences.Dedup{Space: true}, "Hello world.", "hello world.", false},
+		{sentences.Dedup{Case: true}, "Hello World.", "HELLO world.", true},
+		{sentences.Dedup{Case: true}, "Ünïcode, Ελληνικά.", "üNÏCODE, ελληνικά.", true},
+		{sentences.Dedup{Case: true}, "Hello world.", "Hello  world.", false},
+		{sentences.Dedup{Space: true, Case: true}, "Hello World.", "  HELLO\u00a0world.  ", true},
+		{sentences.Dedup{Case: true}, "Bad \xff.", "Bad \xfe.", false},
+	}
+
+	for _, test := range tests {
+		d := test.dedup
+		if d.Seen([]byte(test.a)) {
+			t.Fatalf("%q should not have been seen", test.a)
+		}
+		if got := d.Count([]byte(test.b)); got != 0 != test.expected {
+			t.Errorf("Count(%q) after %q: expected duplicate %t", test.b, test.a, test.expected)
+		}
+		if got := d.Seen([]byte(test.b)); got != test.expected {
+			t.Errorf("Seen(%q) after %q: expected %t, got %t", test.b, test.a, test.expected, got)
+		}
+	}
+}
+
+func TestDedupFilter(t *testing.T) {
+	t.Parallel()
+
+	docs := []string{
+		"Welcome to the site. The first article. All rights reserved.",
+		"Welcome to the site. The second article. All rights reserved.",
+		"Welcome to the site. The third article. All rights reserved.",
+	}
+
+	// Remove exact duplicates, keeping the first
+	{
+		d := &sentences.Dedup{Space: true}
+		var got []string
+		for _, doc := range docs {
+			seg := sentences.NewSegmenter([]byte(doc))
+			seg.Filter(d.Unique)
+			for seg.Next() {
+				got = append(got, seg.Text())
+			}
+		}
+
+		expected := []string{
+			"Welcome to the site. ", "The first article. ", "All rights reserved.",
+			"The second article. ",
+			"The third article. ",
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+		if d.Len() != 5 {
+			t.Fatalf("expected 5 distinct sentences, got %d", d.Len())
+		}
+	}
+
+	// Remove boilerplate, which appears in more than one document
+	{
+		d := &sentences.Dedup{Space: true}
+		for _, doc := range docs {
+			seg := sentences.NewSegmenter([]byte(doc))
+			for seg.Next() {
+				d.Seen(seg.Bytes())
+			}
+		}
+
+		var got []string
+		for _, doc := range docs {
+			seg := sentences.NewSegmenter([]byte(doc))
+			seg.Filter(func(s []byte) bool { return d.Count(s) == 1 })
+			for seg.Next() {
+				got = append(got, seg.Text())
+			}
+		}
+
+		expected := []string{"The first article. ", "The second article. ", "The third article. "}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+
+		d.Reset()
+		if d.Len() != 0 || d.Count([]byte("Welcome to the site.")) != 0 {
+			t.Fatal("expected Reset to forget all sentences")
+		}
+	}
+}
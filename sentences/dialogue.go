@@ -0,0 +1,138 @@
+package sentences
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+// Sentence is a sentence with its quotations, for distinguishing dialogue
+// from narration, see [Dialogue].
+type Sentence struct {
+	// Span is the position of the sentence.
+	iterators.Span
+	// Quotes are the quotations which overlap the sentence, including nested
+	// quotations, in order of position. A quotation may span several
+	// sentences, such as "Stop. Wait.", and appears in each.
+	Quotes []Quote
+	// Narration is the positions of the unquoted parts of the sentence which
+	// contain a letter or number, such as "she said" in `"Hi," she said.`,
+	// without surrounding whitespace. They often attribute the speaker.
+	Narration []iterators.Span
+	// Coverage is the fraction of the sentence which is quoted, from 0 to 1,
+	// excluding whitespace.
+	Coverage float64
+}
+
+// IsDialogue determines if most of the sentence is quoted, i.e. a Coverage
+// of at least one half. For another threshold, or other criteria, use the
+// fields of Sentence.
+func (s Sentence) IsDialogue() bool {
+	return s.Coverage >= 0.5
+}
+
+// Dialogue returns the sentences of data, with their quotations, for
+// distinguishing dialogue from narration, as in fiction. See [Quotes] for
+// how quotations are found, and [Options.Dialogue] to segment with Options.
+// This API is experimental.
+func Dialogue(data []byte) []Sentence {
+	return spec.Dialogue(data)
+}
+
+// Dialogue returns the sentences of data, as segmented with the Options, with
+// their quotations, for distinguishing dialogue from narration, as in
+// fiction. See [Options.Quotes] for how quotations are found. This API is
+// experimental.
+func (o Options) Dialogue(data []byte) []Sentence {
+	spans := o.spans(data)
+	if len(spans) == 0 {
+		return nil
+	}
+
+	quotes := pairQuotes(data)
+	alignQuotes(quotes, spans)
+
+	sentences := make([]Sentence, len(spans))
+	for i, span := range spans {
+		sentences[i].Span = span
+	}
+
+	// Each quotation, to the sentences it overlaps
+	for _, q := range quotes {
+		first := sort.Search(len(spans), func(i int) bool {
+			return spans[i].End > q.Start
+		})
+		for i := first; i < len(spans) && spans[i].Start < q.End; i++ {
+			sentences[i].Quotes = append(sentences[i].Quotes, q)
+		}
+	}
+
+	// The union of quotations, which are sorted by Start, as disjoint spans
+	var quoted []iterators.Span
+	for _, q := range quotes {
+		if n := len(quoted); n > 0 && q.Start <= quoted[n-1].End {
+			if q.End > quoted[n-1].End {
+				quoted[n-1].End = q.End
+			}
+			continue
+		}
+		quoted = append(quoted, q.Span)
+	}
+
+	j := 0 // the first of quoted which may contain pos
+	for i := range sentences {
+		s := &sentences[i]
+
+		var total, covered int
+		narration := -1      // start of the current run of narration, or -1
+		var significant bool // the run contains a letter or number
+		end := -1            // end of the last non-space rune in the run
+
+		for pos := s.Start; pos < s.End; {
+			r, w := utf8.DecodeRune(data[pos:s.End])
+
+			for j < len(quoted) && quoted[j].End <= pos {
+				j++
+			}
+			inQuote := j < len(quoted) && quoted[j].Contains(pos)
+			space := unicode.IsSpace(r)
+
+			if !space {
+				total += w
+				if inQuote {
+					covered += w
+				}
+			}
+
+			switch {
+			case inQuote:
+				if narration >= 0 && significant {
+					s.Narration = append(s.Narration, iterators.Span{Start: narration, End: end})
+				}
+				narration = -1
+			case !space:
+				if narration < 0 {
+					narration = pos
+					significant = false
+				}
+				if unicode.IsLetter(r) || unicode.IsNumber(r) {
+					significant = true
+				}
+				end = pos + w
+			}
+
+			pos += w
+		}
+		if narration >= 0 && significant {
+			s.Narration = append(s.Narration, iterators.Span{Start: narration, End: end})
+		}
+
+		if total > 0 {
+			s.Coverage = float64(covered) / float64(total)
+		}
+	}
+
+	return sentences
+}
@@ -0,0 +1,78 @@
+package sentences_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+func TestDialogue(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`The room was quiet. "Is anyone here?" she asked. "Stop. Wait," he said, "listen."`)
+
+	type sentence struct {
+		text      string
+		quotes    int
+		narration []string
+		dialogue  bool
+	}
+
+	var got []sentence
+	for _, s := range sentences.Dialogue(input) {
+		var narration []string
+		for _, n := range s.Narration {
+			narration = append(narration, string(n.Slice(input)))
+		}
+		got = append(got, sentence{
+			text:      string(s.Slice(input)),
+			quotes:    len(s.Quotes),
+			narration: narration,
+			dialogue:  s.IsDialogue(),
+		})
+	}
+
+	expected := []sentence{
+		{"The room was quiet. ", 0, []string{"The room was quiet."}, false},
+		// By the spec, a sentence ends after ? and the closing quote
+		{`"Is anyone here?" `, 1, nil, true},
+		{"she asked. ", 0, []string{"she asked."}, false},
+		{`"Stop. `, 1, nil, true},
+		{`Wait," he said, "listen."`, 2, []string{"he said,"}, true},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestDialogueCoverage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		coverage float64
+	}{
+		{"No quotes.", 0},
+		{`"All quoted."`, 1},
+		{`"Hi" ok.`, 4.0 / 7.0}, // 4 of 7 non-space bytes
+		{`  "Padded."  `, 1},
+		{"", 0},
+	}
+
+	for _, test := range tests {
+		ss := sentences.Dialogue([]byte(test.input))
+		if test.input == "" {
+			if ss != nil {
+				t.Fatalf("expected no sentences for empty input, got %d", len(ss))
+			}
+			continue
+		}
+		if len(ss) != 1 {
+			t.Fatalf("%q: expected 1 sentence, got %d", test.input, len(ss))
+		}
+		if ss[0].Coverage != test.coverage {
+			t.Errorf("%q: expected coverage %v, got %v", test.input, test.coverage, ss[0].Coverage)
+		}
+	}
+}
@@ -54,3 +54,22 @@ func ExampleNewScanner() {
 	// "“Nice dog! "
 	// "👍🐶”, they said."
 }
+
+// BoundedSplitFunc caps the lookahead after a period, which bounds the work
+// on hostile input, at the cost of deviating from the spec for long runs of
+// spaces or punctuation.
+func ExampleBoundedSplitFunc() {
+	text := "See e.g. the docs. Then go."
+
+	sc := sentences.NewScanner(strings.NewReader(text))
+	sc.Split(sentences.BoundedSplitFunc(64))
+
+	for sc.Scan() {
+		fmt.Printf("%q\n", sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		log.Fatal(err)
+	}
+	// Output: "See e.g. the docs. "
+	// "Then go."
+}
@@ -0,0 +1,99 @@
+package sentences
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/clipperhouse/uax29/ansi"
+)
+
+// unicodeVersion is the version of the Unicode data in trie.go
+const unicodeVersion = "15.0.0"
+
+// rulesVersion is incremented on any change to this package which changes
+// tokenization, such as a fix to the rules; it is part of the Fingerprint.
+// TestFingerprintGuard will fail until it is. Record the change in
+// versions/changes.json, too.
+const rulesVersion = 1
+
+// optionsJSON is the canonical form of Options, which is hashed for the
+// Fingerprint, as words hashes the JSON form of its Joiners
+type optionsJSON struct {
+	Lookahead    int      `json:"lookahead,omitempty"`
+	Lists        bool     `json:"lists,omitempty"`
+	Suppressions []string `json:"suppressions,omitempty"`
+	ANSI         int      `json:"ansi,omitempty"`
+	Vertical     bool     `json:"vertical,omitempty"`
+	Joiners      string   `json:"joiners,omitempty"`
+	STerms       string   `json:"sterms,omitempty"`
+	ATerms       string   `json:"aterms,omitempty"`
+}
+
+// Fingerprint returns an identifier of the tokenization that the Options
+// will produce, with this version of this package, for use in cache keys. It
+// combines the version of the rules, the Unicode version and a hash of the
+// Options. Equal fingerprints imply identical tokenization of the same input;
+// a change to the rules or the data which changes tokenization changes the
+// fingerprint, invalidating caches on upgrade.
+//
+// Options which have no effect are normalized, so a Lookahead of zero or
+// less, or the order of Suppressions, STerms, ATerms or Joiners, does not
+// change the fingerprint.
+func (o Options) Fingerprint() string {
+	var v optionsJSON
+
+	if o.Lookahead > 0 {
+		v.Lookahead = o.Lookahead
+	}
+	v.Lists = o.Lists
+	if len(o.Suppressions) > 0 {
+		s := append([]string(nil), o.Suppressions...)
+		sort.Strings(s)
+		for i, sup := range s {
+			if i > 0 && sup == s[i-1] {
+				continue
+			}
+			v.Suppressions = append(v.Suppressions, sup)
+		}
+	}
+	if o.ANSI {
+		v.ANSI = ansi.DefaultMaxLength
+		if o.MaxANSILength > 0 {
+			v.ANSI = o.MaxANSILength
+		}
+	}
+	v.Vertical = o.Vertical
+
+	// Joiners take precedence, then STerms
+	joiners := sortedRunes(o.Joiners, nil)
+	sterms := sortedRunes(o.STerms, joiners)
+	aterms := sortedRunes(o.ATerms, sortedRunes(append(sterms, joiners...), nil))
+	v.Joiners, v.STerms, v.ATerms = string(joiners), string(sterms), string(aterms)
+
+	// The struct has no values which can't be marshaled
+	b, _ := json.Marshal(v)
+
+	sum := sha256.Sum256([]byte("sentences/" + unicodeVersion + "/" + string(b)))
+	return fmt.Sprintf("sentences/%d/%s/%s", rulesVersion, unicodeVersion, hex.EncodeToString(sum[:]))
+}
+
+// sortedRunes returns runes, sorted and without duplicates, excluding those in
+// exclude, which is sorted
+func sortedRunes(runes, exclude []rune) []rune {
+	var result []rune
+	s := append([]rune(nil), runes...)
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	for i, r := range s {
+		if i > 0 && r == s[i-1] {
+			continue
+		}
+		if j := sort.Search(len(exclude), func(j int) bool { return exclude[j] >= r }); j < len(exclude) && exclude[j] == r {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+package sentences_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/clipperhouse/uax29/ansi"
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+func TestOptionsFingerprint(t *testing.T) {
+	t.Parallel()
+
+	spec := sentences.Options{}.Fingerprint()
+	if !strings.HasPrefix(spec, "sentences/") {
+		t.Errorf("expected fingerprint to include the package, got %s", spec)
+	}
+
+	equivalent := []sentences.Options{
+		{Lookahead: -1},
+		{Suppressions: []string{}},
+		{MaxANSILength: 100},
+	}
+	for _, o := range equivalent {
+		if got := o.Fingerprint(); got != spec {
+			t.Errorf("%+v should have the fingerprint of the spec", o)
+		}
+	}
+
+	a := sentences.Options{Suppressions: []string{"Dr.", "Mr.", "Dr."}}
+	b := sentences.Options{Suppressions: []string{"Mr.", "Dr."}}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Error("the order and repetition of suppressions should not change the fingerprint")
+	}
+
+	e := sentences.Options{STerms: []rune("|;|"), ATerms: []rune("|:")}
+	f := sentences.Options{STerms: []rune(";|"), ATerms: []rune(":")}
+	g := sentences.Options{STerms: []rune("|;"), ATerms: []rune(":;!"), Joiners: []rune("!")}
+	h := sentences.Options{STerms: []rune(";|"), ATerms: []rune(":"), Joiners: []rune("!")}
+	if e.Fingerprint() != f.Fingerprint() || g.Fingerprint() != h.Fingerprint() {
+		t.Error("the order and repetition of terms, and terms which are overridden, should not change the fingerprint")
+	}
+
+	c := sentences.Options{ANSI: true}
+	d := sentences.Options{ANSI: true, MaxANSILength: ansi.DefaultMaxLength}
+	if c.Fingerprint() != d.Fingerprint() {
+		t.Error("the default MaxANSILength should not change the fingerprint")
+	}
+
+	different := []sentences.Options{
+		{Lookahead: 100},
+		{Lists: true},
+		{Suppressions: []string{"Dr."}},
+		{ANSI: true},
+		{ANSI: true, MaxANSILength: 100},
+		{Vertical: true},
+		{STerms: []rune("|")},
+		{ATerms: []rune("|")},
+		{Joiners: []rune("!")},
+	}
+	seen := map[string]bool{spec: true}
+	for _, o := range different {
+		f := o.Fingerprint()
+		if seen[f] {
+			t.Errorf("%+v should have a distinct fingerprint", o)
+		}
+		seen[f] = true
+	}
+}
+
+// TestFingerprintGuard pins the tokenization of the test corpora to the
+// fingerprint. If you've changed tokenization, and this test fails, increment
+// rulesVersion in fingerprint.go, and update the expectations below.
+func TestFingerprintGuard(t *testing.T) {
+	t.Parallel()
+
+	var inputs [][]byte
+	for _, name := range []string{"sample.txt", "chat.txt", "UTF-8-test.txt"} {
+		file, err := os.ReadFile("../testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, file)
+	}
+	for _, test := range unicodeTests {
+		inputs = append(inputs, test.input)
+	}
+
+	tests := []struct {
+		options     sentences.Options
+		fingerprint string
+		digest      string
+	}{
+		{
+			options:     sentences.Options{},
+			fingerprint: "sentences/1/15.0.0/ca0b6406b3c1e2ef4575bd589a74eeeeaa65f42631122ab67dad1345de8f102b",
+			digest:      "4e7308b2472044f7f341accce33a3e5fea3de6866c1418da99dc922e054ae4f1",
+		},
+		{
+			options: sentences.Options{
+				Lists:        true,
+				Suppressions: sentences.EnglishSuppressions,
+				ANSI:         true,
+				Vertical:     true,
+			},
+			fingerprint: "sentences/1/15.0.0/14b2e257577657744be2e9bd0f6cff5a0ee84a7124a0218b9f508e964cea0b63",
+			digest:      "53e6aacbdbbce319747a9693682c1e2844964f54954f160f896b1823b16b1b7b",
+		},
+	}
+
+	for _, test := range tests {
+		fingerprint := test.options.Fingerprint()
+
+		h := sha256.New()
+		for _, input := range inputs {
+			seg := sentences.NewSegmenter(input)
+			seg.Split(test.options.SplitFunc())
+			for seg.Next() {
+				var n [8]byte
+				binary.BigEndian.PutUint64(n[:], uint64(len(seg.Bytes())))
+				h.Write(n[:])
+				h.Write(seg.Bytes())
+			}
+		}
+		digest := hex.EncodeToString(h.Sum(nil))
+
+		if fingerprint != test.fingerprint || digest != test.digest {
+			t.Errorf("tokenization or fingerprint has changed; if tokenization has changed, increment rulesVersion\n"+
+				"expected fingerprint %s, digest %s\n"+
+				"got      fingerprint %s, digest %s", test.fingerprint, test.digest, fingerprint, digest)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package sentences
+
+import (
+	"bufio"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Options are deviations from the spec, for particular kinds of text. The
+// zero value is the spec, identical to SplitFunc. See [Options.SplitFunc].
+type Options struct {
+	// Lookahead, if greater than zero, bounds the SB8 lookahead to this many
+	// bytes, see [BoundedSplitFunc].
+	Lookahead int
+
+	// Lists treats list markers as the start of a sentence, which is useful
+	// for documents with bulleted or numbered lists. A list marker is a bullet
+	// (such as •, - or *), or a number or single letter followed by ")" or
+	// ".", followed by a space, as in "2. " or "b) ".
+	//
+	// A marker following a sentence terminator and spaces, as in
+	// "Do the following. a) mix b) bake", starts a new sentence, where the
+	// spec would continue the sentence because of the lowercase letter (SB8)
+	// or the hyphen (SB8a). A marker at the start of a sentence (including at
+	// the start of a line) is part of that sentence, so the "." in "1. Eggs"
+	// does not end it.
+	Lists bool
+
+	// Suppressions are abbreviations, such as "Dr." or "e.g.", after which a
+	// sentence does not end, as in "Dr. Smith". This is the approach of CLDR
+	// sentence break suppressions. A suppression matches case-sensitively,
+	// and only as a whole word, so "Dr." does not match "MDr.".
+	//
+	// An abbreviation which really does end a sentence will not be a boundary,
+	// so choose suppressions which rarely end one. [EnglishSuppressions] is a
+	// list for English.
+	Suppressions []string
+
+	// ANSI treats ANSI escape sequences, such as terminal colors, as part of
+	// the surrounding text, rather than as punctuation and letters which
+	// would affect the rules. A sequence is ignored, like a Format character
+	// (SB5), so "\x1b[1mHello\x1b[0m. World." is two sentences, the first
+	// including both sequences. A sequence which starts a sentence, after a
+	// terminator and spaces, is part of the subsequent sentence.
+	ANSI bool
+
+	// MaxANSILength is the maximum length, in bytes, of an escape sequence,
+	// see ansi.Parse. Longer sequences are abandoned, and segmented as
+	// ordinary text. If zero, ansi.DefaultMaxLength is used.
+	MaxANSILength int
+
+	// Vertical treats the presentation forms for vertical text of the
+	// ideographic full stop (︒, U+FE12), exclamation mark (︕, U+FE15) and
+	// question mark (︖, U+FE16) as sentence terminators, like their
+	// horizontal equivalents. The spec assigns them no Sentence_Break
+	// property, so vertically typeset text, such as Japanese, would not be
+	// segmented into sentences. Other vertical forms, such as brackets
+	// (Close) and commas (SContinue), have properties in the spec.
+	Vertical bool
+
+	// STerms are runes which are treated as sentence terminators, such as "!"
+	// or "?", in addition to those of the spec. They are patched over the
+	// Sentence_Break property of the spec, so a rune which is an ATerm in the
+	// spec, such as ".", is an STerm if it is in STerms. This is useful for
+	// terminators which are missing from the Unicode data, or for text with
+	// its own conventions, such as "|" between entries in some log formats.
+	STerms []rune
+
+	// ATerms are runes which are treated as ambiguous terminators, like ".",
+	// in addition to those of the spec. An ATerm may not end a sentence, as
+	// in "3.14" or "e.g. this" (SB6 to SB8). As for STerms, they are patched
+	// over the spec. A rune in both STerms and ATerms is an STerm.
+	ATerms []rune
+
+	// Joiners are runes which do not end a sentence, where the spec would,
+	// such as "!" in text which often mentions "Yahoo!". They are patched over
+	// the spec, and take precedence over STerms and ATerms.
+	Joiners []rune
+}
+
+// terms returns the property of the rune at the start of data, given its
+// property p in the spec, see Options.STerms, Options.ATerms and
+// Options.Joiners
+func (o *Options) terms(data []byte, p property) property {
+	r, w := utf8.DecodeRune(data)
+	if r == utf8.RuneError && w <= 1 {
+		// Invalid UTF-8 is not a terminator
+		return p
+	}
+	if runesContain(o.Joiners, r) {
+		if p.is(_SATerm) {
+			return 0
+		}
+		return p
+	}
+	if runesContain(o.STerms, r) {
+		return _STerm
+	}
+	if runesContain(o.ATerms, r) {
+		return _ATerm
+	}
+	return p
+}
+
+// vertical returns the property of a vertical presentation form at the start
+// of data, see Options.Vertical
+func vertical(data []byte) property {
+	// U+FE12, U+FE15 and U+FE16 are EF B8 92, EF B8 95 and EF B8 96
+	if len(data) >= 3 && data[0] == 0xEF && data[1] == 0xB8 {
+		switch data[2] {
+		case 0x92, 0x95, 0x96:
+			return _STerm
+		}
+	}
+	return 0
+}
+
+// EnglishSuppressions are common English abbreviations, such as titles and
+// months, after which a sentence rarely ends, for use as
+// [Options.Suppressions]. They are based on CLDR's English suppressions,
+// omitting some which often end a sentence, such as "etc." or "Inc.".
+var EnglishSuppressions = []string{
+	// Titles
+	"Mr.", "Mrs.", "Ms.", "Mx.", "Dr.", "Prof.", "Sr.", "Jr.", "St.", "Rev.", "Hon.",
+	"Gen.", "Col.", "Capt.", "Lt.", "Sgt.", "Maj.", "Adm.", "Gov.", "Sen.", "Rep.", "Pres.",
+	// Latin
+	"e.g.", "E.g.", "i.e.", "I.e.", "cf.", "Cf.", "vs.", "viz.", "al.",
+	// Months
+	"Jan.", "Feb.", "Mar.", "Apr.", "Jun.", "Jul.", "Aug.", "Sep.", "Sept.", "Oct.", "Nov.", "Dec.",
+	// References and measures
+	"Fig.", "Figs.", "Vol.", "Vols.", "Ch.", "Sec.", "Eq.", "Ref.", "pp.", "approx.", "Approx.", "Mt.", "Ave.",
+}
+
+// SplitFunc returns a bufio.SplitFunc implementation of sentence
+// segmentation with the options, for use with a Scanner or Segmenter.
+func (o Options) SplitFunc() bufio.SplitFunc {
+	return o.splitFunc
+}
+
+// bullets are list markers which are followed by a space
+var bullets = []rune("•◦‣⁃∙▪●–-*+")
+
+// listMarker returns the length of a list marker at the start of data,
+// including leading and trailing spaces, or 0 if there is none. It returns
+// more = true if more data is needed to decide.
+func listMarker(data []byte, atEOF bool) (n int, more bool) {
+	// incomplete is the result when we reach the end of data
+	incomplete := !atEOF
+
+	i := 0
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t') {
+		i++
+	}
+	if i == len(data) {
+		return 0, incomplete
+	}
+
+	switch b := data[i]; {
+	case b >= '0' && b <= '9':
+		// Up to 3 digits
+		j := i
+		for j < len(data) && j-i < 3 && data[j] >= '0' && data[j] <= '9' {
+			j++
+		}
+		i = j
+	case (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z'):
+		// A single letter
+		i++
+	default:
+		if !utf8.FullRune(data[i:]) {
+			return 0, incomplete
+		}
+		r, w := utf8.DecodeRune(data[i:])
+		if !runesContain(bullets, r) {
+			return 0, false
+		}
+		i += w
+		return spacesAfter(data, i, atEOF)
+	}
+
+	if i == len(data) {
+		return 0, incomplete
+	}
+	if data[i] != ')' && data[i] != '.' {
+		return 0, false
+	}
+	return spacesAfter(data, i+1, atEOF)
+}
+
+// spacesAfter returns the position after one or more spaces at data[i:],
+// or 0 if there are none
+func spacesAfter(data []byte, i int, atEOF bool) (n int, more bool) {
+	if i == len(data) {
+		return 0, !atEOF
+	}
+	if data[i] != ' ' && data[i] != '\t' {
+		return 0, false
+	}
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t') {
+		i++
+	}
+	return i, false
+}
+
+func runesContain(runes []rune, rune rune) bool {
+	for _, r := range runes {
+		if r == rune {
+			return true
+		}
+	}
+	return false
+}
+
+// suppressed determines if data ends with one of suppressions, as a whole word
+func suppressed(data []byte, suppressions []string) bool {
+	for _, s := range suppressions {
+		n := len(data) - len(s)
+		if n < 0 || string(data[n:]) != s {
+			continue
+		}
+		if n == 0 {
+			return true
+		}
+		r, _ := utf8.DecodeLastRune(data[:n])
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			return true
+		}
+	}
+	return false
+}
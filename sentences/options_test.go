@@ -0,0 +1,235 @@
+package sentences_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/internal/segtest"
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+func TestOptionsLists(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{"Do the following. a) mix b) bake", []string{"Do the following. ", "a) mix b) bake"}},
+		{"Notes. - first thing - second thing", []string{"Notes. ", "- first thing - second thing"}},
+		{"Buy these. 1. Eggs. 2. Milk.", []string{"Buy these. ", "1. Eggs. ", "2. Milk."}},
+		{"Buy these. 10) eggs 11) milk", []string{"Buy these. ", "10) eggs 11) milk"}},
+		{"Items:\n• eggs\n• milk", []string{"Items:\n", "• eggs\n", "• milk"}},
+		{"Items:\r\n  1. Eggs\r\n  2. Milk", []string{"Items:\r\n", "  1. Eggs\r\n", "  2. Milk"}},
+		{"Done. * next", []string{"Done. ", "* next"}},
+		{"1. ", []string{"1. "}},
+		{"•", []string{"•"}},
+
+		// Not markers
+		{"e.g. this is one sentence.", []string{"e.g. this is one sentence."}},
+		{"It costs 5. dollars", []string{"It costs 5. dollars"}},
+		{"See 1234. Next.", []string{"See 1234. ", "Next."}},
+		{"Hi. ab) no", []string{"Hi. ab) no"}},
+		{"Hi. -no", []string{"Hi. -no"}},
+	}
+
+	split := sentences.Options{Lists: true}.SplitFunc()
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, split, nil, []byte(test.input), test.expected)
+	}
+}
+
+func TestOptionsSuppressions(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{"Dr. Smith is here. He said hi.", []string{"Dr. Smith is here. ", "He said hi."}},
+		{"See Fig. 3 and Fig. A. Then stop.", []string{"See Fig. 3 and Fig. A. ", "Then stop."}},
+		{"Use a tool, e.g. A hammer.", []string{"Use a tool, e.g. A hammer."}},
+		{"(cf. The manual.) Done.", []string{"(cf. The manual.) ", "Done."}},
+		{"Mr.  Jones and Mrs. \"Smith\" left.", []string{"Mr.  Jones and Mrs. \"Smith\" left."}},
+		{"It was Jan. Then Feb. arrived.", []string{"It was Jan. Then Feb. arrived."}},
+		{"Dr.", []string{"Dr."}},
+
+		// Not suppressed
+		{"Ask MDr. Then go.", []string{"Ask MDr. ", "Then go."}},
+		{"Ask dr. Then go.", []string{"Ask dr. ", "Then go."}},
+		{"See Dr.\nSmith", []string{"See Dr.\n", "Smith"}},
+		{"Is it Dr? Yes.", []string{"Is it Dr? ", "Yes."}},
+	}
+
+	split := sentences.Options{Suppressions: sentences.EnglishSuppressions}.SplitFunc()
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, split, nil, []byte(test.input), test.expected)
+	}
+}
+
+func TestOptionsANSI(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		options  sentences.Options
+		input    string
+		expected []string
+	}
+
+	ansi := sentences.Options{ANSI: true}
+
+	tests := []test{
+		{ansi, "\x1b[1mHello\x1b[0m. World.", []string{"\x1b[1mHello\x1b[0m. ", "World."}},
+		{ansi, "Hello.\x1b[0m World.", []string{"Hello.\x1b[0m ", "World."}},
+		{ansi, "Hello. \x1b[1mWorld.\x1b[0m", []string{"Hello. ", "\x1b[1mWorld.\x1b[0m"}},
+		{ansi, "Hello. \x1b[1m\x1b[31mWorld.", []string{"Hello. ", "\x1b[1m\x1b[31mWorld."}},
+		{ansi, "Hello.\n\x1b[1mWorld.", []string{"Hello.\n", "\x1b[1mWorld."}},
+		{ansi, "This is \x1b[1mbold\x1b[0m text. Next.", []string{"This is \x1b[1mbold\x1b[0m text. ", "Next."}},
+		{ansi, "\x1b]0;a title. Yes\x07Hi. There.", []string{"\x1b]0;a title. Yes\x07Hi. ", "There."}},
+		{ansi, "e.g. \x1b[1mthis is one.", []string{"e.g. \x1b[1mthis is one."}},
+		{ansi, "\x1b[", []string{"\x1b["}},
+
+		// The letters in a sequence should not affect the rules
+		{ansi, "Hello. \x1b[1mWorld.", []string{"Hello. ", "\x1b[1mWorld."}},
+		{sentences.Options{}, "Hello. \x1b[1mWorld.", []string{"Hello. \x1b[1mWorld."}},
+
+		// With other options
+		{sentences.Options{ANSI: true, Lists: true}, "Do this. \x1b[1ma) mix", []string{"Do this. ", "\x1b[1ma) mix"}},
+		{sentences.Options{ANSI: true, Suppressions: sentences.EnglishSuppressions}, "Dr. \x1b[1mSmith\x1b[0m is in. Yes.", []string{"Dr. \x1b[1mSmith\x1b[0m is in. ", "Yes."}},
+
+		// A sequence longer than the maximum is segmented as text, so its lowercase letters continue the sentence (SB8)
+		{sentences.Options{ANSI: true, MaxANSILength: 4}, "Hi. \x1b]0;long title\x07. Bye.", []string{"Hi. \x1b]0;long title\x07. ", "Bye."}},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, test.options.SplitFunc(), nil, []byte(test.input), test.expected)
+	}
+}
+
+func TestOptionsVertical(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	// Vertical presentation forms: U+FE12 full stop, U+FE15 exclamation
+	// mark, U+FE16 question mark, U+FE41 and U+FE42 corner brackets (Close)
+	tests := []test{
+		{"\u6587\ufe12\u6b21\u306e\u6587\ufe12", []string{"\u6587\ufe12", "\u6b21\u306e\u6587\ufe12"}},
+		{"\u6587\ufe15\u6b21\ufe16\u6587", []string{"\u6587\ufe15", "\u6b21\ufe16", "\u6587"}},
+		{"\ufe41\u6587\ufe12\ufe42\u6b21", []string{"\ufe41\u6587\ufe12\ufe42", "\u6b21"}},
+		{"\u6587\ufe12\u0301\u6b21", []string{"\u6587\ufe12\u0301", "\u6b21"}},
+		{"\u6587\ufe10\u6b21", []string{"\u6587\ufe10\u6b21"}},
+		{"Hello. World.", []string{"Hello. ", "World."}},
+	}
+
+	split := sentences.Options{Vertical: true}.SplitFunc()
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, split, nil, []byte(test.input), test.expected)
+	}
+
+	// Per the spec, the vertical forms have no property, so do not end a sentence
+	got := sentences.SegmentAll([]byte("\u6587\ufe12\u6b21"))
+	if len(got) != 1 {
+		t.Errorf("expected the spec not to break, got %q", got)
+	}
+}
+
+func TestOptionsTerms(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		options  sentences.Options
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		// A custom STerm, as in some log formats
+		{sentences.Options{STerms: []rune("|")}, "GET /a | 200 | ok", []string{"GET /a | ", "200 | ", "ok"}},
+		{sentences.Options{STerms: []rune("|")}, "a |) b", []string{"a |) ", "b"}},
+		{sentences.Options{STerms: []rune("|")}, "a|\u0301b", []string{"a|\u0301", "b"}},
+		// An STerm ends a sentence where an ATerm would not (SB6, SB8)
+		{sentences.Options{STerms: []rune(".")}, "Pi is 3.14 ok.", []string{"Pi is 3.", "14 ok."}},
+		{sentences.Options{}, "Pi is 3.14 ok.", []string{"Pi is 3.14 ok."}},
+		// A custom ATerm does not end a sentence before a lowercase letter (SB8)
+		{sentences.Options{ATerms: []rune("|")}, "a| b| C", []string{"a| b| ", "C"}},
+		{sentences.Options{ATerms: []rune("|")}, "1|5", []string{"1|5"}},
+		// STerms take precedence
+		{sentences.Options{STerms: []rune("|"), ATerms: []rune("|")}, "a| b", []string{"a| ", "b"}},
+		// Joiners do not end a sentence, and take precedence
+		{sentences.Options{Joiners: []rune("!")}, "Yahoo! is big. Yes", []string{"Yahoo! is big. ", "Yes"}},
+		{sentences.Options{}, "Yahoo! is big. Yes", []string{"Yahoo! ", "is big. ", "Yes"}},
+		{sentences.Options{Joiners: []rune("?")}, "Really? yes. No", []string{"Really? yes. ", "No"}},
+		{sentences.Options{}, "Really? yes. No", []string{"Really? ", "yes. ", "No"}},
+		{sentences.Options{Joiners: []rune("|"), STerms: []rune("|")}, "a| b", []string{"a| b"}},
+		{sentences.Options{Joiners: []rune("a")}, "a. B", []string{"a. ", "B"}},
+		// Other runes are unaffected
+		{sentences.Options{STerms: []rune("|")}, "Hello. World!", []string{"Hello. ", "World!"}},
+		{sentences.Options{STerms: []rune{utf8.RuneError}}, "a\xffb", []string{"a\xffb"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%+v", test.options), func(t *testing.T) {
+			segtest.AssertSegments(t, test.options.SplitFunc(), nil, []byte(test.input), test.expected)
+		})
+	}
+}
+
+func TestOptionsZero(t *testing.T) {
+	t.Parallel()
+
+	// The zero value should be identical to SplitFunc
+	seg1 := sentences.NewSegmenter(nil)
+	seg2 := sentences.NewSegmenter(nil)
+	seg2.Split(sentences.Options{}.SplitFunc())
+
+	for _, test := range unicodeTests {
+		seg1.SetText(test.input)
+		seg2.SetText(test.input)
+
+		for seg1.Next() {
+			if !seg2.Next() || !bytes.Equal(seg1.Bytes(), seg2.Bytes()) {
+				t.Fatalf("%q: expected %q, got %q", test.input, seg1.Bytes(), seg2.Bytes())
+			}
+		}
+		if seg2.Next() {
+			t.Fatalf("%q: unexpected token %q", test.input, seg2.Bytes())
+		}
+	}
+}
+
+func TestOptionsListsRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	seg := sentences.NewSegmenter(nil)
+	seg.Split(sentences.Options{Lists: true, Lookahead: 16, Suppressions: sentences.EnglishSuppressions, ANSI: true, Vertical: true}.SplitFunc())
+
+	for i := 0; i < 1000; i++ {
+		input := getRandomBytes()
+		seg.SetText(input)
+
+		var output []byte
+		for seg.Next() {
+			output = append(output, seg.Bytes()...)
+		}
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(output, input) {
+			t.Fatal("input bytes are not the same as segmented bytes")
+		}
+	}
+}
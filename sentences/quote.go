@@ -0,0 +1,230 @@
+package sentences
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+// Quote is a quotation in text, see [Quotes].
+type Quote struct {
+	// Span is the position of the quotation, including the quotation marks.
+	iterators.Span
+	// Inner is the position of the quoted text, excluding the marks.
+	Inner iterators.Span
+	// Sentences is the position of the sentence(s) containing the quotation,
+	// from the start of the sentence containing the opening mark, to the end of
+	// the sentence containing the closing mark.
+	Sentences iterators.Span
+	// Depth is the nesting of the quotation within other quotations; an
+	// outermost quotation has a depth of 0.
+	Depth int
+}
+
+// quotePairs are the opening and closing quotation marks which are paired,
+// including CJK corner brackets and their vertical forms. A mark may be in
+// several pairs, such as “, which opens in English and closes in German.
+var quotePairs = [...]struct{ open, close rune }{
+	{'"', '"'},
+	{'\'', '\''},
+	{'“', '”'},
+	{'‘', '’'},
+	{'„', '“'},
+	{'‚', '‘'},
+	{'«', '»'},
+	{'‹', '›'},
+	{'「', '」'},
+	{'『', '』'},
+	{'﹁', '﹂'},
+	{'﹃', '﹄'},
+	{'〝', '〞'},
+	{'〝', '〟'},
+	{'＂', '＂'},
+}
+
+// maxQuoteDepth bounds the nesting of quotations, and so the work per mark on
+// hostile input; further opening marks are ignored
+const maxQuoteDepth = 32
+
+// Quotes returns the quotations in data, pairing straight, curly, guillemet
+// and CJK quotation marks, in order of position, with the sentences which
+// contain them. See [Options.Quotes] to segment sentences with Options.
+func Quotes(data []byte) []Quote {
+	return spec.Quotes(data)
+}
+
+// Quotes returns the quotations in data, pairing straight, curly, guillemet
+// and CJK quotation marks, in order of position, with the sentences which
+// contain them, as segmented with the Options.
+//
+// Pairing is heuristic. A closing mark closes the nearest open quotation which
+// it pairs with, and opening marks between them are abandoned. An apostrophe
+// between letters, as in “don't”, is not a quotation mark, nor is a straight
+// or curly single quote which follows a letter and opens nothing, as in
+// “students'”. A blank line, or a paragraph separator, abandons open
+// quotations, so that an unclosed quotation does not capture the rest of the
+// text. This API is experimental.
+func (o Options) Quotes(data []byte) []Quote {
+	quotes := pairQuotes(data)
+	if len(quotes) == 0 {
+		return nil
+	}
+	alignQuotes(quotes, o.spans(data))
+	return quotes
+}
+
+// pairQuotes returns the quotations in data, in order of position, without
+// Sentences, see Options.Quotes
+func pairQuotes(data []byte) []Quote {
+	var quotes []Quote
+
+	type open struct {
+		r   rune
+		pos int
+		w   int
+	}
+	var stack []open
+
+	prev := rune(-1)
+	for pos := 0; pos < len(data); {
+		r, w := utf8.DecodeRune(data[pos:])
+		next := rune(-1)
+		if pos+w < len(data) {
+			next, _ = utf8.DecodeRune(data[pos+w:])
+		}
+
+		if r == '\u2029' || (r == '\n' && blankLine(data[pos+w:])) {
+			stack = stack[:0]
+		}
+
+		closed := false
+		if closing(prev, r, next) {
+			for i := len(stack) - 1; i >= 0; i-- {
+				if !pairs(stack[i].r, r) {
+					continue
+				}
+				quotes = append(quotes, Quote{
+					Span:  iterators.Span{Start: stack[i].pos, End: pos + w},
+					Inner: iterators.Span{Start: stack[i].pos + stack[i].w, End: pos},
+					Depth: i,
+				})
+				stack = stack[:i]
+				closed = true
+				break
+			}
+		}
+
+		if !closed && len(stack) < maxQuoteDepth && opening(prev, r, next) {
+			stack = append(stack, open{r, pos, w})
+		}
+
+		prev = r
+		pos += w
+	}
+
+	sort.Slice(quotes, func(i, j int) bool {
+		return quotes[i].Start < quotes[j].Start
+	})
+	return quotes
+}
+
+// spans returns the positions of the sentences in data
+func (o *Options) spans(data []byte) []iterators.Span {
+	var spans []iterators.Span
+	seg := iterators.NewSegmenter(o.splitFunc)
+	seg.SetText(data)
+	for seg.Next() {
+		spans = append(spans, seg.Span())
+	}
+	return spans
+}
+
+// alignQuotes sets the Sentences of quotes, given the positions of sentences
+func alignQuotes(quotes []Quote, sentences []iterators.Span) {
+	containing := func(pos int) iterators.Span {
+		i := sort.Search(len(sentences), func(i int) bool {
+			return sentences[i].End > pos
+		})
+		if i == len(sentences) {
+			return iterators.Span{Start: pos, End: pos}
+		}
+		return sentences[i]
+	}
+	for i := range quotes {
+		q := &quotes[i]
+		q.Sentences = iterators.Span{
+			Start: containing(q.Start).Start,
+			End:   containing(q.End - 1).End,
+		}
+	}
+}
+
+// pairs determines if the opening mark o is closed by c
+func pairs(o, c rune) bool {
+	for _, p := range quotePairs {
+		if p.open == o && p.close == c {
+			return true
+		}
+	}
+	return false
+}
+
+// opening determines if r may open a quotation, given the runes before and
+// after it, which are -1 at the start or end of text
+func opening(prev, r, next rune) bool {
+	switch r {
+	case '’', '”', '»', '›', '」', '』', '﹂', '﹄', '〞', '〟':
+		return false
+	case '\'', '‘', '‚':
+		// Not an apostrophe, as in "don't"
+		if isWordRune(prev) {
+			return false
+		}
+	}
+	for _, p := range quotePairs {
+		if p.open == r {
+			if r == '«' || r == '‹' {
+				// French spaces the quotation from the guillemets
+				return next >= 0
+			}
+			// The quotation must begin with something
+			return next >= 0 && !unicode.IsSpace(next)
+		}
+	}
+	return false
+}
+
+// closing determines if r may close a quotation, given the runes before and
+// after it, which are -1 at the start or end of text
+func closing(prev, r, next rune) bool {
+	switch r {
+	case '"', '＂':
+		// Symmetric marks close only after something
+		return prev >= 0 && !unicode.IsSpace(prev)
+	case '\'', '’', '‘':
+		// Not an apostrophe, as in "don't"
+		return prev >= 0 && !unicode.IsSpace(prev) && !isWordRune(next)
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return r >= 0 && (unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r))
+}
+
+// blankLine determines if data begins with a line which is blank, i.e. only
+// spaces or tabs and a line break
+func blankLine(data []byte) bool {
+	for _, b := range data {
+		switch b {
+		case ' ', '\t', '\r':
+			continue
+		case '\n':
+			return true
+		}
+		return false
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package sentences_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+func TestQuotes(t *testing.T) {
+	t.Parallel()
+
+	type quote struct {
+		text, inner string
+		depth       int
+	}
+
+	tests := []struct {
+		input    string
+		expected []quote
+	}{
+		{`He said "hello" and "goodbye".`, []quote{{`"hello"`, "hello", 0}, {`"goodbye"`, "goodbye", 0}}},
+		{"She said “I don't know ‘why’ it is.”", []quote{{"“I don't know ‘why’ it is.”", "I don't know ‘why’ it is.", 0}, {"‘why’", "why", 1}}},
+		{"Er sagte „Hallo“ und ging.", []quote{{"„Hallo“", "Hallo", 0}}},
+		{"Il a dit « bonjour » et « au revoir ».", []quote{{"« bonjour »", " bonjour ", 0}, {"« au revoir »", " au revoir ", 0}}},
+		{"彼は「こんにちは『世界』」と言った。", []quote{{"「こんにちは『世界』」", "こんにちは『世界』", 0}, {"『世界』", "世界", 1}}},
+		{"It's the students' books, 'tis true.", nil},
+		{"Rock 'n' roll.", []quote{{"'n'", "n", 0}}},
+		{"He said 'yes' twice.", []quote{{"'yes'", "yes", 0}}},
+		{"An “unclosed quote.\n\nA new paragraph.” Here.", nil},
+		{"Mismatched “quote' here”.", []quote{{"“quote' here”", "quote' here", 0}}},
+		{"No quotes here.", nil},
+		{"", nil},
+	}
+
+	for _, test := range tests {
+		var got []quote
+		for _, q := range sentences.Quotes([]byte(test.input)) {
+			got = append(got, quote{
+				text:  string(q.Slice([]byte(test.input))),
+				inner: string(q.Inner.Slice([]byte(test.input))),
+				depth: q.Depth,
+			})
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%q: expected %q, got %q", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestQuotesSentences(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`First sentence. He said "Stop. Wait." Then he left. Last.`)
+
+	quotes := sentences.Quotes(input)
+	if len(quotes) != 1 {
+		t.Fatalf("expected 1 quote, got %d", len(quotes))
+	}
+
+	got := string(quotes[0].Sentences.Slice(input))
+	expected := `He said "Stop. Wait." `
+	if got != expected {
+		t.Fatalf("expected sentences %q, got %q", expected, got)
+	}
+
+	// With suppressions, the quotation spans the abbreviation
+	input = []byte(`He said "Ask Dr. Smith." Then he left.`)
+	opts := sentences.Options{Suppressions: sentences.EnglishSuppressions}
+	quotes = opts.Quotes(input)
+	if len(quotes) != 1 {
+		t.Fatalf("expected 1 quote, got %d", len(quotes))
+	}
+	got = string(quotes[0].Sentences.Slice(input))
+	expected = `He said "Ask Dr. Smith." `
+	if got != expected {
+		t.Fatalf("expected sentences %q, got %q", expected, got)
+	}
+}
@@ -1,47 +1,18 @@
 package sentences
 
-import "unicode/utf8"
-
-// previousIndex works backward until it hits a rune in properties,
-// ignoring runes in the _Ignore property (per SB5), and returns
-// the index of the rune in data. It returns -1 if such a rune is not found.
-func previousIndex(properties property, data []byte) int {
-	// Start at the end of the buffer and move backwards
-	i := len(data)
-	for i > 0 {
-		_, w := utf8.DecodeLastRune(data[:i])
-
-		i -= w
-
-		lookup, _ := trie.lookup(data[i:])
-
-		if lookup.is(_Ignore) {
-			continue
-		}
-
-		if lookup.is(properties) {
-			return i
-		}
-
-		// If we get this far, it's not there
-		break
-	}
-
-	return -1
-}
-
-// previous works backward in the buffer until it hits a rune in properties,
-// ignoring runes with the _Ignore property per SB5
-func previous(properties property, data []byte) bool {
-	return previousIndex(properties, data) != -1
-}
+import "github.com/clipperhouse/uax29/ansi"
 
 // subsequent looks ahead in the buffer until it hits a rune in properties,
-// ignoring runes in the _Ignore property per SB5
-func subsequent(properties property, data []byte, atEOF bool) (found bool, requestMore bool) {
+// ignoring runes in the _Ignore property per SB5, and escape sequences per
+// Options.ANSI
+func (o *Options) subsequent(properties property, data []byte, atEOF bool) (found bool, requestMore bool) {
 	i := 0
 	for i < len(data) {
-		lookup, w := trie.lookup(data[i:])
+		lookup, w, _, more := o.lookup(data[i:], atEOF)
+		if more {
+			// More to evaluate
+			return false, true
+		}
 		if w == 0 {
 			if atEOF {
 				// Nothing more to evaluate
@@ -72,3 +43,40 @@ func subsequent(properties property, data []byte, atEOF bool) (found bool, reque
 	// More to evaluate
 	return false, true
 }
+
+// lookup returns the property and width of the rune at the start of data. If
+// Options.ANSI is set, and data starts with an escape sequence, it returns
+// the width of the sequence, seq = true, and the _Format property, so that
+// the sequence is ignored per SB5. It returns more = true if the sequence
+// might extend past data, and atEOF is false.
+func (o *Options) lookup(data []byte, atEOF bool) (p property, w int, seq bool, more bool) {
+	if o.ANSI && data[0] == 0x1B { // ESC
+		return o.sequence(data, atEOF)
+	}
+	p, w = trie.lookup(data)
+	if o.Vertical && p == 0 {
+		p = vertical(data)
+	}
+	if len(o.STerms) > 0 || len(o.ATerms) > 0 || len(o.Joiners) > 0 {
+		p = o.terms(data, p)
+	}
+	return p, w, false, false
+}
+
+// sequence is lookup for an escape sequence, see Options.ANSI
+func (o *Options) sequence(data []byte, atEOF bool) (p property, w int, seq bool, more bool) {
+	max := ansi.DefaultMaxLength
+	if o.MaxANSILength > 0 {
+		max = o.MaxANSILength
+	}
+	n, _, more := ansi.Parse(data, max, atEOF)
+	if more {
+		return 0, 0, false, true
+	}
+	if n > 0 {
+		return _Format, n, true, false
+	}
+
+	p, w = trie.lookup(data)
+	return p, w, false, false
+}
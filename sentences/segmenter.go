@@ -25,3 +25,10 @@ func SegmentAll(data []byte) [][]byte {
 	_ = iterators.All(data, &result, SplitFunc) // can elide the error, see tests
 	return result
 }
+
+// Count returns the number of sentences in data, as would be returned by
+// SegmentAll or a Segmenter, without collecting them.
+func Count(data []byte) int {
+	n, _ := iterators.Count(data, SplitFunc) // can elide the error, see tests
+	return n
+}
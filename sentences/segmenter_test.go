@@ -44,6 +44,11 @@ func TestSegmenterUnicode(t *testing.T) {
 		if !reflect.DeepEqual(all, segmented) {
 			t.Error("calling SegmentAll should be identical to iterating Segmenter")
 		}
+
+		// And Count
+		if c := sentences.Count(test.input); c != len(segmented) {
+			t.Errorf("calling Count should be identical to counting Segmenter, expected %d, got %d", len(segmented), c)
+		}
 	}
 
 	if len(unicodeTests) != passed+failed {
@@ -159,6 +164,24 @@ func BenchmarkSegmentAll(b *testing.B) {
 	b.Logf("tokens %d, len %d, avg %d", c, len(file), len(file)/c)
 }
 
+func BenchmarkCount(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+
+	if err != nil {
+		b.Error(err)
+	}
+
+	c := sentences.Count(file)
+	b.ResetTimer()
+	b.SetBytes(int64(len(file)))
+
+	for i := 0; i < b.N; i++ {
+		_ = sentences.Count(file)
+	}
+
+	b.ReportMetric(float64(c), "tokens")
+}
+
 func BenchmarkUnicodeTests(b *testing.B) {
 	var buf bytes.Buffer
 	for _, test := range unicodeTests {
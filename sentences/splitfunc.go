@@ -1,5 +1,7 @@
 package sentences
 
+import "bufio"
+
 var trie = newSentencesTrie(0)
 
 // is determines if lookup intersects propert(ies)
@@ -13,8 +15,30 @@ const (
 	_Ignore  = _Extend | _Format
 )
 
-// SplitFunc is a bufio.SplitFunc implementation of word segmentation, for use with bufio.Scanner.
+// SplitFunc is a bufio.SplitFunc implementation of sentence segmentation, for use with bufio.Scanner.
 func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return spec.splitFunc(data, atEOF)
+}
+
+// spec is the zero Options, i.e. the spec
+var spec = &Options{}
+
+// BoundedSplitFunc returns a bufio.SplitFunc implementation of sentence
+// segmentation, in which the SB8 lookahead is bounded to limit bytes. SB8
+// looks ahead from an ATerm (such as ".") for a lowercase letter, which
+// indicates that the sentence continues, as in "e.g. this". Bounding it caps
+// the worst-case work per character on hostile input, and the amount a
+// Scanner will buffer to decide.
+//
+// It is a deviation from the spec: an ATerm followed by more than limit bytes
+// of digits, spaces or punctuation, and then a lowercase letter, will be a sentence
+// boundary, where the spec would continue the sentence. If limit is zero or
+// less, the lookahead is unbounded, identical to SplitFunc. See also [Options].
+func BoundedSplitFunc(limit int) bufio.SplitFunc {
+	return Options{Lookahead: limit}.SplitFunc()
+}
+
+func (o *Options) splitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	if len(data) == 0 {
 		return 0, nil, nil
 	}
@@ -22,6 +46,7 @@ func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	// These vars are stateful across loop iterations
 	var pos, w int
 	var current property
+	var seq, more bool
 
 	var lastExIgnore property     // "last excluding ignored categories"
 	var lastLastExIgnore property // "last one before that"
@@ -29,10 +54,46 @@ func SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	var lastExIgnoreClose property
 	var lastExIgnoreSpClose property
 
+	// sb11 tracks whether the text so far ends with SATerm Close* Sp*, so that
+	// SB11 needn't look back, which would be O(n^2) on long runs of Close or Sp
+	var sb11 sequence
+
+	// termEnd is the position after the most recent SATerm, for Options.Suppressions
+	var termEnd int
+
+	// sb8End is the position at which the SB8 lookahead last stopped, and
+	// sb8Found its result. The lookahead from any position up to sb8End stops
+	// at the same place, so it needn't be repeated, which would be O(n^2).
+	// With a bounded lookahead, it may have stopped at the bound, so it is
+	// resumed from sb8End, rather than repeated, which would be O(n * limit).
+	sb8End := -1
+	var sb8Found bool
+
+	// seqStart is the position of the run of escape sequences preceding
+	// current, or -1, see Options.ANSI
+	seqStart := -1
+
+	// A list marker at the start of a sentence is part of it, see Options.Lists
+	if o.Lists {
+		n, more := listMarker(data, atEOF)
+		if more {
+			// Marker extends past current data, request more
+			return 0, nil, nil
+		}
+		if n > 0 {
+			// Continue as though at the start of text, from the trailing space
+			pos = n - 1
+		}
+	}
+
 	// https://unicode.org/reports/tr29/#SB1
 	{
 		// Start of text always advances
-		current, w = trie.lookup(data[pos:])
+		current, w, _, more = o.lookup(data[pos:], atEOF)
+		if more {
+			// Sequence extends past current data, request more
+			return 0, nil, nil
+		}
 		if w == 0 {
 			if !atEOF {
 				// Rune extends past current data, request more
@@ -87,7 +148,37 @@ main:
 			lastExIgnoreSpClose = lastExIgnoreSp
 		}
 
-		current, w = trie.lookup(data[pos:])
+		if !last.is(_Ignore) {
+			switch {
+			case last.is(_SATerm):
+				sb11 = closes
+				termEnd = pos
+			case last.is(_Close) && sb11 == closes:
+				// remains
+			case last.is(_Sp) && sb11 != none:
+				sb11 = spaces
+			default:
+				sb11 = none
+			}
+		}
+
+		// Optimization: o.lookup is not inlined, so check for ESC here
+		if o.ANSI && data[pos] == 0x1B {
+			current, w, seq, more = o.sequence(data[pos:], atEOF)
+			if more {
+				// Sequence extends past current data, request more
+				return 0, nil, nil
+			}
+		} else {
+			current, w = trie.lookup(data[pos:])
+			seq = false
+			if o.Vertical && current == 0 {
+				current = vertical(data[pos:])
+			}
+			if len(o.STerms) > 0 || len(o.ATerms) > 0 || len(o.Joiners) > 0 {
+				current = o.terms(data[pos:], current)
+			}
+		}
 		if w == 0 {
 			if atEOF {
 				// Just return the bytes, we can't do anything with them
@@ -98,6 +189,19 @@ main:
 			return 0, nil, nil
 		}
 
+		// A sentence break before a run of escape sequences, following
+		// SATerm Close* Sp+, belongs before the run, so that the sequences
+		// (such as colors) go with the subsequent sentence
+		brk := pos
+		if seqStart >= 0 {
+			brk = seqStart
+		}
+		if !seq {
+			seqStart = -1
+		} else if seqStart < 0 && sb11 == spaces {
+			seqStart = pos
+		}
+
 		// Optimization: no rule can possibly apply
 		if current|last == 0 { // i.e. both are zero
 			pos += w
@@ -123,7 +227,7 @@ main:
 
 		// SB5 applies to subsequent rules; there is an implied "ignoring Extend & Format"
 		// https://unicode.org/reports/tr29/#Sentence_Boundary_Rules
-		// The previous/subsequent methods are shorthand for "seek a property but skip over Extend & Format on the way"
+		// The subsequent method is shorthand for "seek a property but skip over Extend & Format on the way"
 
 		// https://unicode.org/reports/tr29/#SB6
 		if current.is(_Numeric) && lastExIgnore.is(_ATerm) {
@@ -137,42 +241,79 @@ main:
 			continue
 		}
 
+		// A list marker following SATerm Close* Sp+ starts a sentence, see Options.Lists
+		if o.Lists && sb11 == spaces && !current.is(_Sp) {
+			n, more := listMarker(data[pos:], atEOF)
+			if more {
+				// Marker extends past current data, request more
+				return 0, nil, nil
+			}
+			if n > 0 {
+				pos = brk
+				break
+			}
+		}
+
 		// Optimization: determine if SB8 can possibly apply
 		maybeSB8 := lastExIgnoreSpClose.is(_ATerm)
 
 		// https://unicode.org/reports/tr29/#SB8
 		if maybeSB8 {
-			p := pos
-
-			// ( ¬(OLetter | Upper | Lower | ParaSep | SATerm) )*
-			// Zero or more of not-the-above properties
-			for p < len(data) {
-				lookup, w := trie.lookup(data[p:])
-				if w == 0 {
-					if atEOF {
-						// Just return the bytes, we can't do anything with them
-						pos = len(data)
-						break main
-					}
-					// Rune extends past current data, request more
-					return 0, nil, nil
+			// A bounded lookahead differs by position, so can't be reused
+			if pos > sb8End || o.Lookahead > 0 {
+				p := pos
+				if pos <= sb8End {
+					// Bounded: the previous lookahead found nothing from pos to sb8End
+					p = sb8End
 				}
 
-				if lookup.is(_OLetter | _Upper | _Lower | _ParaSep | _SATerm) {
-					break
+				end := len(data)
+				final := atEOF
+				if o.Lookahead > 0 && pos+o.Lookahead < end {
+					end = pos + o.Lookahead
+					final = true
 				}
 
-				p += w
-			}
+				// ( ¬(OLetter | Upper | Lower | ParaSep | SATerm) )*
+				// Zero or more of not-the-above properties
+				for p < end {
+					lookup, w, _, more := o.lookup(data[p:end], final)
+					if more {
+						// Sequence extends past current data, request more
+						return 0, nil, nil
+					}
+					if w == 0 {
+						if final && end < len(data) {
+							// Rune extends past the bound
+							break
+						}
+						if atEOF {
+							// Just return the bytes, we can't do anything with them
+							pos = len(data)
+							break main
+						}
+						// Rune extends past current data, request more
+						return 0, nil, nil
+					}
 
-			found, more := subsequent(_Lower, data[p:], atEOF)
+					if lookup.is(_OLetter | _Upper | _Lower | _ParaSep | _SATerm) {
+						break
+					}
 
-			if more {
-				// Rune or token extends past current data, request more
-				return 0, nil, nil
+					p += w
+				}
+
+				found, more := o.subsequent(_Lower, data[p:end], final)
+
+				if more {
+					// Rune or token extends past current data, request more
+					return 0, nil, nil
+				}
+
+				sb8End, sb8Found = p, found
 			}
 
-			if found {
+			if sb8Found {
 				pos += w
 				continue
 			}
@@ -196,44 +337,17 @@ main:
 			continue
 		}
 
-		// Optimization: determine if SB11 can possibly apply
-		maybeSB11 := lastExIgnore.is(_SATerm | _Close | _Sp | _ParaSep)
-
 		// https://unicode.org/reports/tr29/#SB11
-		if maybeSB11 {
-			p := pos
-
-			// Zero or one ParaSep
-			ps := previousIndex(_ParaSep, data[:p])
-			if ps >= 0 {
-				p = ps
-			}
-
-			// Zero or more Sp's
-			sp := p
-			for {
-				sp = previousIndex(_Sp, data[:sp])
-				if sp < 0 {
-					break
-				}
-				p = sp
-			}
-
-			// Zero or more Close's
-			close := p
-			for {
-				close = previousIndex(_Close, data[:close])
-				if close < 0 {
-					break
-				}
-				p = close
-			}
-
-			// Having looked back past ParaSep, Sp's, Close's, and intervening Extend|Format,
-			// is there an SATerm?
-			if previous(_SATerm, data[:p]) {
-				break
+		// SATerm Close* Sp* ParaSep? ÷
+		// ParaSep is handled by SB4, above
+		if sb11 != none {
+			// An abbreviation does not end a sentence, see Options.Suppressions
+			if o.Suppressions != nil && suppressed(data[:termEnd], o.Suppressions) {
+				pos += w
+				continue
 			}
+			pos = brk
+			break
 		}
 
 		// https://unicode.org/reports/tr29/#SB998
@@ -243,3 +357,12 @@ main:
 	// Return token
 	return pos, data[:pos], nil
 }
+
+// sequence is the state of the SB11 pattern, SATerm Close* Sp*
+type sequence uint8
+
+const (
+	none   sequence = iota
+	closes          // SATerm Close*
+	spaces          // SATerm Close* Sp+
+)
@@ -0,0 +1,80 @@
+package sentences_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/clipperhouse/uax29/sentences"
+)
+
+// unassigned determines if r is unassigned, per package unicode, whose
+// version must be at least that of the tables
+func unassigned(r rune) bool {
+	return !unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z,
+		unicode.Cc, unicode.Cf, unicode.Co, unicode.Cs)
+}
+
+// eachUnassigned calls f with unassigned code points: all of them in the
+// first three planes and in the tags block, and a sample of the rest
+func eachUnassigned(t *testing.T, f func(r rune)) {
+	major, _ := strconv.Atoi(strings.Split(unicode.Version, ".")[0])
+	if major < 15 {
+		t.Skipf("package unicode is version %s, older than the tables", unicode.Version)
+	}
+
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		if r >= 0x30000 && (r < 0xE0000 || r > 0xE0FFF) && r%256 != 0 {
+			continue
+		}
+		if r >= 0xD800 && r <= 0xDFFF || !unassigned(r) {
+			continue
+		}
+		f(r)
+	}
+}
+
+func TestUnassigned(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+		comment  string
+	}
+
+	tests := []test{
+		{"Hi\U00040000. Bye.", []string{"Hi\U00040000. ", "Bye."}, "Other, SB998, SB11"},
+		{"Hi. \U00040000bye.", []string{"Hi. \U00040000bye."}, "Other, SB8"},
+		{"Hi. \u0378Bye.", []string{"Hi. ", "\u0378Bye."}, "Other, SB11"},
+		{"\U0001FAFF\u200D\U0001FAFF", []string{"\U0001FAFF\u200D\U0001FAFF"}, "reserved Extended_Pictographic, SB998"},
+	}
+
+	for _, test := range tests {
+		var got []string
+		seg := sentences.NewSegmenter([]byte(test.input))
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: for %+q, expected %+q, got %+q", test.comment, test.input, test.expected, got)
+		}
+	}
+
+	seg := sentences.NewSegmenter(nil)
+	eachUnassigned(t, func(r rune) {
+		input := "a" + string(r) + "\u0301a. B."
+		expected := []string{"a" + string(r) + "\u0301a. ", "B."}
+
+		seg.SetText([]byte(input))
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("for %U, expected %+q, got %+q", r, expected, got)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package uax29
+
+import (
+	"bufio"
+	"io"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/graphemes"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/sentences"
+	"github.com/clipperhouse/uax29/words"
+)
+
+// Counts are statistics of a text, see [Stats].
+type Counts struct {
+	Bytes     int
+	Runes     int
+	Graphemes int
+	// Words are the word tokens which contain a letter, number or symbol,
+	// such as "Hello" or "3.14", as in filter.Wordlike. Whitespace and
+	// punctuation are not counted.
+	Words     int
+	Sentences int
+
+	// Fields are whitespace-delimited words, as counted by POSIX wc -w, and
+	// by strings.Fields. Unlike Words, "Hello, world!" is two fields, and
+	// "世界" is one field, but two words.
+	Fields int
+	// Lines are line feeds (\n), as counted by wc -l.
+	Lines int
+}
+
+// maxStatsToken is the longest token which Stats will buffer; a longer token
+// is counted in pieces of (about) this size, so that memory is bounded
+const maxStatsToken = 1 << 20
+
+// Stats reads r to EOF, and counts its bytes, runes, graphemes, words and
+// sentences, as well as fields and lines in the manner of wc, without
+// retaining the text. Counts are identical to those of the graphemes, words
+// and sentences packages, for tokens shorter than a megabyte. Invalid UTF-8
+// bytes count as a rune each.
+//
+// As with Analyze, it is not a single pass: the three packages'
+// segmentations run interleaved over a buffer, each reading the bytes for
+// itself, so it is no faster than counting with each package separately;
+// see BenchmarkStats. Its advantage is that r is consumed once, and the text
+// need not be retained. Memory is bounded by the longest token, usually a
+// sentence. It returns the counts so far and the error, if reading fails.
+func Stats(r io.Reader) (Counts, error) {
+	var c Counts
+	field := false // the text so far ends within a field
+
+	type cursor struct {
+		split bufio.SplitFunc
+		count func(token []byte)
+		pos   int
+	}
+	cursors := [3]cursor{
+		{graphemes.SplitFunc, func(token []byte) {
+			c.Graphemes++
+			for pos := 0; pos < len(token); {
+				r, w := utf8.DecodeRune(token[pos:])
+				c.Runes++
+				if r == '\n' {
+					c.Lines++
+				}
+				space := unicode.IsSpace(r)
+				if !space && !field {
+					c.Fields++
+				}
+				field = !space
+				pos += w
+			}
+		}, 0},
+		{words.SplitFunc, func(token []byte) {
+			if filter.Wordlike(token) {
+				c.Words++
+			}
+		}, 0},
+		{sentences.SplitFunc, func([]byte) {
+			c.Sentences++
+		}, 0},
+	}
+
+	buf := make([]byte, 0, 64<<10)
+	start := 0 // position of buf[0] in the text
+	eof := false
+
+	for {
+		// Find the cursor which is furthest behind
+		cur := &cursors[0]
+		for i := range cursors {
+			if cursors[i].pos < cur.pos {
+				cur = &cursors[i]
+			}
+		}
+
+		data := buf[cur.pos-start:]
+		if len(data) == 0 && eof {
+			// All are at the end
+			return c, nil
+		}
+
+		advance := 0
+		if len(data) > 0 {
+			advance, _, _ = cur.split(data, eof) // can elide the error, see tests
+		}
+
+		if advance <= 0 && len(data) >= maxStatsToken {
+			// Count a long token in pieces, on a rune boundary if possible
+			advance = len(data)
+			for i := 1; i < utf8.UTFMax && advance-i > 0; i++ {
+				if utf8.RuneStart(data[advance-i]) {
+					if !utf8.FullRune(data[advance-i:]) {
+						advance -= i
+					}
+					break
+				}
+			}
+		}
+
+		if advance > 0 {
+			if advance > len(data) {
+				// Shouldn't happen; be sure to terminate
+				advance = len(data)
+			}
+			cur.count(data[:advance])
+			cur.pos += advance
+			continue
+		}
+
+		if eof {
+			// Shouldn't happen at EOF; be sure to terminate
+			cur.count(data)
+			cur.pos += len(data)
+			continue
+		}
+
+		// More data is needed; discard what all cursors have passed, which is
+		// up to this one, since it is furthest behind
+		if n := cur.pos - start; n > 0 {
+			copy(buf, buf[n:])
+			buf = buf[:len(buf)-n]
+			start = cur.pos
+		}
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		c.Bytes += n
+		if err == io.EOF {
+			eof = true
+		} else if err != nil {
+			return c, err
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package uax29_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29"
+	"github.com/clipperhouse/uax29/graphemes"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/sentences"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	var inputs [][]byte
+	for _, name := range []string{"sample.txt", "chat.txt", "UTF-8-test.txt"} {
+		file, err := os.ReadFile("testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, file)
+	}
+	inputs = append(inputs, nil, []byte("Hello, 世界. 👍🏼"))
+
+	for _, input := range inputs {
+		b := uax29.Analyze(input)
+
+		seg := words.NewSegmenter(input)
+		seg.Filter(filter.Wordlike)
+		wordlike := 0
+		for seg.Next() {
+			wordlike++
+		}
+
+		count := func(boundaries []int) int {
+			if len(boundaries) == 0 {
+				return 0
+			}
+			return len(boundaries) - 1
+		}
+		expected := uax29.Counts{
+			Bytes:     len(input),
+			Runes:     utf8.RuneCount(input),
+			Graphemes: count(b.Graphemes),
+			Words:     wordlike,
+			Sentences: count(b.Sentences),
+			Fields:    len(strings.Fields(string(input))),
+			Lines:     bytes.Count(input, []byte("\n")),
+		}
+
+		got, err := uax29.Stats(bytes.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != expected {
+			t.Fatalf("expected %+v, got %+v", expected, got)
+		}
+
+		// One byte at a time, so that tokens and runes span reads
+		got, err = uax29.Stats(iotest.OneByteReader(bytes.NewReader(input)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != expected {
+			t.Fatalf("one byte reader: expected %+v, got %+v", expected, got)
+		}
+	}
+}
+
+func TestStatsError(t *testing.T) {
+	t.Parallel()
+
+	e := errors.New("read failed")
+	got, err := uax29.Stats(iotest.ErrReader(e))
+	if !errors.Is(err, e) {
+		t.Fatalf("expected %v, got %v", e, err)
+	}
+	if got != (uax29.Counts{}) {
+		t.Fatalf("expected zero counts, got %+v", got)
+	}
+}
+
+func TestStatsLongToken(t *testing.T) {
+	t.Parallel()
+
+	// A sentence of several megabytes, with multi-byte runes, which is
+	// counted in pieces
+	input := bytes.Repeat([]byte("日本語 "), 500_000)
+
+	got, err := uax29.Stats(bytes.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Bytes != len(input) || got.Runes != utf8.RuneCount(input) || got.Graphemes != utf8.RuneCount(input) {
+		t.Fatalf("expected all bytes, runes and graphemes, got %+v", got)
+	}
+	if got.Fields != 500_000 || got.Words != 1_500_000 {
+		t.Fatalf("expected 500,000 fields and 1,500,000 words, got %+v", got)
+	}
+	if got.Sentences < 2 {
+		t.Fatalf("expected the long sentence to be counted in pieces, got %d", got.Sentences)
+	}
+}
+
+// BenchmarkStats compares Stats with counting by the three packages
+// separately, reading the text three times
+func BenchmarkStats(b *testing.B) {
+	file, err := os.ReadFile("testdata/sample.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Stats", func(b *testing.B) {
+		b.SetBytes(int64(len(file)))
+		for i := 0; i < b.N; i++ {
+			if _, err := uax29.Stats(bytes.NewReader(file)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("separate", func(b *testing.B) {
+		b.SetBytes(int64(len(file)))
+		for i := 0; i < b.N; i++ {
+			var counts uax29.Counts
+
+			g := graphemes.NewScanner(bytes.NewReader(file))
+			for g.Scan() {
+				counts.Graphemes++
+			}
+			w := words.NewScanner(bytes.NewReader(file))
+			for w.Scan() {
+				counts.Words++
+			}
+			s := sentences.NewScanner(bytes.NewReader(file))
+			for s.Scan() {
+				counts.Sentences++
+			}
+			_ = counts
+		}
+	})
+}
@@ -0,0 +1,99 @@
+// Package tailor provides locale tailorings of word and sentence
+// segmentation, selected by BCP 47 language tag, such as "sv" or "de-AT".
+// They are based on the CLDR segmentation tailorings, and are expressed as
+// words.Joiners and sentences.Options, so they can be modified or combined
+// with other Joiners and Options.
+//
+// The Unicode spec is a language-neutral default; CLDR tailors it for
+// particular languages. For example, the spec treats a colon as MidLetter,
+// for Swedish abbreviations such as "k:a", but CLDR does so only for Swedish
+// and Finnish, so that "key:value" is split in other languages.
+//
+// Hebrew quotation marks, as in "צה״ל", are handled by the spec (WB7a–c),
+// and need no tailoring.
+package tailor
+
+import (
+	"strings"
+
+	"github.com/clipperhouse/uax29/sentences"
+	"github.com/clipperhouse/uax29/words"
+)
+
+// Words returns the Joiners for word segmentation in the language of tag, a
+// BCP 47 language tag, such as "sv-SE". Only the language subtag is used.
+// For an unknown or empty tag, it returns the CLDR root tailoring. The result
+// is a new value, which the caller may modify.
+//
+//	seg := words.NewSegmenter(text)
+//	seg.Joiners(tailor.Words("sv"))
+func Words(tag string) *words.Joiners {
+	switch language(tag) {
+	case "sv", "fi":
+		// Colons are MidLetter, per the spec, for abbreviations such as "k:a"
+		return &words.Joiners{}
+	}
+
+	// The CLDR root removes colons from MidLetter
+	return &words.Joiners{
+		Overrides: map[rune]words.Property{
+			':':      words.Other,
+			'\ufe55': words.Other, // small colon
+			'\uff1a': words.Other, // fullwidth colon
+		},
+	}
+}
+
+// Sentences returns the Options for sentence segmentation in the language of
+// tag, a BCP 47 language tag, such as "de-AT". Only the language subtag is
+// used. For an unknown or empty tag, it returns the zero Options, i.e. the
+// spec. The result is a new value, which the caller may modify.
+//
+//	seg := sentences.NewSegmenter(text)
+//	seg.Split(tailor.Sentences("en").SplitFunc())
+func Sentences(tag string) sentences.Options {
+	var o sentences.Options
+
+	switch lang := language(tag); lang {
+	case "el":
+		// Greek uses a semicolon (or U+037E, which looks like one) as a
+		// question mark
+		o.STerms = []rune(";\u037e")
+	default:
+		if s, ok := suppressions[lang]; ok {
+			o.Suppressions = append([]string(nil), s...)
+		}
+	}
+
+	return o
+}
+
+// suppressions are abbreviations after which a sentence rarely ends, by
+// language, based on the CLDR sentence break suppressions, omitting some
+// which often end a sentence, as for sentences.EnglishSuppressions
+var suppressions = map[string][]string{
+	"en": sentences.EnglishSuppressions,
+	"de": {
+		"Dr.", "Prof.", "Hr.", "Fr.", "St.", "Nr.", "Abs.", "Abb.", "Bd.", "bzw.", "ca.",
+		"evtl.", "ggf.", "inkl.", "vgl.", "z.B.", "d.h.", "u.a.", "Jan.", "Feb.", "Jun.",
+		"Jul.", "Aug.", "Sep.", "Sept.", "Okt.", "Nov.", "Dez.",
+	},
+	"es": {
+		"Sr.", "Sra.", "Srta.", "Dr.", "Dra.", "Lic.", "Ud.", "Uds.", "Av.", "pág.", "núm.",
+		"aprox.", "p.ej.", "ene.", "feb.", "abr.", "jun.", "jul.", "ago.", "sept.", "oct.", "dic.",
+	},
+	"fr": {
+		"M.", "MM.", "Mme.", "Mlle.", "Dr.", "Pr.", "St.", "Ste.", "av.", "bd.", "p.",
+		"env.", "cf.", "janv.", "févr.", "avr.", "juil.", "sept.", "oct.", "nov.", "déc.",
+	},
+}
+
+// language returns the language subtag of a BCP 47 tag, in lower case, such
+// as "sv" for "sv-SE". Underscores are accepted as separators, as in POSIX
+// locales such as "sv_SE.UTF-8".
+func language(tag string) string {
+	if i := strings.IndexAny(tag, "-_."); i >= 0 {
+		tag = tag[:i]
+	}
+	return strings.ToLower(tag)
+}
@@ -0,0 +1,90 @@
+package tailor_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/sentences"
+	"github.com/clipperhouse/uax29/tailor"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestWords(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		tag      string
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{"sv", "Köp k:a key:value", []string{"Köp", " ", "k:a", " ", "key:value"}},
+		{"sv-SE", "k:a", []string{"k:a"}},
+		{"fi_FI.UTF-8", "k:a", []string{"k:a"}},
+		{"en", "Köp k:a key:value", []string{"Köp", " ", "k", ":", "a", " ", "key", ":", "value"}},
+		{"en", "a\ufe55b a\uff1ab", []string{"a", "\ufe55", "b", " ", "a", "\uff1a", "b"}},
+		{"", "key:value", []string{"key", ":", "value"}},
+		{"xx", "key:value", []string{"key", ":", "value"}},
+		// Otherwise, per the spec
+		{"he", "צה״ל it's", []string{"צה״ל", " ", "it's"}},
+		{"en", "3.14 it's", []string{"3.14", " ", "it's"}},
+	}
+
+	for _, test := range tests {
+		seg := words.NewSegmenter([]byte(test.input))
+		seg.Joiners(tailor.Words(test.tag))
+
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Fatalf("%s %q: expected %q, got %q", test.tag, test.input, test.expected, got)
+		}
+	}
+}
+
+func TestSentences(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		tag      string
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{"el", "Τι κάνεις; Καλά.", []string{"Τι κάνεις; ", "Καλά."}},
+		{"el-GR", "Τι κάνεις\u037e Καλά.", []string{"Τι κάνεις\u037e ", "Καλά."}},
+		{"en", "Τι κάνεις; Καλά.", []string{"Τι κάνεις; Καλά."}},
+		{"en", "Τι κάνεις\u037e Καλά.", []string{"Τι κάνεις\u037e Καλά."}},
+		{"en-US", "Dr. Smith is in. Mr. Jones is not.", []string{"Dr. Smith is in. ", "Mr. Jones is not."}},
+		{"de", "Das ist z.B. ein Test. Gut.", []string{"Das ist z.B. ein Test. ", "Gut."}},
+		{"fr", "M. Dupont est là. Bien.", []string{"M. Dupont est là. ", "Bien."}},
+		{"es", "La Sra. García llegó. Bien.", []string{"La Sra. García llegó. ", "Bien."}},
+		{"", "Dr. Smith is in.", []string{"Dr. ", "Smith is in."}},
+	}
+
+	for _, test := range tests {
+		seg := sentences.NewSegmenter([]byte(test.input))
+		seg.Split(tailor.Sentences(test.tag).SplitFunc())
+
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Fatalf("%s %q: expected %q, got %q", test.tag, test.input, test.expected, got)
+		}
+	}
+
+	// The result is a copy, which may be modified
+	o := tailor.Sentences("en")
+	o.Suppressions[0] = "modified"
+	if sentences.EnglishSuppressions[0] == "modified" {
+		t.Fatal("expected a copy of the suppressions")
+	}
+}
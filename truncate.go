@@ -0,0 +1,81 @@
+package uax29
+
+import (
+	"bufio"
+
+	"github.com/clipperhouse/uax29/graphemes"
+	"github.com/clipperhouse/uax29/words"
+)
+
+// TruncateToFit returns the longest prefix of data which is at most maxBytes
+// long, and ends at a grapheme cluster boundary, so that a multi-byte rune
+// or a cluster (such as an emoji with a skin tone, or a letter with an
+// accent) is never split. It is intended for storage with a byte limit,
+// such as a VARCHAR(n) column. If data is valid UTF-8, so is the result.
+//
+// If wholeWords is true, the prefix also ends at a word boundary, so that
+// it does not end with part of a word. It ends at the last position which is
+// both a word and a grapheme cluster boundary; the two usually coincide, but
+// not always, since words pairs regional indicators across Extend (WB4) and
+// graphemes does not. If there is no such position, for example because the
+// first word alone is longer than maxBytes, it is truncated at a grapheme
+// cluster boundary instead.
+//
+// If data is no longer than maxBytes, it is returned whole. The result is a
+// subslice of data; it does not allocate.
+func TruncateToFit(data []byte, maxBytes int, wholeWords bool) []byte {
+	if len(data) <= maxBytes {
+		return data
+	}
+	if maxBytes <= 0 {
+		return data[:0]
+	}
+
+	if wholeWords {
+		if end := lastCommonBoundary(data, maxBytes, words.SplitFunc, graphemes.SplitFunc); end > 0 {
+			return data[:end]
+		}
+	}
+
+	return data[:lastBoundary(data, maxBytes, graphemes.SplitFunc)]
+}
+
+// lastBoundary returns the last boundary per split which is at most max
+func lastBoundary(data []byte, max int, split bufio.SplitFunc) int {
+	pos := 0
+	for pos < len(data) {
+		advance, _, _ := split(data[pos:], true) // can elide the error, see tests
+		if advance <= 0 || pos+advance > max {
+			break
+		}
+		pos += advance
+	}
+	return pos
+}
+
+// lastCommonBoundary returns the last boundary per both split1 and split2
+// which is at most max
+func lastCommonBoundary(data []byte, max int, split1, split2 bufio.SplitFunc) int {
+	var pos1, pos2, common int
+	for {
+		// Advance whichever is behind
+		split, pos := split1, &pos1
+		if pos2 < pos1 {
+			split, pos = split2, &pos2
+		}
+
+		if *pos >= len(data) {
+			break
+		}
+		advance, _, _ := split(data[*pos:], true) // can elide the error, see tests
+		if advance <= 0 || *pos+advance > max {
+			break
+		}
+		*pos += advance
+
+		if pos1 == pos2 {
+			common = pos1
+		}
+	}
+	return common
+}
@@ -0,0 +1,108 @@
+package uax29_test
+
+import (
+	"bytes"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29"
+)
+
+func TestTruncateToFit(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input      string
+		maxBytes   int
+		wholeWords bool
+		expected   string
+	}
+
+	tests := []test{
+		{"Hello, world", 20, false, "Hello, world"},
+		{"Hello, world", 12, true, "Hello, world"},
+		{"Hello, world", 10, false, "Hello, wor"},
+		{"Hello, world", 10, true, "Hello, "},
+		{"Hello, world", 0, false, ""},
+		{"Hello, world", -1, true, ""},
+		{"世界", 4, false, "世"},                   // a rune is not split
+		{"e\u0301e\u0301", 4, false, "e\u0301"}, // a cluster is not split
+		{"\U0001F44D\U0001F3FD!", 7, false, ""}, // emoji with skin tone
+		{"\U0001F1FA\U0001F1F8\U0001F1EC\U0001F1E7", 12, false, "\U0001F1FA\U0001F1F8"},
+		{"Supercalifragilistic", 5, true, "Super"}, // first word is too long
+		{"The cafe\u0301", 9, true, "The "},
+		{"can't stop", 4, true, "can'"}, // first word is too long
+		{"can't stop", 6, true, "can't "},
+	}
+
+	for _, test := range tests {
+		got := uax29.TruncateToFit([]byte(test.input), test.maxBytes, test.wholeWords)
+		if string(got) != test.expected {
+			t.Errorf("%q, %d, %t: expected %q, got %q", test.input, test.maxBytes, test.wholeWords, test.expected, got)
+		}
+	}
+}
+
+func TestTruncateToFitBoundaries(t *testing.T) {
+	t.Parallel()
+
+	texts := []string{
+		"Hello, 世界. Nice dog! \U0001F44D\U0001F3FD \U0001F468\u200D\U0001F469\u200D\U0001F467 e\u0301te\u0301 can't",
+		// Words pairs the regional indicators across the virama (WB4), graphemes does not
+		"。-\u0085 👍»🇺\u094D🇸🇺B\u200D。",
+	}
+
+	contains := func(boundaries []int, pos int) bool {
+		for _, b := range boundaries {
+			if b == pos {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, text := range texts {
+		text := []byte(text)
+		b := uax29.Analyze(text)
+
+		for max := 0; max <= len(text); max++ {
+			got := uax29.TruncateToFit(text, max, false)
+			if len(got) > max {
+				t.Fatalf("%q, %d: expected at most %d bytes, got %d", text, max, max, len(got))
+			}
+			if !utf8.Valid(got) {
+				t.Fatalf("%q, %d: expected valid UTF-8, got %q", text, max, got)
+			}
+			if !contains(b.Graphemes, len(got)) {
+				t.Fatalf("%q, %d: expected a grapheme boundary, got %q", text, max, got)
+			}
+			graphemesOnly := got
+
+			got = uax29.TruncateToFit(text, max, true)
+			if len(got) > max {
+				t.Fatalf("%q, %d: expected at most %d bytes, got %d", text, max, max, len(got))
+			}
+			if !contains(b.Graphemes, len(got)) {
+				t.Fatalf("%q, %d: expected a grapheme boundary with whole words, got %q", text, max, got)
+			}
+			// If no word boundary fits, it is truncated at a grapheme boundary
+			if !contains(b.Words, len(got)) && len(got) != len(graphemesOnly) {
+				t.Fatalf("%q, %d: expected a word boundary, got %q", text, max, got)
+			}
+		}
+	}
+}
+
+func TestTruncateToFitRegionalIndicators(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("。-\u0085 👍»🇺\u094D🇸🇺B\u200D。")
+	got := uax29.TruncateToFit(text, 24, true)
+
+	// The word boundary at 24 bytes, after 🇸, splits the grapheme 🇸🇺; the
+	// previous word boundary which is also a grapheme boundary is before 🇺
+	expected := []byte("。-\u0085 👍»")
+	if !bytes.Equal(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,123 @@
+// Package versions is a record of the changes to this module which change
+// tokenization, i.e. where the boundaries fall, such as a Unicode upgrade, a
+// fix to the rules, or a change to a default. Deployment tooling can use it
+// to rebuild an index only when boundaries have actually changed, and not on
+// every upgrade of the module.
+//
+// The record is changes.json, in this directory, which is embedded; tooling
+// in other languages can read the file directly. Each change increments the
+// rules version of the package, which for words and sentences is part of the
+// Fingerprint.
+package versions
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Kind is the reason for a change.
+type Kind string
+
+const (
+	// Baseline is the first recorded state of a package, not a change.
+	// Releases before it are not recorded.
+	Baseline Kind = "baseline"
+	// Unicode is an upgrade of the Unicode data.
+	Unicode Kind = "unicode"
+	// Rules is a change to the implementation of the rules, such as a fix.
+	Rules Kind = "rules"
+	// Default is a change to the default behavior, such as a default option.
+	Default Kind = "default"
+)
+
+// Change is a change to the tokenization of a package.
+type Change struct {
+	// Package is the package whose tokenization changed, such as "words".
+	Package string `json:"package"`
+	// Rules is the rules version of the package after the change, as in the
+	// Fingerprint of words and sentences.
+	Rules int `json:"rules"`
+	// Unicode is the version of the Unicode data after the change.
+	Unicode string `json:"unicode"`
+	// Source describes the data, where it is not simply the UCD of the
+	// Unicode version, such as a rule from a later version.
+	Source string `json:"source,omitempty"`
+	// Kind is the reason for the change.
+	Kind Kind `json:"kind"`
+	// Release is the first module version which includes the change, such as
+	// "v1.14.0", if known.
+	Release string `json:"release,omitempty"`
+	// Summary describes the change, for humans.
+	Summary string `json:"summary"`
+}
+
+//go:embed changes.json
+var changesJSON []byte
+
+var changes = func() []Change {
+	var c []Change
+	if err := json.Unmarshal(changesJSON, &c); err != nil {
+		panic("versions: invalid changes.json: " + err.Error())
+	}
+	return c
+}()
+
+// Changes returns all recorded changes, in order. The result is a copy,
+// which the caller may modify.
+func Changes() []Change {
+	return append([]Change(nil), changes...)
+}
+
+// Current returns the latest change to the package, which describes its
+// tokenization in this version of the module. It returns false for an
+// unknown package.
+func Current(pkg string) (Change, bool) {
+	for i := len(changes) - 1; i >= 0; i-- {
+		if changes[i].Package == pkg {
+			return changes[i], true
+		}
+	}
+	return Change{}, false
+}
+
+// Since returns the changes to the package after the given rules and Unicode
+// versions, which are those of an existing index. If there are none, the
+// boundaries are unchanged, and the index need not be rebuilt.
+//
+// It returns false if the versions are not in the record, such as an index
+// made before the record began, or with a newer version of the module; in
+// that case, rebuild the index.
+func Since(pkg string, rules int, unicode string) ([]Change, bool) {
+	for i, c := range changes {
+		if c.Package == pkg && c.Rules == rules && c.Unicode == unicode {
+			var since []Change
+			for _, c := range changes[i+1:] {
+				if c.Package == pkg {
+					since = append(since, c)
+				}
+			}
+			return since, true
+		}
+	}
+	return nil, false
+}
+
+// SinceFingerprint is Since, for the versions in fingerprint, which is from
+// words.Joiners.Fingerprint or sentences.Options.Fingerprint. It returns false
+// if the fingerprint can't be parsed.
+//
+// Only changes to this module are considered; a fingerprint also changes with
+// the Joiners or Options, which is the caller's concern.
+func SinceFingerprint(fingerprint string) ([]Change, bool) {
+	parts := strings.Split(fingerprint, "/")
+	if len(parts) != 4 {
+		return nil, false
+	}
+	rules, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, false
+	}
+	return Since(parts[0], rules, parts[2])
+}
@@ -0,0 +1,119 @@
+package versions_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/sentences"
+	"github.com/clipperhouse/uax29/versions"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestChanges(t *testing.T) {
+	t.Parallel()
+
+	kinds := map[versions.Kind]bool{
+		versions.Baseline: true,
+		versions.Unicode:  true,
+		versions.Rules:    true,
+		versions.Default:  true,
+	}
+
+	last := map[string]versions.Change{}
+	for i, c := range versions.Changes() {
+		if c.Package == "" || c.Unicode == "" || c.Summary == "" {
+			t.Errorf("change %d is incomplete: %+v", i, c)
+		}
+		if !kinds[c.Kind] {
+			t.Errorf("change %d has unknown kind %q", i, c.Kind)
+		}
+
+		prev, ok := last[c.Package]
+		switch {
+		case !ok && c.Kind != versions.Baseline:
+			t.Errorf("change %d: the first change to %s should be a baseline", i, c.Package)
+		case ok && c.Kind == versions.Baseline:
+			t.Errorf("change %d: %s has more than one baseline", i, c.Package)
+		case ok && c.Rules != prev.Rules+1:
+			t.Errorf("change %d: rules version of %s should be %d, got %d", i, c.Package, prev.Rules+1, c.Rules)
+		}
+		last[c.Package] = c
+	}
+
+	for _, pkg := range []string{"graphemes", "words", "sentences", "phrases", "lines"} {
+		if _, ok := versions.Current(pkg); !ok {
+			t.Errorf("no record of %s", pkg)
+		}
+	}
+}
+
+// TestFingerprints ensures that the record is updated along with the rules
+// versions of the packages
+func TestFingerprints(t *testing.T) {
+	t.Parallel()
+
+	wf, err := (*words.Joiners)(nil).Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprints := []string{
+		wf,
+		sentences.Options{}.Fingerprint(),
+	}
+
+	for _, fingerprint := range fingerprints {
+		since, ok := versions.SinceFingerprint(fingerprint)
+		if !ok {
+			t.Errorf("%s is not in the record; add a change to changes.json", fingerprint)
+			continue
+		}
+		if len(since) > 0 {
+			t.Errorf("%s should be the current version, but has %d changes since", fingerprint, len(since))
+		}
+	}
+}
+
+func TestSince(t *testing.T) {
+	t.Parallel()
+
+	current, ok := versions.Current("words")
+	if !ok {
+		t.Fatal("no record of words")
+	}
+
+	since, ok := versions.Since("words", current.Rules, current.Unicode)
+	if !ok || len(since) != 0 {
+		t.Errorf("expected no changes since the current version, got %v, %t", since, ok)
+	}
+
+	tests := []struct {
+		pkg     string
+		rules   int
+		unicode string
+	}{
+		{"words", current.Rules + 1, current.Unicode},
+		{"words", current.Rules, "1.0.0"},
+		{"words", 0, current.Unicode},
+		{"nope", current.Rules, current.Unicode},
+	}
+	for _, test := range tests {
+		if _, ok := versions.Since(test.pkg, test.rules, test.unicode); ok {
+			t.Errorf("expected %s/%d/%s not to be in the record", test.pkg, test.rules, test.unicode)
+		}
+	}
+
+	for _, fingerprint := range []string{"", "words", "words/x/15.0.0/abc", "words/1/15.0.0"} {
+		if _, ok := versions.SinceFingerprint(fingerprint); ok {
+			t.Errorf("expected %q not to parse", fingerprint)
+		}
+	}
+}
+
+func TestChangesCopy(t *testing.T) {
+	t.Parallel()
+
+	c := versions.Changes()
+	c[0].Package = "modified"
+	if versions.Changes()[0].Package == "modified" {
+		t.Error("Changes should return a copy")
+	}
+}
@@ -0,0 +1,73 @@
+package words
+
+import (
+	"bufio"
+
+	"github.com/clipperhouse/uax29/ansi"
+)
+
+// ANSI sets options for segmenting text which contains ANSI escape sequences,
+// such as terminal output. See the [ANSI] type.
+func (seg *Segmenter) ANSI(a *ANSI) {
+	seg.Split(a.SplitFunc())
+}
+
+// ANSI sets options for segmenting text which contains ANSI escape sequences,
+// such as terminal output. See the [ANSI] type.
+func (sc *Scanner) ANSI(a *ANSI) {
+	sc.Split(a.SplitFunc())
+}
+
+// ANSI specifies the treatment of ANSI escape sequences, such as those used for
+// terminal colors. Escape sequences are treated as single tokens, rather than
+// being split into punctuation and words. Sequences are parsed by the ansi package.
+type ANSI struct {
+	// MaxLength is the maximum length, in bytes, of an escape sequence. Longer
+	// sequences are abandoned, and segmented as ordinary text. If zero,
+	// ansi.DefaultMaxLength is used. See ansi.Parse.
+	MaxLength int
+
+	// Payloads specifies that the text payloads of OSC and DCS sequences, such as
+	// window titles, should be segmented into words. The introducer
+	// (e.g. ESC ] 0 ;) and the terminator (BEL or ST) will be separate tokens.
+	//
+	// If false, the default, the entire sequence is a single token. To skip
+	// sequences, use filter.NoANSI, or filter.NoANSIWith for options other
+	// than the defaults.
+	Payloads bool
+
+	// Joiners optionally specifies Joiners for the text outside of escape sequences.
+	Joiners *Joiners
+}
+
+// SplitFunc returns a bufio.SplitFunc for the options specified by a.
+// It is suitable for use with a Scanner; sequences which span reads are
+// handled correctly.
+func (a *ANSI) SplitFunc() bufio.SplitFunc {
+	max := ansi.DefaultMaxLength
+	if a.MaxLength > 0 {
+		max = a.MaxLength
+	}
+
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if len(data) > 0 && data[0] == 0x1B { // ESC
+			n, _, more := ansi.Parse(data, max, atEOF)
+			if more {
+				// Sequence extends past current data, request more
+				return 0, nil, nil
+			}
+			if n > 0 {
+				if a.Payloads {
+					// Return only the introducer; the payload will be segmented
+					// in subsequent calls, and the terminator is a token of its own
+					if start, end := ansi.Payload(data[:n]); start > 0 && start < end {
+						n = start
+					}
+				}
+				return n, data[:n], nil
+			}
+		}
+
+		return a.Joiners.splitFunc(data, atEOF)
+	}
+}
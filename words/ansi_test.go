@@ -0,0 +1,57 @@
+package words_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/internal/segtest"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+var ansiInput = []byte("\x1b]0;build: all-green\x07\x1b[1mok\x1b[0m done")
+
+func TestANSI(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		name     string
+		ansi     *words.ANSI
+		filter   filter.Func
+		expected []string
+	}
+
+	tests := []test{
+		{
+			name:     "default",
+			ansi:     &words.ANSI{},
+			expected: []string{"\x1b]0;build: all-green\x07", "\x1b[1m", "ok", "\x1b[0m", " ", "done"},
+		},
+		{
+			name:     "payloads",
+			ansi:     &words.ANSI{Payloads: true},
+			expected: []string{"\x1b]0;", "build", ":", " ", "all", "-", "green", "\x07", "\x1b[1m", "ok", "\x1b[0m", " ", "done"},
+		},
+		{
+			name:     "payloads with joiners",
+			ansi:     &words.ANSI{Payloads: true, Joiners: &words.Joiners{Middle: []rune("-")}},
+			expected: []string{"\x1b]0;", "build", ":", " ", "all-green", "\x07", "\x1b[1m", "ok", "\x1b[0m", " ", "done"},
+		},
+		{
+			name:     "skip",
+			ansi:     &words.ANSI{},
+			filter:   filter.NoANSI,
+			expected: []string{"ok", " ", "done"},
+		},
+		{
+			name:     "too long",
+			ansi:     &words.ANSI{MaxLength: 8},
+			expected: []string{"\x1b", "]", "0", ";", "build", ":", " ", "all", "-", "green", "\x07", "\x1b[1m", "ok", "\x1b[0m", " ", "done"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			segtest.AssertSegments(t, test.ansi.SplitFunc(), test.filter, ansiInput, test.expected)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package words
+
+// IsBoundary determines if position i of data is a word boundary, as would
+// be found by a Segmenter (without Joiners), without segmenting from the start
+// of data. It is useful for cursor movement and double-click selection.
+// Positions 0 and len(data) are boundaries for non-empty data; positions
+// outside data are not.
+//
+// It looks back from i to the nearest position which is known to be a
+// boundary by its neighbors alone, such as after a line break, or between a
+// space and a letter, and segments forward from there. In the worst case (a
+// long text with no spaces, for example), that is the start of data.
+//
+// Data is assumed to be valid UTF-8. A Segmenter returns the remainder of
+// invalid text from an incomplete rune onward as a single token, which
+// IsBoundary does not look back far enough to see.
+func IsBoundary(data []byte, i int) bool {
+	if len(data) == 0 || i < 0 || i > len(data) {
+		return false
+	}
+	if i == 0 || i == len(data) {
+		return true
+	}
+
+	pos := i
+	for pos > 0 && !knownBoundary(data, pos) {
+		pos--
+	}
+
+	for pos < i {
+		advance, _, _ := SplitFunc(data[pos:], true) // can elide the error, see tests
+		if advance <= 0 {
+			return false
+		}
+		pos += advance
+	}
+
+	return pos == i
+}
+
+// knownBoundary determines if 0 < pos < len(data) is a boundary, by the
+// bytes on either side of it. It may return false for a boundary.
+func knownBoundary(data []byte, pos int) bool {
+	a, b := data[pos-1], data[pos]
+	if a == '\r' && b == '\n' {
+		// WB3
+		return false
+	}
+	if a == '\n' || a == '\r' || a == '\v' || a == '\f' {
+		// WB3a
+		return true
+	}
+	// No rule joins an ASCII character to a space, other than a space (WB3d),
+	// nor a space to an ASCII character (WB4 requires Extend, Format or ZWJ)
+	return a < 0x80 && b < 0x80 && (a == ' ') != (b == ' ')
+}
@@ -0,0 +1,271 @@
+package words
+
+import (
+	"bufio"
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Combiner recognizes a sequence of tokens which should be combined into a
+// single token, such as a URL, see [Combine].
+type Combiner interface {
+	// Combine determines if data begins with a sequence to be combined, and
+	// returns its length, or zero if not. Data begins at a token boundary.
+	// If more is true, the sequence may extend past data, and Combine should
+	// be called again with more data; it will not be if atEOF is true.
+	Combine(data []byte, atEOF bool) (n int, more bool)
+}
+
+// Combine returns a bufio.SplitFunc which combines tokens recognized by
+// combiners into single tokens, and otherwise defers to split. Combiners are
+// tried in order, at each token boundary; the first to recognize a sequence
+// wins. It is suitable for use with a Scanner; sequences which span reads are
+// handled correctly.
+//
+//	seg := words.NewSegmenter(text)
+//	seg.Split(words.Combine(words.SplitFunc, words.URLs, words.Emails, words.Hashtags, words.Mentions))
+func Combine(split bufio.SplitFunc, combiners ...Combiner) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		for _, c := range combiners {
+			n, more := c.Combine(data, atEOF)
+			if more && !atEOF {
+				// Sequence extends past current data, request more
+				return 0, nil, nil
+			}
+			if n > 0 {
+				return n, data[:n], nil
+			}
+		}
+
+		return split(data, atEOF)
+	}
+}
+
+// SplitFunc returns a bufio.SplitFunc for the Joiners, for example to use with [Combine].
+func (j *Joiners) SplitFunc() bufio.SplitFunc {
+	return j.splitFunc
+}
+
+var (
+	// Hashtags combines a # and the subsequent word, such as #golang.
+	Hashtags Combiner = prefixed('#')
+	// Mentions combines an @ and the subsequent word, such as @user. Place
+	// it after [Emails], so that an email address is not split at the @.
+	Mentions Combiner = prefixed('@')
+	// Emails combines an email address, such as someone@example.com. It
+	// recognizes ASCII addresses, with a domain of at least two labels, and
+	// the lengths allowed by RFC 5321: 64 bytes before the @, 255 after.
+	Emails Combiner = emails{}
+	// URLs combines an http or https URL, such as https://example.com/path?q=1.
+	// Trailing punctuation, such as a period at the end of a sentence, or an
+	// unbalanced closing parenthesis, is not included.
+	URLs Combiner = urls{}
+)
+
+// PunctuationRuns returns a Combiner which combines a run of a repeated
+// punctuation character, such as "!!!", "---" or "...", into a single token,
+// where the spec would split it into one token per character. If max is
+// greater than zero, runs are split into tokens of at most max characters.
+//
+// ASCII symbols, such as "***" or "===", are included; other symbols, such as
+// emoji, are not.
+func PunctuationRuns(max int) Combiner {
+	return runs(max)
+}
+
+type runs int
+
+func (max runs) Combine(data []byte, atEOF bool) (int, bool) {
+	if !utf8.FullRune(data) && !atEOF {
+		return 0, true
+	}
+
+	r, w := utf8.DecodeRune(data)
+	if !unicode.IsPunct(r) && !(r < utf8.RuneSelf && unicode.IsSymbol(r)) {
+		return 0, false
+	}
+
+	i, n := w, 1
+	for max <= 0 || n < int(max) {
+		if i+w > len(data) {
+			if !atEOF && bytes.HasPrefix(data[:w], data[i:]) {
+				// The next character may be the same
+				return 0, true
+			}
+			break
+		}
+		if !bytes.Equal(data[i:i+w], data[:w]) {
+			break
+		}
+		i += w
+		n++
+	}
+
+	if n < 2 {
+		return 0, false
+	}
+	return i, false
+}
+
+// prefixed combines a prefix rune and the subsequent alphanumeric word
+type prefixed rune
+
+func (p prefixed) Combine(data []byte, atEOF bool) (int, bool) {
+	if data[0] != byte(p) {
+		return 0, false
+	}
+	if len(data) == 1 {
+		return 0, true
+	}
+
+	advance, token, _ := SplitFunc(data[1:], atEOF)
+	if advance == 0 {
+		return 0, true
+	}
+
+	r, _ := utf8.DecodeRune(token)
+	if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+		return 0, false
+	}
+
+	return 1 + advance, false
+}
+
+type emails struct{}
+
+// The maximum lengths of the local part and the domain of an email address,
+// per RFC 5321. They bound the lookahead, so that a long run of, say, a-a-a-…
+// is neither scanned from every token, nor buffered in its entirety.
+const (
+	maxLocal  = 64
+	maxDomain = 255
+)
+
+func (emails) Combine(data []byte, atEOF bool) (int, bool) {
+	if !isAlnum(data[0]) {
+		return 0, false
+	}
+
+	i := 0
+	for i < len(data) && i <= maxLocal && isLocal(data[i]) {
+		i++
+	}
+	if i > maxLocal {
+		// Too long to be followed by @
+		return 0, false
+	}
+	if i == len(data) {
+		return 0, true
+	}
+	if data[i] != '@' {
+		return 0, false
+	}
+	i++
+
+	start := i
+	for i < len(data) && i-start <= maxDomain && (isAlnum(data[i]) || data[i] == '-' || data[i] == '.') {
+		i++
+	}
+	if i-start > maxDomain {
+		return 0, false
+	}
+	if i == len(data) && !atEOF {
+		return 0, true
+	}
+
+	// A trailing period or hyphen ends the sentence, not the domain
+	domain := bytes.TrimRight(data[start:i], ".-")
+	labels := bytes.Split(domain, []byte("."))
+	if len(labels) < 2 {
+		return 0, false
+	}
+	for _, label := range labels {
+		if len(label) == 0 {
+			return 0, false
+		}
+	}
+
+	return start + len(domain), false
+}
+
+type urls struct{}
+
+var schemes = [][]byte{[]byte("https://"), []byte("http://")}
+
+func (urls) Combine(data []byte, atEOF bool) (int, bool) {
+	if data[0] != 'h' && data[0] != 'H' {
+		return 0, false
+	}
+
+	var i int
+	for _, scheme := range schemes {
+		if len(data) < len(scheme) {
+			if bytes.EqualFold(data, scheme[:len(data)]) {
+				return 0, true
+			}
+			continue
+		}
+		if bytes.EqualFold(data[:len(scheme)], scheme) {
+			i = len(scheme)
+			break
+		}
+	}
+	if i == 0 {
+		return 0, false
+	}
+
+	for i < len(data) {
+		if !utf8.FullRune(data[i:]) && !atEOF {
+			return 0, true
+		}
+		r, w := utf8.DecodeRune(data[i:])
+		if !isURL(r) {
+			break
+		}
+		i += w
+	}
+	if i == len(data) && !atEOF {
+		return 0, true
+	}
+
+	// Trim trailing punctuation, and unbalanced closing parentheses
+	n := i
+	for n > 0 {
+		switch data[n-1] {
+		case '.', ',', ';', ':', '!', '?', '\'', '"':
+			n--
+			continue
+		case ')':
+			if bytes.Count(data[:n], []byte("(")) < bytes.Count(data[:n], []byte(")")) {
+				n--
+				continue
+			}
+		}
+		break
+	}
+
+	return n, false
+}
+
+// isURL determines if r may appear in a URL (or IRI)
+func isURL(r rune) bool {
+	switch r {
+	case '<', '>', '"', '`', '{', '}', '|', '\\', '^', utf8.RuneError:
+		return false
+	}
+	return !unicode.IsSpace(r) && !unicode.IsControl(r)
+}
+
+func isAlnum(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// isLocal determines if b may appear in the local part of an email address
+func isLocal(b byte) bool {
+	return isAlnum(b) || b == '.' || b == '_' || b == '%' || b == '+' || b == '-'
+}
@@ -0,0 +1,157 @@
+package words_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/clipperhouse/uax29/internal/segtest"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestCombine(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	split := words.Combine(words.SplitFunc, words.URLs, words.Emails, words.Hashtags, words.Mentions)
+
+	tests := []test{
+		{"Hello, world.", []string{"Hello", "world"}},
+		{"I love #golang and #go_lang!", []string{"I", "love", "#golang", "and", "#go_lang"}},
+		{"Ask @ada or @bob.", []string{"Ask", "@ada", "or", "@bob"}},
+		{"Email foo.bar+baz@example.co.uk.", []string{"Email", "foo.bar+baz@example.co.uk"}},
+		{"Not an email: foo@localhost", []string{"Not", "an", "email", "foo", "@localhost"}},
+		{"See https://example.com/a/b?q=1&r=2#frag.", []string{"See", "https://example.com/a/b?q=1&r=2#frag"}},
+		{"(see http://en.wikipedia.org/wiki/Go_(language))", []string{"see", "http://en.wikipedia.org/wiki/Go_(language)"}},
+		{"(see HTTPS://example.com)", []string{"see", "HTTPS://example.com"}},
+		{"https://例え.jp/パス ok", []string{"https://例え.jp/パス", "ok"}},
+		{"http is a protocol", []string{"http", "is", "a", "protocol"}},
+		{"# is a hash, @ is at", []string{"is", "a", "hash", "is", "at"}},
+		{"C# and F#", []string{"C", "and", "F"}},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, split, filter.Wordlike, []byte(test.input), test.expected)
+	}
+}
+
+func TestCombineEmailsLength(t *testing.T) {
+	t.Parallel()
+
+	split := words.Combine(words.SplitFunc, words.Emails)
+
+	local := strings.Repeat("a", 64)
+	input := local + "@example.com " + local + "a@example.com"
+	expected := []string{local + "@example.com", local + "a", "example.com"}
+	segtest.AssertSegments(t, split, filter.Wordlike, []byte(input), expected)
+
+	domain := strings.Repeat("b", 252) + ".co"
+	input = "a@" + domain + " a@" + domain + "m"
+	expected = []string{"a@" + domain, "a", domain + "m"}
+	segtest.AssertSegments(t, split, filter.Wordlike, []byte(input), expected)
+}
+
+// TestCombineEmailsLong ensures that a long run which might be the local part
+// of an email address is not buffered in its entirety, nor rescanned from
+// every token
+func TestCombineEmailsLong(t *testing.T) {
+	t.Parallel()
+
+	split := words.Combine(words.SplitFunc, words.Emails)
+	input := bytes.Repeat([]byte("a-"), 40_000)
+
+	sc := words.NewScanner(bytes.NewReader(input))
+	sc.Split(split)
+
+	var n int
+	for sc.Scan() {
+		n++
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 80_000 {
+		t.Errorf("expected 80,000 tokens, got %d", n)
+	}
+}
+
+func TestCombineRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	split := words.Combine(words.SplitFunc, words.URLs, words.Emails, words.Hashtags, words.Mentions)
+	seg := words.NewSegmenter(nil)
+	seg.Split(split)
+
+	for i := 0; i < 100; i++ {
+		input := getRandomBytes()
+		seg.SetText(input)
+
+		var output []byte
+		for seg.Next() {
+			output = append(output, seg.Bytes()...)
+		}
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(output, input) {
+			t.Fatal("input bytes are not the same as segmented bytes")
+		}
+	}
+}
+
+func TestCombineJoiners(t *testing.T) {
+	t.Parallel()
+
+	seg := words.NewSegmenter([]byte("A well-known site: https://example.com"))
+	seg.Split(words.Combine(words.SearchJoiners().SplitFunc(), words.URLs))
+	seg.Filter(filter.Wordlike)
+
+	var got []string
+	for seg.Next() {
+		got = append(got, seg.Text())
+	}
+
+	expected := []string{"A", "well-known", "site", "https://example.com"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestPunctuationRuns(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		max      int
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{0, "wow!!! ok", []string{"wow", "!!!", " ", "ok"}},
+		{0, "a---b", []string{"a", "---", "b"}},
+		{0, "wait……", []string{"wait", "……"}},
+		{0, "so...", []string{"so", "..."}},
+		{0, "***", []string{"***"}},
+		{0, "?!?", []string{"?", "!", "?"}},
+		{0, "a!b", []string{"a", "!", "b"}},
+		{0, "👍👍", []string{"👍", "👍"}},
+		{3, "!!!!!!!", []string{"!!!", "!!!", "!"}},
+		{2, "!!!!", []string{"!!", "!!"}},
+		{1, "!!!", []string{"!", "!", "!"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("max %d", test.max), func(t *testing.T) {
+			split := words.Combine(words.SplitFunc, words.PunctuationRuns(test.max))
+			segtest.AssertSegments(t, split, nil, []byte(test.input), test.expected)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package words
+
+import (
+	"bytes"
+	"sort"
+	"unicode/utf8"
+)
+
+// Dehyphenated is text which has had words joined across hyphenated line
+// breaks, see [Dehyphenate]. It retains a map of offsets, in order to
+// relate positions in Text to positions in the original text.
+type Dehyphenated struct {
+	// Text is the dehyphenated text
+	Text []byte
+
+	// at are positions in Text at which bytes were removed, in ascending order,
+	// and removed are the cumulative number of bytes removed at each position
+	at      []int
+	removed []int
+}
+
+// Original returns the position (byte index) in the original text, which
+// corresponds to position i in Text. For example, it can be used to map
+// the Start and End of a token back to the original.
+func (d *Dehyphenated) Original(i int) int {
+	// the last removal at or before i
+	n := sort.Search(len(d.at), func(j int) bool {
+		return d.at[j] > i
+	})
+	if n == 0 {
+		return i
+	}
+	return i + d.removed[n-1]
+}
+
+// hyphens are the characters considered by Dehyphenate: hyphen-minus and hyphen
+const hyphens = "-‐"
+
+// Dehyphenate joins words which are split by a hyphen and a line break, as is
+// common in typeset or OCR'd text. For example, "seg-\nmentation" becomes
+// "segmentation". The hyphen must follow a letter, and the line break (which
+// may be followed by spaces) must precede a letter.
+//
+// Some words are hyphenated regardless of line breaks, such as "well-known".
+// If exclude is not nil, it is called with the words on either side of the
+// hyphen; if it returns true, the hyphen is retained, and only the line
+// break is removed. A dictionary lookup is a typical implementation.
+//
+// The returned Dehyphenated will have its own copy of the text; the input
+// is not modified.
+func Dehyphenate(text []byte, exclude func(left, right []byte) bool) *Dehyphenated {
+	d := &Dehyphenated{
+		Text: make([]byte, 0, len(text)),
+	}
+
+	var pos, removed int
+	for pos < len(text) {
+		i, w := nextHyphen(text[pos:])
+		if i < 0 {
+			break
+		}
+		i += pos
+		after := i + w // the byte after the hyphen
+
+		// must follow a letter
+		left := leftWord(text[:i])
+		if len(left) == 0 {
+			d.Text = append(d.Text, text[pos:after]...)
+			pos = after
+			continue
+		}
+
+		// must be followed by a line break...
+		end := after
+		switch {
+		case bytes.HasPrefix(text[end:], []byte("\r\n")):
+			end += 2
+		case bytes.HasPrefix(text[end:], []byte("\n")), bytes.HasPrefix(text[end:], []byte("\r")):
+			end++
+		default:
+			d.Text = append(d.Text, text[pos:after]...)
+			pos = after
+			continue
+		}
+
+		// ...and optional spaces...
+		for end < len(text) {
+			lookup, w := trie.lookup(text[end:])
+			if w == 0 || !lookup.is(_WSegSpace) {
+				break
+			}
+			end += w
+		}
+
+		// ...and a letter
+		var right []byte
+		if end < len(text) {
+			if lookup, _ := trie.lookup(text[end:]); lookup.is(_AHLetter) {
+				_, right, _ = SplitFunc(text[end:], true)
+			}
+		}
+		if len(right) == 0 {
+			d.Text = append(d.Text, text[pos:after]...)
+			pos = after
+			continue
+		}
+
+		// Retain the hyphen if excluded, otherwise remove it with the line break
+		keep := i
+		if exclude != nil && exclude(left, right) {
+			keep = after
+		}
+		d.Text = append(d.Text, text[pos:keep]...)
+
+		removed += end - keep
+		d.at = append(d.at, len(d.Text))
+		d.removed = append(d.removed, removed)
+
+		pos = end
+	}
+
+	d.Text = append(d.Text, text[pos:]...)
+
+	return d
+}
+
+// nextHyphen returns the index and width of the first hyphen in data,
+// or -1 if not found.
+func nextHyphen(data []byte) (int, int) {
+	i := bytes.IndexAny(data, hyphens)
+	if i < 0 {
+		return -1, 0
+	}
+	_, w := utf8.DecodeRune(data[i:])
+	return i, w
+}
+
+// leftWord returns the letters (and ignored runes, per WB4) at the
+// end of data.
+func leftWord(data []byte) []byte {
+	i := len(data)
+	for i > 0 {
+		_, w := utf8.DecodeLastRune(data[:i])
+		lookup, _ := trie.lookup(data[i-w:])
+		if !lookup.is(_AHLetter | _Ignore) {
+			break
+		}
+		i -= w
+	}
+
+	// must contain a letter, not only ignored runes
+	if !previous(_AHLetter, data) {
+		return nil
+	}
+
+	return data[i:]
+}
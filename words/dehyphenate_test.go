@@ -0,0 +1,81 @@
+package words_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestDehyphenate(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected string
+	}
+
+	tests := []test{
+		{"seg-\nmentation", "segmentation"},
+		{"seg-\r\nmentation", "segmentation"},
+		{"seg‐\n   mentation", "segmentation"},
+		{"A well-\nknown seg-\nmentation", "A well-known segmentation"},
+		{"super-cool", "super-cool"},
+		{"3-\n4", "3-\n4"},
+		{"seg-\n4", "seg-\n4"},
+		{"seg-", "seg-"},
+		{"seg -\nmentation", "seg -\nmentation"},
+		{"-\nmentation", "-\nmentation"},
+		{"", ""},
+	}
+
+	exclude := func(left, right []byte) bool {
+		return string(left) == "well" && string(right) == "known"
+	}
+
+	for _, test := range tests {
+		got := words.Dehyphenate([]byte(test.input), exclude)
+		if string(got.Text) != test.expected {
+			t.Fatalf("expected %q, got %q", test.expected, got.Text)
+		}
+	}
+}
+
+func TestDehyphenateOriginal(t *testing.T) {
+	t.Parallel()
+
+	original := []byte("A well-\nknown seg-\n  mentation of com-\r\nplex words")
+	d := words.Dehyphenate(original, func(left, right []byte) bool {
+		return string(left) == "well"
+	})
+
+	expected := "A well-known segmentation of complex words"
+	if string(d.Text) != expected {
+		t.Fatalf("expected %q, got %q", expected, d.Text)
+	}
+
+	expectedOriginals := map[string]string{
+		"well":         "well",
+		"known":        "known",
+		"segmentation": "seg-\n  mentation",
+		"of":           "of",
+		"complex":      "com-\r\nplex",
+		"words":        "words",
+	}
+
+	seg := words.NewSegmenter(d.Text)
+	for seg.Next() {
+		expected, ok := expectedOriginals[seg.Text()]
+		if !ok {
+			continue
+		}
+
+		start := d.Original(seg.Start())
+		// End is exclusive, so map the last byte of the token
+		end := d.Original(seg.End()-1) + 1
+
+		got := string(original[start:end])
+		if got != expected {
+			t.Fatalf("token %q should map to original %q, got %q", seg.Bytes(), expected, got)
+		}
+	}
+}
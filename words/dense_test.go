@@ -0,0 +1,119 @@
+package words
+
+import (
+	"bytes"
+	"flag"
+	"go/format"
+	"os"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/uax29/gen/dense"
+)
+
+// This is the harness for the comparison of the triegen and dense layouts
+// of the generated tries, see gen/dense. The dense table, in
+// trie_dense_test.go, is generated from the values of the trie, so the two
+// hold identical data, and differ only in layout.
+
+var updateDense = flag.Bool("update-dense", false, "regenerate trie_dense_test.go from the values of the trie")
+
+// trieValues returns the non-zero values of the trie, by rune
+func trieValues() map[rune]uint64 {
+	values := map[rune]uint64{}
+	for r := rune(0); r <= utf8.MaxRune; r++ {
+		if !utf8.ValidRune(r) {
+			continue
+		}
+		if v, _ := trie.lookup([]byte(string(r))); v != 0 {
+			values[r] = uint64(v)
+		}
+	}
+	return values
+}
+
+func TestDenseTrie(t *testing.T) {
+	t.Parallel()
+
+	if *updateDense {
+		var buf bytes.Buffer
+		buf.WriteString("package words\n\n// generated by github.com/clipperhouse/uax29/gen/dense\n// from the values of wordsTrie, see dense_test.go\n")
+		if err := dense.Gen(&buf, "dense", trieValues()); err != nil {
+			t.Fatal(err)
+		}
+		formatted, err := format.Source(buf.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile("trie_dense_test.go", formatted, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		t.Skip("regenerated trie_dense_test.go; run again to compare")
+	}
+
+	dt := newDenseTrie(0)
+	compare := func(s []byte) {
+		v1, w1 := trie.lookup(s)
+		v2, w2 := dt.lookup(s)
+		if v1 != v2 || w1 != w2 {
+			t.Fatalf("%q: expected %d, %d from the trie, got %d, %d from the dense table; if the trie was regenerated, run with -update-dense", s, v1, w1, v2, w2)
+		}
+	}
+
+	for r := rune(0); r <= utf8.MaxRune; r++ {
+		if utf8.ValidRune(r) {
+			compare([]byte(string(r)))
+		}
+	}
+
+	// Invalid and incomplete UTF-8
+	file, err := os.ReadFile("../testdata/UTF-8-test.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range file {
+		compare(file[i:])
+		for j := i + 1; j <= i+utf8.UTFMax && j <= len(file); j++ {
+			compare(file[i:j])
+		}
+	}
+}
+
+// BenchmarkLookup compares the lookup of each layout alone, without the
+// rules of segmentation
+func BenchmarkLookup(b *testing.B) {
+	dt := newDenseTrie(0)
+
+	for _, name := range []string{"sample.txt", "UTF-8-test.txt"} {
+		file, err := os.ReadFile("../testdata/" + name)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(name+"/triegen", func(b *testing.B) {
+			b.SetBytes(int64(len(file)))
+			var sink property
+			for i := 0; i < b.N; i++ {
+				for pos := 0; pos < len(file); {
+					v, w := trie.lookup(file[pos:])
+					sink |= v
+					pos += w
+				}
+			}
+			_ = sink
+		})
+
+		b.Run(name+"/dense", func(b *testing.B) {
+			b.SetBytes(int64(len(file)))
+			var sink property
+			for i := 0; i < b.N; i++ {
+				for pos := 0; pos < len(file); {
+					v, w := dt.lookup(file[pos:])
+					sink |= v
+					pos += w
+				}
+			}
+			_ = sink
+		})
+	}
+}
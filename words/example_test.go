@@ -108,3 +108,44 @@ func ExampleJoiners() {
 	// foo@example.biz
 	// #winning
 }
+
+func ExampleNewBuilderTail() {
+	var b strings.Builder
+	tail := words.NewBuilderTail(&b)
+
+	b.WriteString("Hello, 世")
+	for tail.Next() {
+		fmt.Printf("%q\n", tail.Text())
+	}
+
+	// The last token might be extended by subsequent appends,
+	// so it's not returned until more is appended, or Flush is called
+	b.WriteString("界. Nice dog!")
+	tail.Flush()
+	for tail.Next() {
+		fmt.Printf("%q\n", tail.Text())
+	}
+	// Output: "Hello"
+	//","
+	//" "
+	//"世"
+	//"界"
+	//"."
+	//" "
+	//"Nice"
+	//" "
+	//"dog"
+	//"!"
+}
+
+func ExampleExplain() {
+	explanations := words.Explain("SKU AB-CD", nil)
+	for _, e := range explanations {
+		fmt.Printf("%q %s: %s\n", e.Token, e.Rule, e.Reason)
+	}
+	// Output: "SKU" WB999: no rule joins ALetter 'U' and WSegSpace ' '
+	// " " WB999: no rule joins WSegSpace ' ' and ALetter 'A'
+	// "AB" WB999: no rule joins ALetter 'B' and Other '-'; to join them, specify '-' as a Middle joiner
+	// "-" WB999: no rule joins Other '-' and ALetter 'C'; to join them, specify '-' as a Leading joiner
+	// "CD" WB2: end of text
+}
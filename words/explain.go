@@ -0,0 +1,186 @@
+package words
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Explanation is a token, and the reason for the boundary which follows it,
+// see [Explain].
+type Explanation struct {
+	Token      string
+	Start, End int
+
+	// Rule is the rule of the spec which broke after the token, such as "WB3a",
+	// or "Delimiters" for a delimiter specified by Joiners.
+	// See https://unicode.org/reports/tr29/#Word_Boundary_Rules
+	Rule string
+
+	// Reason is a human-readable description of the boundary, which may
+	// include a hint about how to join the tokens, if that is what you want.
+	Reason string
+}
+
+// Explain segments s, using joiners j (which may be nil, per the spec), and
+// returns each token along with the reason for the boundary after it. It is
+// intended for debugging and support, to answer questions like "why was this
+// product code split?" It is much slower than a Segmenter, and the reasons
+// are for humans; their wording may change.
+//
+// Boundaries are attributed after segmentation, by the properties of the
+// runes on either side, ignoring Extend, Format and ZWJ per WB4.
+func Explain(s string, j *Joiners) []Explanation {
+	data := []byte(s)
+
+	seg := NewSegmenter(data)
+	seg.Joiners(j)
+
+	var result []Explanation
+	for seg.Next() {
+		result = append(result, Explanation{
+			Token: seg.Text(),
+			Start: seg.Start(),
+			End:   seg.End(),
+		})
+	}
+
+	for i := range result {
+		e := &result[i]
+
+		if i == len(result)-1 {
+			e.Rule, e.Reason = "WB2", "end of text"
+			continue
+		}
+
+		left := lastExIgnore(data[e.Start:e.End], j)
+		lr, _ := utf8.DecodeLastRuneInString(e.Token)
+		next := result[i+1].Token
+		right, _ := trie.lookup([]byte(next))
+		rr, _ := utf8.DecodeRuneInString(next)
+		right = j.override(rr, right)
+
+		switch {
+		case j.delimits(lr) || j.delimits(rr):
+			e.Rule, e.Reason = "Delimiters", "break at a field delimiter, per Joiners"
+		case left.is(_Newline | _CR | _LF):
+			e.Rule, e.Reason = "WB3a", "break after a line break"
+		case right.is(_Newline | _CR | _LF):
+			e.Rule, e.Reason = "WB3b", "break before a line break"
+		default:
+			e.Rule = "WB999"
+			e.Reason = fmt.Sprintf("no rule joins %s %q and %s %q", propertyNames(left), lr, propertyNames(right), rr)
+
+			var after string
+			if i+2 < len(result) {
+				after = result[i+2].Token
+			}
+			if hint := joinHint(e.Token, next, after); hint != "" {
+				e.Reason += "; " + hint
+			}
+		}
+	}
+
+	return result
+}
+
+// joinHint suggests Joiners which would join a word across punctuation,
+// such as a hyphen, a leading # or a trailing +
+func joinHint(token, next, after string) string {
+	r, size := utf8.DecodeRuneInString(next)
+	if size == len(next) && (unicode.IsPunct(r) || unicode.IsSymbol(r)) {
+		// Middle joiners apply between letters, or between digits
+		left, right := lastRune(token), firstRune(after)
+		letters := unicode.IsLetter(left) && unicode.IsLetter(right)
+		digits := unicode.IsNumber(left) && unicode.IsNumber(right)
+		if letters || digits {
+			return fmt.Sprintf("to join them, specify %q as a Middle joiner", r)
+		}
+	}
+
+	// Trailing joiners apply at the end of a word, but sentence punctuation,
+	// quotes and brackets are not likely to be wanted there
+	if size == len(next) && (unicode.IsPunct(r) || unicode.IsSymbol(r)) && alnum(lastRune(token)) && !alnum(firstRune(after)) &&
+		!unicode.In(r, unicode.Terminal_Punctuation, unicode.Quotation_Mark, unicode.Ps, unicode.Pe, unicode.Pi, unicode.Pf) {
+		return fmt.Sprintf("to join them, specify %q as a Trailing joiner", r)
+	}
+
+	r, size = utf8.DecodeRuneInString(token)
+	if size == len(token) && (unicode.IsPunct(r) || unicode.IsSymbol(r)) && alnum(firstRune(next)) {
+		return fmt.Sprintf("to join them, specify %q as a Leading joiner", r)
+	}
+
+	return ""
+}
+
+func alnum(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
+
+// lastExIgnore returns the property of the last rune in token which is not
+// ignored per WB4, or of the first rune if all are ignored, with the
+// Overrides of j
+func lastExIgnore(token []byte, j *Joiners) property {
+	var result property
+	for pos := 0; pos < len(token); {
+		p, w := trie.lookup(token[pos:])
+		if w == 0 {
+			break
+		}
+		p = j.override(decodeRune(token[pos:], w), p)
+		if !p.is(_Ignore) || pos == 0 {
+			result = p
+		}
+		pos += w
+	}
+	return result
+}
+
+// names are the word break property names
+var names = map[property]string{
+	_ALetter:              "ALetter",
+	_CR:                   "CR",
+	_DoubleQuote:          "Double_Quote",
+	_Extend:               "Extend",
+	_ExtendNumLet:         "ExtendNumLet",
+	_ExtendedPictographic: "Extended_Pictographic",
+	_Format:               "Format",
+	_HebrewLetter:         "Hebrew_Letter",
+	_Katakana:             "Katakana",
+	_LF:                   "LF",
+	_MidLetter:            "MidLetter",
+	_MidNum:               "MidNum",
+	_MidNumLet:            "MidNumLet",
+	_Newline:              "Newline",
+	_Numeric:              "Numeric",
+	_RegionalIndicator:    "Regional_Indicator",
+	_SingleQuote:          "Single_Quote",
+	_WSegSpace:            "WSegSpace",
+	_ZWJ:                  "ZWJ",
+}
+
+// propertyNames returns the names of the word break properties of p, or
+// "Other" if none
+func propertyNames(p property) string {
+	var result []string
+	for bit := property(1); bit != 0; bit <<= 1 {
+		if name, ok := names[bit]; ok && p.is(bit) {
+			result = append(result, name)
+		}
+	}
+	if len(result) == 0 {
+		return "Other"
+	}
+	return strings.Join(result, "+")
+}
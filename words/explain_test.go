@@ -0,0 +1,93 @@
+package words_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	input := "SKU AB-CD\n#sale"
+	got := words.Explain(input, nil)
+
+	type expected struct {
+		token, rule, reason string
+	}
+
+	tests := []expected{
+		{"SKU", "WB999", `no rule joins ALetter 'U' and WSegSpace ' '`},
+		{" ", "WB999", `no rule joins WSegSpace ' ' and ALetter 'A'`},
+		{"AB", "WB999", `no rule joins ALetter 'B' and Other '-'; to join them, specify '-' as a Middle joiner`},
+		{"-", "WB999", `no rule joins Other '-' and ALetter 'C'; to join them, specify '-' as a Leading joiner`},
+		{"CD", "WB3b", "break before a line break"},
+		{"\n", "WB3a", "break after a line break"},
+		{"#", "WB999", `no rule joins Other '#' and ALetter 's'; to join them, specify '#' as a Leading joiner`},
+		{"sale", "WB2", "end of text"},
+	}
+
+	if len(got) != len(tests) {
+		t.Fatalf("expected %d explanations, got %d: %+v", len(tests), len(got), got)
+	}
+
+	var b strings.Builder
+	for i, test := range tests {
+		e := got[i]
+		if e.Token != test.token || e.Rule != test.rule || e.Reason != test.reason {
+			t.Errorf("expected %+v, got %+v", test, e)
+		}
+		if input[e.Start:e.End] != e.Token {
+			t.Errorf("expected Start and End to match the token")
+		}
+		b.WriteString(e.Token)
+	}
+
+	if b.String() != input {
+		t.Errorf("expected tokens to roundtrip")
+	}
+
+	// With joiners, the hints are taken
+	got = words.Explain(input, &words.Joiners{Middle: []rune("-"), Leading: []rune("#")})
+	if got[2].Token != "AB-CD" || got[len(got)-1].Token != "#sale" {
+		t.Errorf("expected joiners to apply, got %+v", got)
+	}
+}
+
+func TestExplainTrailing(t *testing.T) {
+	t.Parallel()
+
+	got := words.Explain("C++ rocks.", nil)
+
+	expected := `no rule joins ALetter 'C' and Other '+'; to join them, specify '+' as a Trailing joiner`
+	if got[0].Token != "C" || got[0].Reason != expected {
+		t.Errorf("expected a Trailing hint, got %+v", got[0])
+	}
+
+	// Sentence punctuation is not hinted
+	for _, e := range got {
+		if e.Token == "rocks" && strings.Contains(e.Reason, "Trailing") {
+			t.Errorf("expected no Trailing hint before a period, got %+v", e)
+		}
+	}
+
+	got = words.Explain("C++ rocks.", &words.Joiners{Trailing: []rune("+")})
+	if got[0].Token != "C++" {
+		t.Errorf("expected joiners to apply, got %+v", got)
+	}
+}
+
+func TestExplainDelimiters(t *testing.T) {
+	t.Parallel()
+
+	got := words.Explain("3\t5", words.TSVJoiners())
+	if len(got) != 3 {
+		t.Fatalf("expected 3 tokens, got %+v", got)
+	}
+	for _, e := range got[:2] {
+		if e.Rule != "Delimiters" {
+			t.Errorf("expected Delimiters rule, got %+v", e)
+		}
+	}
+}
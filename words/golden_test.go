@@ -0,0 +1,79 @@
+package words_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// TestGolden segments a corpus of informal text under each preset, and
+// compares the tokens with golden files, so that changes in behavior show
+// up as diffs. To accept changes, run go test -run Golden -update.
+func TestGolden(t *testing.T) {
+	t.Parallel()
+
+	input, err := os.ReadFile("../testdata/chat.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type test struct {
+		name  string
+		split func(seg *words.Segmenter)
+	}
+
+	tests := []test{
+		{"strict", func(seg *words.Segmenter) {}},
+		{"search", func(seg *words.Segmenter) { seg.Joiners(words.SearchJoiners()) }},
+		{"editorial", func(seg *words.Segmenter) { seg.Joiners(words.EditorialJoiners()) }},
+		{"chat", func(seg *words.Segmenter) { seg.Joiners(words.ChatJoiners()) }},
+		{"chat-combiners", func(seg *words.Segmenter) {
+			seg.Split(words.Combine(words.ChatJoiners().SplitFunc(), words.URLs, words.Emails, words.Hashtags, words.Mentions))
+		}},
+	}
+
+	for _, test := range tests {
+		seg := words.NewSegmenter(input)
+		test.split(seg)
+		seg.Filter(filter.Wordlike)
+
+		var got bytes.Buffer
+		for seg.Next() {
+			got.WriteString(strconv.Quote(seg.Text()))
+			got.WriteByte('\n')
+		}
+
+		golden(t, "words-"+test.name+".txt", got.Bytes())
+	}
+}
+
+// golden compares got with the golden file name, or updates it if -update is set
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("..", "testdata", "golden", name)
+
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("%s: tokens differ from the golden file; if the change is intended, run go test -run Golden -update", name)
+	}
+}
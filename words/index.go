@@ -0,0 +1,11 @@
+package words
+
+import "github.com/clipperhouse/uax29/iterators"
+
+// NewIndex segments data into words once, and returns an Index of the
+// boundaries, for repeated queries on the same text, such as the word at a
+// byte offset (At), the n-th word (Nth), or the Count. Queries are
+// O(log n). See [iterators.Index].
+func NewIndex(data []byte) *iterators.Index {
+	return iterators.NewIndex(data, SplitFunc)
+}
@@ -0,0 +1,15 @@
+package words
+
+import "github.com/clipperhouse/uax29/invisible"
+
+// Invisibles returns the kinds of invisible characters in the current token,
+// such as zero-width joiners or bidi controls, see [invisible.Of].
+func (seg *Segmenter) Invisibles() invisible.Flags {
+	return invisible.Of(seg.Bytes())
+}
+
+// Invisibles returns the kinds of invisible characters in the current token,
+// such as zero-width joiners or bidi controls, see [invisible.Of].
+func (sc *Scanner) Invisibles() invisible.Flags {
+	return invisible.Of(sc.Bytes())
+}
@@ -0,0 +1,27 @@
+package words_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/invisible"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestInvisibles(t *testing.T) {
+	t.Parallel()
+
+	// Bidi controls are Format, and so are part of the preceding word, per WB4
+	input := "if access\u202E\u2066 {"
+	expected := []invisible.Flags{invisible.None, invisible.None, invisible.Bidi, invisible.None, invisible.None}
+
+	var got []invisible.Flags
+	seg := words.NewSegmenter([]byte(input))
+	for seg.Next() {
+		got = append(got, seg.Invisibles())
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -28,10 +28,149 @@ type Joiners struct {
 	// For example, specifying "#" will join #hashtags.
 	// Specifying "." will preserve leading decimals like .01.
 	Leading []rune
+
+	// Trailing specifies which characters (runes) should
+	// join words (tokens) where they would otherwise be split,
+	// at the end of a word.
+	//
+	// For example, specifying "+#" will preserve C++, F# and A+. A run of
+	// trailing joiners joins a word only at its end, i.e. when followed by
+	// something other than a letter or number, so "a+b" is still split.
+	Trailing []rune
+
+	// Apostrophes specifies which characters (runes) should be treated
+	// like an apostrophe (Single_Quote in the spec). Apostrophes join letters
+	// in the middle of a word, such as "O`Brien".
+	//
+	// Note that ' and ’ are already treated as apostrophes. See
+	// [ApostropheVariants] for a set of common alternatives.
+	Apostrophes []rune
+
+	// SoftHyphens specifies that a soft hyphen (U+00AD) followed by a line
+	// break should join a word across the line break, as in "seg\u00AD\nmentation".
+	// The token will include the soft hyphen and the line break.
+	SoftHyphens bool
+
+	// Digits, if not nil, is called when a run of digits is followed by a
+	// single space and another run of digits, as in "1 000 000", which is
+	// common in OCR output and some number formats. Left is the token so far,
+	// and right is the subsequent digits. Return true to join them.
+	Digits func(left, right []byte) bool
+
+	// NoBreakHyphens specifies that a no-break hyphen (U+2011) should be treated
+	// like a letter, so that it joins words as its name suggests, e.g. "super‑cool".
+	NoBreakHyphens bool
+
+	// Dashes specifies the treatment of figure dash (U+2012) and en dash
+	// (U+2013). The default is [DashBreak], per the spec.
+	Dashes Dash
+
+	// Lookahead, if greater than zero, bounds the distance (in bytes) that
+	// rules such as WB6 and WB12 will look ahead, past ignored characters
+	// (Extend, Format and ZWJ), to decide whether to join. This caps the
+	// worst-case work per token on hostile input, and the amount a Scanner
+	// will buffer to decide. It is a deviation from the spec: a token
+	// such as "a." followed by more than Lookahead bytes of combining marks
+	// and then a letter will be split, where the spec would join it. Zero,
+	// the default, is unbounded, per the spec.
+	Lookahead int
+
+	// Delimiters specifies field delimiters, such as tab in TSV or comma in
+	// CSV, which always break words, and are tokens of their own. They are
+	// never joined, by other Joiners or by the spec; for example, "3,5" is
+	// ordinarily a single number, but with "," as a delimiter, it is three
+	// tokens. See [TSVJoiners].
+	Delimiters []rune
+
+	// Overrides replaces the word break property of the spec for particular
+	// runes, without regenerating data. For example, the spec treats a colon
+	// as MidLetter, for Swedish abbreviations such as "k:a", which are one
+	// word. For other languages, you might prefer to split "key:value":
+	//
+	//	j := &words.Joiners{
+	//		Overrides: map[rune]words.Property{':': words.Other},
+	//	}
+	//
+	// Overrides apply before the other Joiners, so a rune which is overridden
+	// may also be, say, a Middle joiner. A property of Other removes the
+	// property of the spec.
+	Overrides map[rune]Property
+}
+
+// Dash is a policy for the treatment of figure dash (U+2012) and en dash
+// (U+2013), see [Joiners].
+type Dash int
+
+const (
+	// DashBreak splits words on dashes, per the spec. It is the default.
+	DashBreak Dash = iota
+	// DashAsHyphen treats dashes like a hyphen-minus (-): they will join words
+	// only if "-" is specified as a Middle joiner.
+	DashAsHyphen
+	// DashJoin joins words on dashes, as though they were specified as Middle joiners.
+	DashJoin
+)
+
+// ApostropheVariants are characters which are commonly used in place of an
+// apostrophe, for example in OCR output or transliterations: ʼ (modifier
+// letter apostrophe), ʻ (modifier letter turned comma, as in Hawaiʻi),
+// ` (grave accent) and ´ (acute accent). For use with [Joiners].
+var ApostropheVariants = []rune("ʼʻ`´")
+
+// HyphenVariants are characters which are commonly used in place of a hyphen,
+// for example in OCR output: ‐ (hyphen), ‒ (figure dash), – (en dash) and
+// − (minus sign). To join hyphenated words, specify these along with "-" as
+// Middle [Joiners].
+var HyphenVariants = []rune("‐‒–−")
+
+// OCRJoiners returns Joiners tailored to text from optical character
+// recognition (OCR), which treat [ApostropheVariants] as apostrophes, and
+// join words broken by a soft hyphen and line break. You may wish to modify
+// the result, for example to set Digits, or to add [HyphenVariants].
+func OCRJoiners() *Joiners {
+	return &Joiners{
+		Apostrophes: append([]rune(nil), ApostropheVariants...),
+		SoftHyphens: true,
+	}
 }
 
 var none *Joiners = nil
 
+// delimits determines if r is one of the Delimiters
+func (j *Joiners) delimits(r rune) bool {
+	return j != nil && runesContain(j.Delimiters, r)
+}
+
+// hasMiddle determines if any joiners might apply in the middle of a word
+func (j *Joiners) hasMiddle() bool {
+	return j.Middle != nil || j.Apostrophes != nil || j.NoBreakHyphens || j.Dashes != DashBreak || j.Overrides != nil
+}
+
+// middle returns the properties which joiners add to r, in the middle of a word
+func (j *Joiners) middle(r rune) property {
+	var result property
+
+	if runesContain(j.Middle, r) {
+		result |= _MidNumLet
+	}
+	if runesContain(j.Apostrophes, r) {
+		result |= _SingleQuote
+	}
+
+	switch r {
+	case '\u2011':
+		if j.NoBreakHyphens {
+			result |= _ALetter
+		}
+	case '\u2012', '\u2013':
+		if j.Dashes == DashJoin || (j.Dashes == DashAsHyphen && runesContain(j.Middle, '-')) {
+			result |= _MidNumLet
+		}
+	}
+
+	return result
+}
+
 func runesContain(runes []rune, rune rune) bool {
 	// Did some bechmarking, a map isn't faster for small numbers
 	for _, r := range runes {
@@ -41,3 +180,12 @@ func runesContain(runes []rune, rune rune) bool {
 	}
 	return false
 }
+
+// lookahead bounds data to the Lookahead distance, if any. Bounded data is
+// treated as final, so that a lookahead will not request more data.
+func (j *Joiners) lookahead(data []byte, atEOF bool) ([]byte, bool) {
+	if j == nil || j.Lookahead <= 0 || len(data) <= j.Lookahead {
+		return data, atEOF
+	}
+	return data[:j.Lookahead], true
+}
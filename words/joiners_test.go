@@ -1,6 +1,17 @@
 package words_test
 
-import "github.com/clipperhouse/uax29/words"
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/clipperhouse/uax29/internal/segtest"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
 
 var joinersInput = []byte("Hello, 世界. Tell me about your super-cool .com. I'm .01% interested and 3/4 of a mile away. Email me at foo@example.biz. #winning")
 var joiners = &words.Joiners{
@@ -37,3 +48,390 @@ var joinersTests = []joinersTest{
 	{"winning", true, false},
 	{"#winning", false, true},
 }
+
+func TestJoinersApostrophes(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("O`Brien and O´Brien visited Hawaiʻi, and 'twas grand. 'Quoted'.")
+
+	seg1 := words.NewSegmenter(text)
+	founds1 := segToSet(seg1)
+
+	seg2 := words.NewSegmenter(text)
+	seg2.Joiners(&words.Joiners{
+		Apostrophes: words.ApostropheVariants,
+	})
+	founds2 := segToSet(seg2)
+
+	tests := []joinersTest{
+		{"O", true, false},
+		{"Brien", true, false},
+		{"O`Brien", false, true},
+		{"O´Brien", false, true},
+		{"Hawaiʻi", true, true}, // ʻ is already a letter
+		{"twas", true, true},    // leading apostrophes are unaffected
+		{"Quoted", true, true},  // as are trailing
+	}
+
+	for _, test := range tests {
+		_, found1 := founds1[test.input]
+		if found1 != test.found1 {
+			t.Fatalf("For %q, expected %t for found in non-config segmenter, but got %t", test.input, test.found1, found1)
+		}
+		_, found2 := founds2[test.input]
+		if found2 != test.found2 {
+			t.Fatalf("For %q, expected %t for found in segmenter with apostrophes, but got %t", test.input, test.found2, found2)
+		}
+	}
+}
+
+// TestJoinersDigitsMultibyte ensures that the Scanner requests more data,
+// rather than breaking, when a multi-byte digit spans reads
+func TestJoinersDigitsMultibyte(t *testing.T) {
+	t.Parallel()
+
+	joiners := &words.Joiners{Digits: func(left, right []byte) bool { return true }}
+
+	input := []byte("1 ١٢٣ apples")
+	expected := []string{"1 ١٢٣", " ", "apples"}
+	segtest.AssertSegments(t, joiners.SplitFunc(), nil, input, expected)
+}
+
+func TestJoinersOCR(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("The seg\u00AD\nmentation of O`Brien\u00AD\r\nesque text costs 1 000 000 dollars, or 2 apples.\u00AD\nNext")
+
+	joiners := words.OCRJoiners()
+	joiners.Digits = func(left, right []byte) bool {
+		// join thousands separated by spaces
+		return len(right) == 3
+	}
+
+	seg := words.NewSegmenter(text)
+	seg.Joiners(joiners)
+	founds := segToSet(seg)
+
+	tests := []struct {
+		input string
+		found bool
+	}{
+		{"seg\u00AD\nmentation", true},
+		{"O`Brien\u00AD\r\nesque", true},
+		{"1 000 000", true},
+		{"2", true},
+		{"apples", true},
+		{"Next", true}, // soft hyphen follows punctuation, so no join
+		{"seg", false},
+		{"mentation", false},
+	}
+
+	for _, test := range tests {
+		_, found := founds[test.input]
+		if found != test.found {
+			t.Fatalf("For %q, expected %t for found in segmenter with OCR joiners, but got %t", test.input, test.found, found)
+		}
+	}
+
+	// The scanner, which requests more data mid-token, should agree with the segmenter
+	sc := words.NewScanner(iotest.OneByteReader(bytes.NewReader(text)))
+	sc.Joiners(joiners)
+	seg.SetText(text)
+
+	for seg.Next() {
+		if !sc.Scan() {
+			t.Fatal("scanner returned fewer tokens than segmenter")
+		}
+		if !bytes.Equal(seg.Bytes(), sc.Bytes()) {
+			t.Fatalf("scanner and segmenter should give identical results, expected %q, got %q", seg.Bytes(), sc.Bytes())
+		}
+	}
+	if sc.Scan() {
+		t.Fatal("scanner returned more tokens than segmenter")
+	}
+}
+
+func TestJoinersHyphensAndDashes(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("A super‑cool, well-known 1‒2 or 3–4 range")
+
+	type test struct {
+		joiners  *words.Joiners
+		expected []string
+	}
+
+	tests := []test{
+		{
+			nil,
+			[]string{"A", "super", "cool", "well", "known", "1", "2", "or", "3", "4", "range"},
+		},
+		{
+			&words.Joiners{NoBreakHyphens: true},
+			[]string{"A", "super‑cool", "well", "known", "1", "2", "or", "3", "4", "range"},
+		},
+		{
+			// the ASCII equivalent
+			&words.Joiners{Middle: []rune("-")},
+			[]string{"A", "super", "cool", "well-known", "1", "2", "or", "3", "4", "range"},
+		},
+		{
+			&words.Joiners{Middle: []rune("-"), Dashes: words.DashAsHyphen},
+			[]string{"A", "super", "cool", "well-known", "1‒2", "or", "3–4", "range"},
+		},
+		{
+			&words.Joiners{Dashes: words.DashAsHyphen},
+			[]string{"A", "super", "cool", "well", "known", "1", "2", "or", "3", "4", "range"},
+		},
+		{
+			&words.Joiners{Dashes: words.DashJoin, NoBreakHyphens: true},
+			[]string{"A", "super‑cool", "well", "known", "1‒2", "or", "3–4", "range"},
+		},
+	}
+
+	for _, test := range tests {
+		seg := words.NewSegmenter(text)
+		if test.joiners != nil {
+			seg.Joiners(test.joiners)
+		}
+		seg.Filter(filter.AlphaNumeric)
+
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Fatalf("for joiners %+v, expected %q, got %q", test.joiners, test.expected, got)
+		}
+	}
+}
+
+func TestJoinersLookahead(t *testing.T) {
+	t.Parallel()
+
+	// WB6 looks ahead past combining marks for a letter
+	input := []byte("a." + strings.Repeat("\u0301", 10) + "b c")
+
+	type test struct {
+		lookahead int
+		expected  []string
+	}
+
+	tests := []test{
+		{0, []string{"a." + strings.Repeat("\u0301", 10) + "b", " ", "c"}},
+		{100, []string{"a." + strings.Repeat("\u0301", 10) + "b", " ", "c"}},
+		{8, []string{"a", "." + strings.Repeat("\u0301", 10), "b", " ", "c"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("lookahead %d", test.lookahead), func(t *testing.T) {
+			j := &words.Joiners{Lookahead: test.lookahead}
+			segtest.AssertSegments(t, j.SplitFunc(), nil, input, test.expected)
+		})
+	}
+}
+
+func TestJoinersDelimiters(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		joiners  *words.Joiners
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{
+			// the spec joins numbers across a comma, per WB11 and WB12
+			nil,
+			"3,5\tfoo,bar",
+			[]string{"3,5", "\t", "foo", ",", "bar"},
+		},
+		{
+			words.TSVJoiners(),
+			"3\t5\tfoo bar\t\t",
+			[]string{"3", "\t", "5", "\t", "foo", " ", "bar", "\t", "\t"},
+		},
+		{
+			&words.Joiners{Delimiters: []rune(",")},
+			"3,5,foo,bar",
+			[]string{"3", ",", "5", ",", "foo", ",", "bar"},
+		},
+		{
+			// delimiters take precedence over other joiners
+			&words.Joiners{Delimiters: []rune(",;"), Middle: []rune(",;"), Leading: []rune(",")},
+			",a;b,1,2",
+			[]string{",", "a", ";", "b", ",", "1", ",", "2"},
+		},
+		{
+			// combining marks do not attach to a delimiter
+			&words.Joiners{Delimiters: []rune(",")},
+			"a,\u0301b",
+			[]string{"a", ",", "\u0301", "b"},
+		},
+		{
+			&words.Joiners{Delimiters: []rune("\t"), Digits: func(left, right []byte) bool { return true }},
+			"1 000\t2 000",
+			[]string{"1 000", "\t", "2 000"},
+		},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, test.joiners.SplitFunc(), nil, []byte(test.input), test.expected)
+	}
+}
+
+func TestJoinersTrailing(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		joiners  *words.Joiners
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{
+			nil,
+			"C++ and F#, A+.",
+			[]string{"C", "+", "+", " ", "and", " ", "F", "#", ",", " ", "A", "+", "."},
+		},
+		{
+			&words.Joiners{Trailing: []rune("+#")},
+			"C++ and F#, A+.",
+			[]string{"C++", " ", "and", " ", "F#", ",", " ", "A+", "."},
+		},
+		{
+			// only at the end of a word
+			&words.Joiners{Trailing: []rune("+")},
+			"a+b 1+2 C++x",
+			[]string{"a", "+", "b", " ", "1", "+", "2", " ", "C", "+", "+", "x"},
+		},
+		{
+			// only after a letter or number
+			&words.Joiners{Trailing: []rune("+")},
+			"+ ++ x+",
+			[]string{"+", " ", "+", "+", " ", "x+"},
+		},
+		{
+			// combining marks are ignored, per WB4
+			&words.Joiners{Trailing: []rune("+")},
+			"C+\u0301+ ok",
+			[]string{"C+\u0301+", " ", "ok"},
+		},
+		{
+			// with leading joiners
+			&words.Joiners{Leading: []rune("#"), Trailing: []rune("#")},
+			"#C# 10%",
+			[]string{"#C#", " ", "10", "%"},
+		},
+		{
+			&words.Joiners{Trailing: []rune("%")},
+			"10% of 5%.",
+			[]string{"10%", " ", "of", " ", "5%", "."},
+		},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, test.joiners.SplitFunc(), nil, []byte(test.input), test.expected)
+	}
+}
+
+func TestJoinersOverrides(t *testing.T) {
+	t.Parallel()
+
+	colon := &words.Joiners{
+		Overrides: map[rune]words.Property{':': words.Other},
+	}
+
+	type test struct {
+		joiners  *words.Joiners
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{
+			// The spec treats a colon as MidLetter, as in Swedish
+			nil,
+			"Köp k:a key:value",
+			[]string{"Köp", " ", "k:a", " ", "key:value"},
+		},
+		{
+			colon,
+			"Köp k:a key:value",
+			[]string{"Köp", " ", "k", ":", "a", " ", "key", ":", "value"},
+		},
+		{
+			// MidNum joins numbers (WB11, WB12), but not letters
+			&words.Joiners{Overrides: map[rune]words.Property{':': words.MidNum}},
+			"10:30 k:a",
+			[]string{"10:30", " ", "k", ":", "a"},
+		},
+		{
+			// Other removes the property of the spec
+			&words.Joiners{Overrides: map[rune]words.Property{'\'': words.Other}},
+			"it's",
+			[]string{"it", "'", "s"},
+		},
+		{
+			// Overrides apply to lookahead (WB6), and at the start of a token
+			&words.Joiners{Overrides: map[rune]words.Property{'@': words.MidLetter, '%': words.ALetter}},
+			"a@% %a",
+			[]string{"a@%", " ", "%a"},
+		},
+		{
+			// with other joiners
+			&words.Joiners{Overrides: map[rune]words.Property{':': words.Other}, Middle: []rune("-")},
+			"k:a-b",
+			[]string{"k", ":", "a-b"},
+		},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, test.joiners.SplitFunc(), nil, []byte(test.input), test.expected)
+	}
+
+	if got := words.MidLetter.String(); got != "MidLetter" {
+		t.Errorf("expected MidLetter, got %s", got)
+	}
+}
+
+func TestJoinersWidths(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		joiners  *words.Joiners
+		input    string
+		expected []string
+	}
+
+	// Joiners decode runes of each UTF-8 width
+	tests := []test{
+		{&words.Joiners{Middle: []rune("~")}, "a~b", []string{"a~b"}},
+		{&words.Joiners{Middle: []rune("·")}, "a·b", []string{"a·b"}},
+		{&words.Joiners{Middle: []rune("→")}, "a→b", []string{"a→b"}},
+		{&words.Joiners{Middle: []rune("\U0001F517")}, "a\U0001F517b", []string{"a\U0001F517b"}},
+		{&words.Joiners{Leading: []rune("§")}, "§a", []string{"§a"}},
+		{&words.Joiners{Leading: []rune("\U0001F517")}, "\U0001F517a", []string{"\U0001F517a"}},
+		{&words.Joiners{Delimiters: []rune("‖")}, "a‖b", []string{"a", "‖", "b"}},
+
+		// A lone continuation byte is not the rune which it might complete
+		{&words.Joiners{Middle: []rune("·")}, "a\xB7b", []string{"a", "\xB7", "b"}},
+	}
+
+	for _, test := range tests {
+		seg := words.NewSegmenter([]byte(test.input))
+		seg.Joiners(test.joiners)
+
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Fatalf("%q: expected %q, got %q", test.input, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package words
+
+import "github.com/clipperhouse/uax29/emoji"
+
+// Kind flags a token which is a malformed emoji sequence, see [KindOf]. It
+// is an alias of [emoji.Kind], which is shared with other packages.
+// This API is experimental.
+type Kind = emoji.Kind
+
+const (
+	// Normal indicates that the token is not a malformed sequence.
+	Normal = emoji.Normal
+	// MalformedFlag indicates a lone regional indicator, i.e. half of a flag.
+	MalformedFlag = emoji.MalformedFlag
+	// MalformedKeycap indicates a combining enclosing keycap (U+20E3) which
+	// does not follow a keycap base (0-9, # or *).
+	MalformedKeycap = emoji.MalformedKeycap
+)
+
+// KindOf determines if a token is a malformed flag or keycap sequence, see
+// [emoji.KindOf]. This API is experimental.
+func KindOf(token []byte) Kind {
+	return emoji.KindOf(token)
+}
+
+// Kind returns the [Kind] of the current token, see [KindOf].
+func (seg *Segmenter) Kind() Kind {
+	return KindOf(seg.Bytes())
+}
+
+// Kind returns the [Kind] of the current token, see [KindOf].
+func (sc *Scanner) Kind() Kind {
+	return KindOf(sc.Bytes())
+}
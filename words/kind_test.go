@@ -0,0 +1,56 @@
+package words_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestKind(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []words.Kind
+	}
+
+	tests := []test{
+		{"hello", []words.Kind{words.Normal}},
+		{"🇺🇸", []words.Kind{words.Normal}},
+		{"🇺", []words.Kind{words.MalformedFlag}},
+		{"🇺🇸🇺", []words.Kind{words.Normal, words.MalformedFlag}},
+		{"1️⃣", []words.Kind{words.Normal}},
+		{"#⃣", []words.Kind{words.Normal}},
+		{"a⃣", []words.Kind{words.MalformedKeycap}},
+		{"⃣", []words.Kind{words.MalformedKeycap}},
+		{"x️⃣", []words.Kind{words.MalformedKeycap}},
+		{"️⃣", []words.Kind{words.MalformedKeycap}},
+	}
+
+	for _, test := range tests {
+		seg := words.NewSegmenter([]byte(test.input))
+		var got []words.Kind
+		for seg.Next() {
+			got = append(got, seg.Kind())
+		}
+
+		if len(got) != len(test.expected) {
+			t.Fatalf("%q: expected %d tokens, got %d", test.input, len(test.expected), len(got))
+		}
+		for i := range got {
+			if got[i] != test.expected[i] {
+				t.Errorf("%q: token %d expected kind %d, got %d", test.input, i, test.expected[i], got[i])
+			}
+		}
+
+		sc := words.NewScanner(bytes.NewReader([]byte(test.input)))
+		i := 0
+		for sc.Scan() {
+			if sc.Kind() != test.expected[i] {
+				t.Errorf("%q: scanner token %d expected kind %d, got %d", test.input, i, test.expected[i], sc.Kind())
+			}
+			i++
+		}
+	}
+}
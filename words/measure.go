@@ -0,0 +1,217 @@
+package words
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Measurements combines a number and a unit of measurement, such as
+// "10 kg", "3.5 mm", "100 km/h" or "20 °C", where the spec would split them
+// at the space or slash. Units are recognized case-insensitively from a
+// list of common units of length, mass, volume, time, speed, temperature,
+// data, frequency and power, optionally with ² or ³, as in "m²". Units which
+// are also common words, such as "in", are omitted. See [Units] to specify
+// your own, and [SplitMeasurement] to separate the value and the unit.
+var Measurements Combiner = Units(
+	"mm", "cm", "dm", "m", "km", "µm", "μm", "nm", "ft", "yd", "mi",
+	"mg", "g", "kg", "t", "lb", "lbs", "oz",
+	"ml", "cl", "dl", "l", "gal", "qt", "pt",
+	"ms", "s", "sec", "min", "h", "hr", "hrs",
+	"mph", "kph", "rpm",
+	"°", "°c", "°f",
+	"b", "kb", "mb", "gb", "tb", "kib", "mib", "gib", "tib", "bps", "kbps", "mbps", "gbps",
+	"hz", "khz", "mhz", "ghz",
+	"w", "kw", "mw", "wh", "kwh", "v", "mah", "cal", "kcal",
+	"%", "‰",
+)
+
+// Units returns a Combiner which, like [Measurements], combines a number
+// and one of the given units, such as "10 kg". Units are matched
+// case-insensitively. A compound unit, such as "km/h", is recognized if
+// each of its parts is a unit.
+func Units(units ...string) Combiner {
+	u := make(unitList, len(units))
+	for i, unit := range units {
+		u[i] = []byte(unit)
+	}
+	return u
+}
+
+type unitList [][]byte
+
+func (u unitList) Combine(data []byte, atEOF bool) (int, bool) {
+	if !isDigit(data[0]) {
+		return 0, false
+	}
+
+	i, more := number(data)
+	if more && !atEOF {
+		return 0, true
+	}
+
+	s, more := space(data[i:])
+	if more && !atEOF {
+		return 0, true
+	}
+	i += s
+
+	n, more := u.unit(data[i:], atEOF)
+	if more {
+		return 0, true
+	}
+	if n == 0 {
+		return 0, false
+	}
+	i += n
+
+	// A compound unit, such as km/h
+	if i < len(data) && data[i] == '/' {
+		n, more := u.unit(data[i+1:], atEOF)
+		if more {
+			return 0, true
+		}
+		if n > 0 {
+			i += 1 + n
+		}
+	}
+
+	// The unit must end at a word boundary, so "10 kgx" and "10 t-shirts"
+	// are not measurements
+	cont, more := continues(data[i:], atEOF)
+	if more {
+		return 0, true
+	}
+	if cont {
+		return 0, false
+	}
+
+	return i, false
+}
+
+// continues determines if data begins with a letter or number, or a hyphen
+// or apostrophe followed by a letter, i.e. the word continues
+func continues(data []byte, atEOF bool) (cont, more bool) {
+	for i := 0; ; {
+		if i == len(data) || !utf8.FullRune(data[i:]) {
+			return false, !atEOF
+		}
+		r, w := utf8.DecodeRune(data[i:])
+		if unicode.IsLetter(r) || (i == 0 && unicode.IsNumber(r)) {
+			return true, false
+		}
+		if i > 0 || (r != '-' && r != '\'' && r != '\u2019') {
+			return false, false
+		}
+		i += w
+	}
+}
+
+// unit returns the length of the unit at the start of data, if it is one of u
+func (u unitList) unit(data []byte, atEOF bool) (n int, more bool) {
+	end, letters := unitLength(data)
+	if !atEOF && (end == len(data) || !utf8.FullRune(data[end:])) {
+		return 0, true
+	}
+	if letters == 0 {
+		return 0, false
+	}
+
+	for _, unit := range u {
+		if bytes.EqualFold(data[:letters], unit) {
+			return end, false
+		}
+	}
+	return 0, false
+}
+
+// unitLength returns the length of the unit-like text at the start of data,
+// i.e. % or ‰, or an optional ° followed by letters and then an optional
+// ² or ³, and the length excluding the ² or ³.
+func unitLength(data []byte) (n, letters int) {
+	r, w := utf8.DecodeRune(data)
+	if r == '%' || r == '‰' {
+		return w, w
+	}
+
+	i := 0
+	if r == '°' {
+		i += w
+	}
+	for i < len(data) {
+		r, w := utf8.DecodeRune(data[i:])
+		if !unicode.IsLetter(r) {
+			break
+		}
+		i += w
+	}
+	letters = i
+
+	if r, w := utf8.DecodeRune(data[i:]); r == '²' || r == '³' {
+		i += w
+	}
+	return i, letters
+}
+
+// number returns the length of the number at the start of data, such as
+// "10", "3.5" or "1,000", and whether it may extend past data
+func number(data []byte) (n int, more bool) {
+	i := 0
+	for {
+		for i < len(data) && isDigit(data[i]) {
+			i++
+		}
+		if i == len(data) {
+			return i, true
+		}
+		if data[i] != '.' && data[i] != ',' {
+			return i, false
+		}
+		if i+1 == len(data) {
+			return i, true
+		}
+		if !isDigit(data[i+1]) {
+			return i, false
+		}
+		i++
+	}
+}
+
+// space returns the length of a space, or a non-breaking space, at the start
+// of data, and whether it may extend past data
+func space(data []byte) (n int, more bool) {
+	if len(data) == 0 {
+		return 0, true
+	}
+	if data[0] == ' ' {
+		return 1, false
+	}
+	if !utf8.FullRune(data) {
+		return 0, true
+	}
+	switch r, w := utf8.DecodeRune(data); r {
+	case '\u00a0', '\u202f': // no-break space, narrow no-break space
+		return w, false
+	}
+	return 0, false
+}
+
+// SplitMeasurement separates a token such as "10kg", "3.5 mm" or "100 km/h",
+// as returned by a Segmenter with [Measurements], into its value and unit.
+// It does not check that the unit is one of Measurements, only that the
+// token is a number, followed by an optional space, and then a unit, which
+// begins with a letter, °, % or ‰.
+func SplitMeasurement(token []byte) (value, unit []byte, ok bool) {
+	n, _ := number(token)
+	if n == 0 {
+		return nil, nil, false
+	}
+
+	s, _ := space(token[n:])
+	unit = token[n+s:]
+
+	if _, letters := unitLength(unit); letters == 0 {
+		return nil, nil, false
+	}
+	return token[:n], unit, true
+}
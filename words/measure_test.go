@@ -0,0 +1,124 @@
+package words_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/internal/segtest"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestMeasurements(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	split := words.Combine(words.SplitFunc, words.Measurements)
+
+	tests := []test{
+		{"10kg of rice", []string{"10kg", "of", "rice"}},
+		{"10 kg of rice", []string{"10 kg", "of", "rice"}},
+		{"a 3.5 mm jack", []string{"a", "3.5 mm", "jack"}},
+		{"at 100 km/h.", []string{"at", "100 km/h"}},
+		{"1,000 MB free", []string{"1,000 MB", "free"}},
+		{"20 °C and 68°F", []string{"20 °C", "and", "68°F"}},
+		{"50 % off, 50% off", []string{"50 %", "off", "50%", "off"}},
+		{"12 m² room", []string{"12 m²", "room"}},
+		{"10\u00a0kg", []string{"10\u00a0kg"}},
+		{"10 km/day", []string{"10 km", "day"}},
+		{"3 in stock", []string{"3", "in", "stock"}},
+		{"10 people", []string{"10", "people"}},
+		{"10 t-shirts", []string{"10", "t", "shirts"}},
+		{"10 kgs", []string{"10", "kgs"}},
+		{"1. m", []string{"1", "m"}},
+		{"10", []string{"10"}},
+		{"10 ", []string{"10"}},
+		{"kg 10", []string{"kg", "10"}},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, split, filter.Wordlike, []byte(test.input), test.expected)
+	}
+}
+
+func TestUnits(t *testing.T) {
+	t.Parallel()
+
+	split := words.Combine(words.SplitFunc, words.Units("px", "em"))
+	input := []byte("10 px by 2 em, 3 kg")
+	expected := []string{"10 px", "by", "2 em", "3", "kg"}
+
+	seg := words.NewSegmenter(input)
+	seg.Split(split)
+	seg.Filter(filter.Wordlike)
+
+	var got []string
+	for seg.Next() {
+		got = append(got, seg.Text())
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("%q: expected %q, got %q", input, expected, got)
+	}
+}
+
+func TestSplitMeasurement(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input       string
+		value, unit string
+		ok          bool
+	}
+
+	tests := []test{
+		{"10kg", "10", "kg", true},
+		{"3.5 mm", "3.5", "mm", true},
+		{"100 km/h", "100", "km/h", true},
+		{"1,000\u00a0MB", "1,000", "MB", true},
+		{"20 °C", "20", "°C", true},
+		{"50%", "50", "%", true},
+		{"10", "", "", false},
+		{"10 ", "", "", false},
+		{"kg", "", "", false},
+		{"10.", "", "", false},
+		{"", "", "", false},
+	}
+
+	for _, test := range tests {
+		value, unit, ok := words.SplitMeasurement([]byte(test.input))
+		if ok != test.ok || string(value) != test.value || string(unit) != test.unit {
+			t.Errorf("%q: expected %q %q %t, got %q %q %t", test.input, test.value, test.unit, test.ok, value, unit, ok)
+		}
+	}
+}
+
+func TestMeasurementsRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	split := words.Combine(words.SplitFunc, words.Measurements)
+	seg := words.NewSegmenter(nil)
+	seg.Split(split)
+
+	for i := 0; i < 100; i++ {
+		input := getRandomBytes()
+		seg.SetText(input)
+
+		var output []byte
+		for seg.Next() {
+			output = append(output, seg.Bytes()...)
+		}
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(output, input) {
+			t.Fatal("input bytes are not the same as segmented bytes")
+		}
+	}
+}
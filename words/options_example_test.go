@@ -0,0 +1,168 @@
+package words_test
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+// Start and End are byte offsets into the original text, which is useful
+// for highlighting or annotating tokens in place.
+func ExampleSegmenter_Start() {
+	text := []byte("Hello, 世界.")
+
+	seg := words.NewSegmenter(text)
+	seg.Filter(filter.Wordlike)
+
+	for seg.Next() {
+		fmt.Printf("%q [%d:%d]\n", seg.Bytes(), seg.Start(), seg.End())
+	}
+	// Output: "Hello" [0:5]
+	// "世" [7:10]
+	// "界" [10:13]
+}
+
+// A Scanner reads from an io.Reader, such as a file or network stream, with
+// bounded memory. Options such as joiners and filters work the same as for
+// a Segmenter.
+func ExampleScanner_Joiners() {
+	r := strings.NewReader("Email foo@example.biz about the #launch, it's well-timed.")
+
+	sc := words.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64), bufio.MaxScanTokenSize)
+	sc.Joiners(&words.Joiners{
+		Middle:  []rune("@-"),
+		Leading: []rune("#"),
+	})
+	sc.Filter(filter.Wordlike)
+
+	for sc.Scan() {
+		fmt.Println(sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		log.Fatal(err)
+	}
+	// Output: Email
+	// foo@example.biz
+	// about
+	// the
+	// #launch
+	// it's
+	// well-timed
+}
+
+// Presets bundle joiners for a domain, and can be modified.
+func ExampleSearchJoiners() {
+	text := []byte("Email me@example.com re: state-of-the-art O`Brien #search")
+
+	seg := words.NewSegmenter(text)
+	seg.Joiners(words.SearchJoiners())
+	seg.Filter(filter.Wordlike)
+
+	for seg.Next() {
+		fmt.Println(seg.Text())
+	}
+	// Output: Email
+	// me@example.com
+	// re
+	// state-of-the-art
+	// O`Brien
+	// #search
+}
+
+// OCR output often has alternative apostrophes, and words broken across
+// lines by a soft hyphen.
+func ExampleOCRJoiners() {
+	text := []byte("O`Brien's seg\u00AD\nmentation")
+
+	seg := words.NewSegmenter(text)
+	seg.Joiners(words.OCRJoiners())
+	seg.Filter(filter.Wordlike)
+
+	for seg.Next() {
+		fmt.Printf("%q\n", seg.Text())
+	}
+	// Output: "O`Brien's"
+	// "seg\u00ad\nmentation"
+}
+
+// By default, an ANSI escape sequence would be split into punctuation and
+// letters. With ANSI, each sequence is a single token, which can be
+// filtered out.
+func ExampleSegmenter_ANSI() {
+	text := []byte("\x1b[1;31mError:\x1b[0m file not found")
+
+	seg := words.NewSegmenter(text)
+	seg.ANSI(&words.ANSI{})
+
+	for seg.Next() {
+		fmt.Printf("%q\n", seg.Text())
+	}
+	// Output: "\x1b[1;31m"
+	// "Error"
+	// ":"
+	// "\x1b[0m"
+	// " "
+	// "file"
+	// " "
+	// "not"
+	// " "
+	// "found"
+}
+
+// With Payloads, the text of an OSC or DCS sequence, such as a window
+// title, is segmented into words.
+func ExampleANSI_payloads() {
+	text := []byte("\x1b]0;My title\x07Hi")
+
+	seg := words.NewSegmenter(text)
+	seg.ANSI(&words.ANSI{Payloads: true})
+
+	for seg.Next() {
+		fmt.Printf("%q\n", seg.Text())
+	}
+	// Output: "\x1b]0;"
+	// "My"
+	// " "
+	// "title"
+	// "\a"
+	// "Hi"
+}
+
+func ExampleSegmenter_Kind() {
+	text := []byte("Flags 🇺🇸 and 🇺")
+
+	seg := words.NewSegmenter(text)
+	seg.Filter(filter.Wordlike)
+
+	for seg.Next() {
+		fmt.Printf("%q %s\n", seg.Text(), seg.Kind())
+	}
+	// Output: "Flags" Normal
+	// "🇺🇸" Normal
+	// "and" Normal
+	// "🇺" MalformedFlag
+}
+
+// Hash identifies a configuration of joiners, for example to record how an
+// index was tokenized.
+func ExampleJoiners_Hash() {
+	a := &words.Joiners{Middle: []rune("-@")}
+	b := &words.Joiners{Middle: []rune("@-")}
+
+	hashA, err := a.Hash()
+	if err != nil {
+		log.Fatal(err)
+	}
+	hashB, err := b.Hash()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(hashA == hashB)
+	// Output: true
+}
@@ -0,0 +1,115 @@
+package words
+
+import "bytes"
+
+// ordinalSuffixes are the suffixes recognized by IsOrdinal, in English,
+// French, and the Romance indicators, including superscript forms
+var ordinalSuffixes = [][]byte{
+	[]byte("st"), []byte("nd"), []byte("rd"), []byte("th"),
+	[]byte("er"), []byte("re"), []byte("e"), []byte("ère"), []byte("ème"), []byte("nde"),
+	[]byte("º"), []byte("ª"),
+	[]byte("ˢᵗ"), []byte("ⁿᵈ"), []byte("ʳᵈ"), []byte("ᵗʰ"), []byte("ᵉʳ"), []byte("ʳᵉ"), []byte("ᵉ"),
+}
+
+// IsOrdinal determines if a token is a number with an ordinal suffix, such as
+// "3rd", "21st", "1er", "2e", "2º" or "1ª". The spec keeps such a token whole,
+// as a digit followed by letters, so it is a single token from a Segmenter or
+// Scanner. Suffixes are not case sensitive, and they are not checked for
+// agreement with the number, so "1th" is an ordinal. This API is experimental.
+func IsOrdinal(token []byte) bool {
+	i := 0
+	for i < len(token) && isDigit(token[i]) {
+		i++
+		// Allow thousands separators, as in "1,000th"
+		if i+1 < len(token) && token[i] == ',' && isDigit(token[i+1]) {
+			i++
+		}
+	}
+	if i == 0 {
+		return false
+	}
+
+	suffix := token[i:]
+	for _, s := range ordinalSuffixes {
+		if bytes.EqualFold(suffix, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// IsRoman determines if a token is a well-formed Roman numeral, from I to
+// MMMCMXCIX (3999), in upper or lower case, such as "XIV" or "iv". Mixed
+// case, and malformed numerals such as "IIII" or "VX", are not.
+//
+// Note that many such tokens are also words, such as "I", "mix" or "did",
+// so callers will want context, such as a preceding "Chapter", or a heading.
+// This API is experimental.
+func IsRoman(token []byte) bool {
+	if len(token) == 0 {
+		return false
+	}
+
+	lower := token[0] >= 'a'
+	for _, b := range token {
+		if (b >= 'a') != lower {
+			return false
+		}
+	}
+
+	// The thousands, hundreds, tens and ones, in canonical form
+	pos := 0
+	pos += romanPlace(token[pos:], 'm', 0, 0)
+	pos += romanPlace(token[pos:], 'c', 'd', 'm')
+	pos += romanPlace(token[pos:], 'x', 'l', 'c')
+	pos += romanPlace(token[pos:], 'i', 'v', 'x')
+	return pos == len(token)
+}
+
+// romanPlace returns the length of the numeral for a single decimal place at
+// the start of data, given the (lowercase) letters for one, five and ten of
+// that place: one of "", one{1,3}, one five, five one{0,3}, or one ten
+func romanPlace(data []byte, one, five, ten byte) int {
+	at := func(i int, c byte) bool {
+		return c != 0 && i < len(data) && data[i]|0x20 == c
+	}
+
+	if at(0, one) && (at(1, five) || at(1, ten)) {
+		return 2
+	}
+
+	n := 0
+	if at(0, five) {
+		n++
+	}
+	for i := 0; i < 3 && at(n, one); i++ {
+		n++
+	}
+	return n
+}
+
+// Ordinal determines if the current token is a number with an ordinal
+// suffix, see [IsOrdinal].
+func (seg *Segmenter) Ordinal() bool {
+	return IsOrdinal(seg.Bytes())
+}
+
+// Ordinal determines if the current token is a number with an ordinal
+// suffix, see [IsOrdinal].
+func (sc *Scanner) Ordinal() bool {
+	return IsOrdinal(sc.Bytes())
+}
+
+// Roman determines if the current token is a Roman numeral, see [IsRoman].
+func (seg *Segmenter) Roman() bool {
+	return IsRoman(seg.Bytes())
+}
+
+// Roman determines if the current token is a Roman numeral, see [IsRoman].
+func (sc *Scanner) Roman() bool {
+	return IsRoman(sc.Bytes())
+}
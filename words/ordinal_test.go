@@ -0,0 +1,147 @@
+package words_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestIsOrdinal(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected bool
+	}
+
+	tests := []test{
+		{"3rd", true},
+		{"21st", true},
+		{"2nd", true},
+		{"11th", true},
+		{"3RD", true},
+		{"1,000th", true},
+		{"1er", true},
+		{"1re", true},
+		{"2e", true},
+		{"2ème", true},
+		{"2nde", true},
+		{"2º", true},
+		{"1ª", true},
+		{"3ʳᵈ", true},
+		{"1ᵉʳ", true},
+		{"", false},
+		{"3", false},
+		{"rd", false},
+		{"3rds", false},
+		{"3d", false},
+		{"1,th", false},
+		{",1st", false},
+		{"a1st", false},
+		{"1.5th", false},
+	}
+
+	for _, test := range tests {
+		got := words.IsOrdinal([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%q: expected %t, got %t", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestIsRoman(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected bool
+	}
+
+	tests := []test{
+		{"I", true},
+		{"IV", true},
+		{"IX", true},
+		{"XIV", true},
+		{"XL", true},
+		{"XC", true},
+		{"CD", true},
+		{"CM", true},
+		{"MCMXCIV", true},
+		{"MMMCMXCIX", true},
+		{"iv", true},
+		{"xiv", true},
+		{"", false},
+		{"Xiv", false},
+		{"IIII", false},
+		{"VV", false},
+		{"VX", false},
+		{"IL", false},
+		{"IC", false},
+		{"XM", false},
+		{"CMC", false},
+		{"IXI", false},
+		{"MMMM", false},
+		{"XIV.", false},
+		{"ABC", false},
+	}
+
+	for _, test := range tests {
+		got := words.IsRoman([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%q: expected %t, got %t", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestOrdinalAndRomanAreAtomic(t *testing.T) {
+	t.Parallel()
+
+	input := []byte("Chapter XIV, the 3rd, 1er and 2º ones.")
+
+	type token struct {
+		value          string
+		ordinal, roman bool
+	}
+
+	expected := []token{
+		{"XIV", false, true},
+		{"3rd", true, false},
+		{"1er", true, false},
+		{"2º", true, false},
+	}
+
+	var got []token
+	seg := words.NewSegmenter(input)
+	for seg.Next() {
+		if seg.Ordinal() || seg.Roman() {
+			got = append(got, token{seg.Text(), seg.Ordinal(), seg.Roman()})
+		}
+	}
+	if err := seg.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], got[i])
+		}
+	}
+
+	i := 0
+	sc := words.NewScanner(bytes.NewReader(input))
+	for sc.Scan() {
+		if sc.Ordinal() || sc.Roman() {
+			if i >= len(expected) || sc.Text() != expected[i].value {
+				t.Errorf("scanner: unexpected token %q", sc.Text())
+			}
+			i++
+		}
+	}
+	if i != len(expected) {
+		t.Errorf("scanner: expected %d tokens, got %d", len(expected), i)
+	}
+}
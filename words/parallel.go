@@ -0,0 +1,102 @@
+package words
+
+import (
+	"runtime"
+	"sync"
+)
+
+// minChunk is the smallest chunk which SegmentAllParallel and CountParallel
+// will segment on its own goroutine; smaller inputs are not worth the overhead
+const minChunk = 16 << 10
+
+// SegmentAllParallel is SegmentAll, using up to workers goroutines, for large
+// inputs. If workers is zero or less, runtime.GOMAXPROCS(0) is used. The data
+// is divided into chunks at positions which are known to be word boundaries,
+// such as after a line break, or between a space and a letter, so the result
+// is identical to SegmentAll. Tokens alias data.
+//
+// Data is assumed to be valid UTF-8, see [IsBoundary].
+func SegmentAllParallel(data []byte, workers int) [][]byte {
+	chunks := chunk(data, workers)
+	if len(chunks) == 1 {
+		return SegmentAll(data)
+	}
+
+	results := make([][][]byte, len(chunks))
+	parallel(chunks, func(i int, c []byte) {
+		results[i] = SegmentAll(c)
+	})
+
+	n := 0
+	for _, r := range results {
+		n += len(r)
+	}
+	all := make([][]byte, 0, n)
+	for _, r := range results {
+		all = append(all, r...)
+	}
+	return all
+}
+
+// CountParallel is Count, using up to workers goroutines, for large inputs.
+// If workers is zero or less, runtime.GOMAXPROCS(0) is used. See
+// [SegmentAllParallel].
+func CountParallel(data []byte, workers int) int {
+	chunks := chunk(data, workers)
+	if len(chunks) == 1 {
+		return Count(data)
+	}
+
+	counts := make([]int, len(chunks))
+	parallel(chunks, func(i int, c []byte) {
+		counts[i] = Count(c)
+	})
+
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	return n
+}
+
+// chunk divides data into up to workers chunks, of at least minChunk bytes,
+// at known boundaries
+func chunk(data []byte, workers int) [][]byte {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	size := (len(data) + workers - 1) / workers
+	if size < minChunk {
+		size = minChunk
+	}
+
+	var chunks [][]byte
+	for len(data) > 0 {
+		end := len(data)
+		if size < len(data) {
+			end = size
+			for end < len(data) && !knownBoundary(data, end) {
+				end++
+			}
+		}
+		chunks = append(chunks, data[:end])
+		data = data[end:]
+	}
+	if len(chunks) == 0 {
+		chunks = append(chunks, data)
+	}
+	return chunks
+}
+
+// parallel calls f for each chunk, on its own goroutine, and waits for them
+func parallel(chunks [][]byte, f func(i int, c []byte)) {
+	var wg sync.WaitGroup
+	wg.Add(len(chunks))
+	for i, c := range chunks {
+		go func(i int, c []byte) {
+			defer wg.Done()
+			f(i, c)
+		}(i, c)
+	}
+	wg.Wait()
+}
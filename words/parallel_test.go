@@ -0,0 +1,58 @@
+package words_test
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestSegmentAllParallel(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := [][]byte{
+		nil,
+		[]byte("Hello, world."),
+		file,
+		bytes.Repeat(file, 4),
+		// No spaces or line breaks, so there are no known boundaries
+		bytes.Repeat([]byte("abc"), 20000),
+	}
+
+	for _, input := range inputs {
+		expected := words.SegmentAll(input)
+		count := words.Count(input)
+
+		for _, workers := range []int{0, 1, 2, 3, 8, 64} {
+			got := words.SegmentAllParallel(input, workers)
+			if !reflect.DeepEqual(got, expected) {
+				t.Fatalf("SegmentAllParallel with %d workers should be identical to SegmentAll", workers)
+			}
+			if got := words.CountParallel(input, workers); got != count {
+				t.Fatalf("CountParallel with %d workers: expected %d, got %d", workers, count, got)
+			}
+		}
+	}
+}
+
+func BenchmarkCountParallel(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		b.Error(err)
+	}
+	file = bytes.Repeat(file, 10)
+
+	b.ResetTimer()
+	b.SetBytes(int64(len(file)))
+
+	for i := 0; i < b.N; i++ {
+		words.CountParallel(file, 0)
+	}
+}
@@ -0,0 +1,158 @@
+package words
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Phones combines a phone number, such as "+1 (555) 123-4567", "555.123.4567"
+// or "020 7946 0958", where the spec would split it at the spaces, dashes,
+// dots and parentheses. It recognizes groups of digits, separated by a
+// single space, dash or dot, with an optional leading + and parenthesized
+// group, and between 7 and 15 digits in total.
+//
+// To avoid combining other numbers, groupings which look like a date, such as
+// "2024-01-15", or like thousands, such as "1 000 000", are not phone numbers,
+// unless there is a + or parentheses. Like any heuristic, it will have false
+// positives and negatives; it is intended for extraction from free text, not
+// validation.
+var Phones Combiner = phones{}
+
+type phones struct{}
+
+// maxPhone is the most bytes a phone number may occupy, so that the lookahead
+// is bounded
+const maxPhone = 32
+
+func (phones) Combine(data []byte, atEOF bool) (int, bool) {
+	b := data[0]
+	if b != '+' && b != '(' && !isDigit(b) {
+		return 0, false
+	}
+
+	var groups [8]int // digits per group
+	var n, digits int // number of groups, and total digits
+	var plus, paren bool
+
+	i := 0
+	if data[i] == '+' {
+		plus = true
+		i++
+	}
+
+	for {
+		if i == len(data) {
+			return 0, !atEOF
+		}
+
+		closing := false
+		if data[i] == '(' {
+			if paren {
+				return 0, false
+			}
+			paren, closing = true, true
+			i++
+		}
+
+		start := i
+		for i < len(data) && isDigit(data[i]) {
+			i++
+		}
+		if i > maxPhone {
+			return 0, false
+		}
+		if i == len(data) && !atEOF {
+			return 0, true
+		}
+		if i == start {
+			return 0, false
+		}
+		g := i - start
+
+		if closing {
+			if i == len(data) || data[i] != ')' {
+				return 0, false
+			}
+			i++
+		}
+
+		if n == len(groups) {
+			return 0, false
+		}
+		groups[n] = g
+		digits += g
+		n++
+
+		// A separator must be followed by a digit or a parenthesized group;
+		// after a closing parenthesis, it is optional
+		if i == len(data) {
+			if !atEOF {
+				return 0, true
+			}
+			break
+		}
+		if closing && isDigit(data[i]) {
+			continue
+		}
+		if s := data[i]; s != ' ' && s != '-' && s != '.' {
+			break
+		}
+		if i+1 == len(data) {
+			if !atEOF {
+				return 0, true
+			}
+			break
+		}
+		if !isDigit(data[i+1]) && data[i+1] != '(' {
+			break
+		}
+		i++
+	}
+
+	if digits < 7 || digits > 15 {
+		return 0, false
+	}
+	if n == 1 && !plus && !paren {
+		// A single run of digits, which the spec already keeps whole
+		return 0, false
+	}
+	if !plus && !paren && (isDate(groups[:n]) || isThousands(data, groups[:n])) {
+		return 0, false
+	}
+
+	// The number must end at a word boundary, so "555-1234abc" is not a phone
+	if i < len(data) {
+		if !utf8.FullRune(data[i:]) && !atEOF {
+			return 0, true
+		}
+		if r, _ := utf8.DecodeRune(data[i:]); unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return 0, false
+		}
+	}
+
+	return i, false
+}
+
+// isDate determines if groups of digits look like a date, such as 2024-01-15
+// or 15.01.2024
+func isDate(groups []int) bool {
+	if len(groups) != 3 {
+		return false
+	}
+	return (groups[0] == 4 && groups[1] <= 2 && groups[2] <= 2) ||
+		(groups[0] <= 2 && groups[1] <= 2 && groups[2] == 4)
+}
+
+// isThousands determines if groups of digits look like a number grouped by
+// thousands, such as 1 000 000, which never has a leading zero
+func isThousands(data []byte, groups []int) bool {
+	if groups[0] > 3 || data[0] == '0' {
+		return false
+	}
+	for _, g := range groups[1:] {
+		if g != 3 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package words_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/clipperhouse/uax29/internal/segtest"
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestPhones(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	split := words.Combine(words.SplitFunc, words.Phones)
+
+	tests := []test{
+		{"Call +1 (555) 123-4567 today", []string{"Call", "+1 (555) 123-4567", "today"}},
+		{"Call (555) 123-4567.", []string{"Call", "(555) 123-4567"}},
+		{"Call (555)123-4567", []string{"Call", "(555)123-4567"}},
+		{"Call 555.123.4567, or", []string{"Call", "555.123.4567", "or"}},
+		{"Call 555-1234", []string{"Call", "555-1234"}},
+		{"Ring 020 7946 0958", []string{"Ring", "020 7946 0958"}},
+		{"Ring +44 20 7946 0958", []string{"Ring", "+44 20 7946 0958"}},
+		{"Ring +442079460958", []string{"Ring", "+442079460958"}},
+		{"+1 555 123 4567", []string{"+1 555 123 4567"}},
+		{"5551234567", []string{"5551234567"}},
+		{"On 2024-01-15 at", []string{"On", "2024", "01", "15", "at"}},
+		{"On 15.01.2024 at", []string{"On", "15.01.2024", "at"}},
+		{"About 1 000 000 people", []string{"About", "1", "000", "000", "people"}},
+		{"Score 3-2", []string{"Score", "3", "2"}},
+		{"Call 555-1234abc", []string{"Call", "555", "1234abc"}},
+		{"Call 555-1234-", []string{"Call", "555-1234"}},
+		{"123 456 789 123 456 789", []string{"123", "456", "789", "123", "456", "789"}},
+		{"(555) (123) 4567", []string{"555", "(123) 4567"}},
+		{"+ 555", []string{"+", "555"}},
+	}
+
+	for _, test := range tests {
+		segtest.AssertSegments(t, split, filter.Wordlike, []byte(test.input), test.expected)
+	}
+}
+
+func TestPhonesRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	split := words.Combine(words.SplitFunc, words.Phones)
+	seg := words.NewSegmenter(nil)
+	seg.Split(split)
+
+	for i := 0; i < 100; i++ {
+		input := getRandomBytes()
+		seg.SetText(input)
+
+		var output []byte
+		for seg.Next() {
+			output = append(output, seg.Bytes()...)
+		}
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(output, input) {
+			t.Fatal("input bytes are not the same as segmented bytes")
+		}
+	}
+}
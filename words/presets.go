@@ -0,0 +1,71 @@
+package words
+
+// Presets are Joiners tailored to common domains, so that you needn't learn
+// every option to get good defaults. Each returns a new *Joiners, which you
+// may modify. See also [OCRJoiners], and [Segmenter.Strict] for no joiners
+// at all, per the spec.
+//
+// Presets may be refined in future versions, as options are added. If you
+// need tokenization to be reproducible over time, specify Joiners explicitly.
+
+// SearchJoiners returns Joiners tailored to search indexing and queries, where
+// a token should be something a user might type, and find:
+//   - hyphenated-words, including [HyphenVariants], and no-break hyphens
+//   - email@addresses and handles
+//   - #hashtags
+//   - apostrophes, including [ApostropheVariants], as in O`Brien
+//   - figure and en dashes are treated as hyphens, as in "pages 10–12" → "10–12"
+func SearchJoiners() *Joiners {
+	return &Joiners{
+		Middle:         append([]rune("-@"), HyphenVariants...),
+		Leading:        []rune("#"),
+		Apostrophes:    append([]rune(nil), ApostropheVariants...),
+		NoBreakHyphens: true,
+		Dashes:         DashAsHyphen,
+	}
+}
+
+// EditorialJoiners returns Joiners tailored to edited prose, such as books
+// and articles, where typography is deliberate:
+//   - hyphenated-words, and no-break hyphens, but not dashes, which separate
+//   - apostrophes, including [ApostropheVariants], as in Hawaiʻi
+//   - words broken across lines by a soft hyphen
+func EditorialJoiners() *Joiners {
+	return &Joiners{
+		Middle:         []rune("-"),
+		Apostrophes:    append([]rune(nil), ApostropheVariants...),
+		SoftHyphens:    true,
+		NoBreakHyphens: true,
+	}
+}
+
+// ChatJoiners returns Joiners tailored to informal text, such as chat and
+// social media:
+//   - #hashtags, @mentions and $cashtags
+//   - hyphenated-words
+//   - apostrophes, including [ApostropheVariants], as in "don`t"
+//
+// Emoji, including ZWJ sequences and skin tones, are kept whole by the spec,
+// with or without joiners.
+func ChatJoiners() *Joiners {
+	return &Joiners{
+		Middle:      []rune("-"),
+		Leading:     []rune("#@$"),
+		Apostrophes: append([]rune(nil), ApostropheVariants...),
+	}
+}
+
+// TSVJoiners returns Joiners tailored to tab-separated values, in which tab
+// is a [Joiners] Delimiter, so that tokens never span columns. For CSV, or
+// other delimiters, modify the result, for example:
+//
+//	j := words.TSVJoiners()
+//	j.Delimiters = []rune(",")
+//
+// Note that quoted fields are not parsed; a delimiter within quotes will
+// break, too.
+func TSVJoiners() *Joiners {
+	return &Joiners{
+		Delimiters: []rune("\t"),
+	}
+}
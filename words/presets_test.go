@@ -0,0 +1,90 @@
+package words_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/iterators/filter"
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestPresets(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		name     string
+		joiners  *words.Joiners
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		{
+			"strict",
+			nil,
+			"Email foo@example.com about #winning, O`Brien’s pages 10–12 👍🏽",
+			[]string{"Email", "foo", "example.com", "about", "winning", "O", "`", "Brien’s", "pages", "10", "12", "👍🏽"},
+		},
+		{
+			"search",
+			words.SearchJoiners(),
+			"Email foo@example.com about #winning, O`Brien’s pages 10–12 super‐cool",
+			[]string{"Email", "foo@example.com", "about", "#winning", "O`Brien’s", "pages", "10–12", "super‐cool"},
+		},
+		{
+			"editorial",
+			words.EditorialJoiners(),
+			"The well-known Hawaiʻi trip, 1999–2001, was seg­\nmented.",
+			[]string{"The", "well-known", "Hawaiʻi", "trip", "1999", "2001", "was", "seg­\nmented"},
+		},
+		{
+			"chat",
+			words.ChatJoiners(),
+			"@ada don`t buy $TSLA #yolo 👩🏽‍💻",
+			[]string{"@ada", "don`t", "buy", "$TSLA", "#yolo", "👩🏽‍💻"},
+		},
+	}
+
+	for _, test := range tests {
+		seg := words.NewSegmenter([]byte(test.input))
+		if test.joiners != nil {
+			seg.Joiners(test.joiners)
+		}
+		seg.Filter(filter.Wordlike)
+
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, got)
+		}
+	}
+}
+
+// TestPresetsIndependent ensures that modifying one preset does not modify
+// another, or the exported variants
+func TestPresetsIndependent(t *testing.T) {
+	t.Parallel()
+
+	variants := append([]rune(nil), words.ApostropheVariants...)
+
+	modified := words.SearchJoiners()
+	modified.Apostrophes[0] = 'x'
+
+	presets := map[string]*words.Joiners{
+		"SearchJoiners":    words.SearchJoiners(),
+		"EditorialJoiners": words.EditorialJoiners(),
+		"ChatJoiners":      words.ChatJoiners(),
+		"OCRJoiners":       words.OCRJoiners(),
+	}
+	for name, j := range presets {
+		if !reflect.DeepEqual(j.Apostrophes, variants) {
+			t.Errorf("%s: expected Apostrophes %q, got %q", name, variants, j.Apostrophes)
+		}
+	}
+	if !reflect.DeepEqual(words.ApostropheVariants, variants) {
+		t.Errorf("expected ApostropheVariants %q, got %q", variants, words.ApostropheVariants)
+	}
+}
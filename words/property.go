@@ -0,0 +1,78 @@
+package words
+
+import "fmt"
+
+// Property is a word break property of the spec, for overriding the property
+// of a rune, see [Joiners].Overrides. The zero value is Other, i.e. none.
+// See https://unicode.org/reports/tr29/#Table_Word_Break_Property_Values
+type Property uint32
+
+const (
+	Other                Property = 0
+	ALetter              Property = Property(_ALetter)
+	CR                   Property = Property(_CR)
+	DoubleQuote          Property = Property(_DoubleQuote)
+	Extend               Property = Property(_Extend)
+	ExtendNumLet         Property = Property(_ExtendNumLet)
+	ExtendedPictographic Property = Property(_ExtendedPictographic)
+	Format               Property = Property(_Format)
+	HebrewLetter         Property = Property(_HebrewLetter)
+	Katakana             Property = Property(_Katakana)
+	LF                   Property = Property(_LF)
+	MidLetter            Property = Property(_MidLetter)
+	MidNum               Property = Property(_MidNum)
+	MidNumLet            Property = Property(_MidNumLet)
+	Newline              Property = Property(_Newline)
+	Numeric              Property = Property(_Numeric)
+	RegionalIndicator    Property = Property(_RegionalIndicator)
+	SingleQuote          Property = Property(_SingleQuote)
+	WSegSpace            Property = Property(_WSegSpace)
+	ZWJ                  Property = Property(_ZWJ)
+)
+
+// String returns the name of the property, as in the spec, such as
+// "MidLetter" or "Hebrew_Letter".
+func (p Property) String() string {
+	if p == Other {
+		return "Other"
+	}
+	if name, ok := names[property(p)]; ok {
+		return name
+	}
+	return fmt.Sprintf("Property(%d)", uint32(p))
+}
+
+// MarshalText implements encoding.TextMarshaler, for serialization
+func (p Property) MarshalText() ([]byte, error) {
+	if _, ok := names[property(p)]; !ok && p != Other {
+		return nil, fmt.Errorf("words: unknown Property %d", uint32(p))
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, for serialization
+func (p *Property) UnmarshalText(text []byte) error {
+	if string(text) == "Other" {
+		*p = Other
+		return nil
+	}
+	for bit, name := range names {
+		if string(text) == name {
+			*p = Property(bit)
+			return nil
+		}
+	}
+	return fmt.Errorf("words: unknown Property %q", text)
+}
+
+// override returns the property of r, as overridden by Joiners.Overrides, or
+// p if there is no override
+func (j *Joiners) override(r rune, p property) property {
+	if j == nil {
+		return p
+	}
+	if o, ok := j.Overrides[r]; ok {
+		return property(o)
+	}
+	return p
+}
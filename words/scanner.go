@@ -21,7 +21,8 @@ func NewScanner(r io.Reader) *Scanner {
 }
 
 // Joiners sets runes that should be treated like word characters, where
-// otherwsie words sill be split. See the [Joiners] type.
+// otherwise words will be split. See the [Joiners] type. Tokens are identical
+// to those of a Segmenter with the same Joiners.
 func (sc *Scanner) Joiners(j *Joiners) {
 	sc.Split(j.splitFunc)
 }
@@ -1,12 +1,15 @@
 package words_test
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/rand"
+	"fmt"
 	mathrand "math/rand"
 	"os"
 	"reflect"
 	"testing"
+	"testing/iotest"
 	"unicode/utf8"
 
 	"github.com/clipperhouse/uax29/words"
@@ -100,6 +103,42 @@ func TestScannerJoiners(t *testing.T) {
 	}
 }
 
+func TestScannerJoinersSameAsSegmenter(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	presets := []*words.Joiners{joiners, words.SearchJoiners(), words.EditorialJoiners(), words.ChatJoiners(), words.TSVJoiners()}
+	for _, j := range presets {
+		for _, input := range [][]byte{joinersInput, file} {
+			var expected []string
+			seg := words.NewSegmenter(input)
+			seg.Joiners(j)
+			for seg.Next() {
+				expected = append(expected, seg.Text())
+			}
+
+			// One byte at a time, so that joiners are tested at buffer boundaries
+			var got []string
+			sc := words.NewScanner(iotest.OneByteReader(bytes.NewReader(input)))
+			sc.Joiners(j)
+			for sc.Scan() {
+				got = append(got, sc.Text())
+			}
+			if err := sc.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, expected) {
+				t.Fatal("Scanner and Segmenter with Joiners should give identical results")
+			}
+		}
+	}
+}
+
 func TestInvalidUTF8(t *testing.T) {
 	t.Parallel()
 
@@ -215,6 +254,51 @@ func BenchmarkScanner(b *testing.B) {
 	}
 }
 
+// BenchmarkScannerBuffer streams a large input with different buffer sizes, and
+// compares with Segmenter on the same input. If Scanner were bound by memory
+// access rather than by segmentation rules, we would expect it to be slower
+// than Segmenter, and to be sensitive to buffer size.
+func BenchmarkScannerBuffer(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		b.Error(err)
+	}
+	file = bytes.Repeat(file, 16)
+
+	for _, size := range []int{4 << 10, 8 << 10, 64 << 10} {
+		b.Run(fmt.Sprintf("Scanner/%dKB", size>>10), func(b *testing.B) {
+			b.SetBytes(int64(len(file)))
+			r := bytes.NewReader(file)
+
+			for i := 0; i < b.N; i++ {
+				r.Reset(file)
+				sc := words.NewScanner(r)
+				sc.Buffer(make([]byte, size), bufio.MaxScanTokenSize)
+
+				for sc.Scan() {
+				}
+				if err := sc.Err(); err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	}
+
+	b.Run("Segmenter", func(b *testing.B) {
+		b.SetBytes(int64(len(file)))
+
+		for i := 0; i < b.N; i++ {
+			seg := words.NewSegmenter(file)
+
+			for seg.Next() {
+			}
+			if err := seg.Err(); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
 func BenchmarkUnicodeSegments(b *testing.B) {
 	var buf bytes.Buffer
 	for _, test := range unicodeTests {
@@ -0,0 +1,196 @@
+package words
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ScriptOf returns the dominant Unicode script of a token, by the names used
+// in [unicode.Scripts], such as "Latin", "Han" or "Cyrillic". It is the script
+// of the most runes in the token, ignoring characters which are shared across
+// scripts (Common, such as digits and punctuation, and Inherited, such as
+// combining marks); ties go to the script which appears first. If there is no
+// such script, it returns "Common".
+//
+// This is useful for routing tokens by script, for example to send Han
+// tokens to a CJK analyzer. This API is experimental.
+func ScriptOf(token []byte) string {
+	type count struct {
+		script, n int
+	}
+
+	// Tokens rarely have more than a couple of scripts; avoid allocation
+	var counts [4]count
+	var seen int
+
+	last := -1
+	for pos := 0; pos < len(token); {
+		s, w, i := scriptAt(token[pos:], last)
+		pos += w
+		if s < 0 {
+			continue
+		}
+		last = i
+
+		found := false
+		for i := 0; i < seen; i++ {
+			if counts[i].script == s {
+				counts[i].n++
+				found = true
+				break
+			}
+		}
+		if !found && seen < len(counts) {
+			counts[seen] = count{s, 1}
+			seen++
+		}
+	}
+
+	if seen == 0 {
+		return "Common"
+	}
+
+	best := counts[0]
+	for _, c := range counts[1:seen] {
+		if c.n > best.n {
+			best = c
+		}
+	}
+	return scriptNames[best.script]
+}
+
+// IsMixedScript determines if a token contains runes of more than one
+// script, ignoring Common and Inherited characters, see [ScriptOf]. For
+// example, "p\u0430ypal", with a Cyrillic "а" (U+0430), is mixed. This is
+// useful for detecting homographs (spoofing) in usernames and URLs. Note
+// that some languages, such as Japanese, mix scripts legitimately.
+// This API is experimental.
+func IsMixedScript(token []byte) bool {
+	first, last := -1, -1
+	for pos := 0; pos < len(token); {
+		s, w, i := scriptAt(token[pos:], last)
+		pos += w
+		if s < 0 {
+			continue
+		}
+		last = i
+
+		if first < 0 {
+			first = s
+			continue
+		}
+		if s != first {
+			return true
+		}
+	}
+	return false
+}
+
+// scriptAt returns the script id of the rune at the start of data, or -1
+// if it is Common or Inherited, and the width of the rune. Index is the
+// range in scripts, to be passed as last to a subsequent call.
+func scriptAt(data []byte, last int) (id, w, index int) {
+	if b := data[0]; b < utf8.RuneSelf {
+		// Fast path: ASCII letters are Latin, the rest are Common
+		if (b|0x20) < 'a' || (b|0x20) > 'z' {
+			return -1, 1, last
+		}
+		return latin, 1, last
+	}
+
+	r, w := utf8.DecodeRune(data)
+	i := scriptIndex(r, last)
+	if i < 0 {
+		return -1, w, last
+	}
+	return scripts[i].id, w, i
+}
+
+// Script returns the dominant script of the current token, see [ScriptOf].
+func (seg *Segmenter) Script() string {
+	return ScriptOf(seg.Bytes())
+}
+
+// Script returns the dominant script of the current token, see [ScriptOf].
+func (sc *Scanner) Script() string {
+	return ScriptOf(sc.Bytes())
+}
+
+// MixedScript determines if the current token contains more than one
+// script, see [IsMixedScript].
+func (seg *Segmenter) MixedScript() bool {
+	return IsMixedScript(seg.Bytes())
+}
+
+// MixedScript determines if the current token contains more than one
+// script, see [IsMixedScript].
+func (sc *Scanner) MixedScript() bool {
+	return IsMixedScript(sc.Bytes())
+}
+
+// script is a range of runes in a script, identified by its index in scriptNames
+type script struct {
+	lo, hi rune
+	id     int
+}
+
+// scripts are the ranges of unicode.Scripts, excluding Common and
+// Inherited, sorted by lo, for binary search
+var scripts []script
+
+var scriptNames []string
+
+// latin is the id of the Latin script
+var latin int
+
+func init() {
+	for name := range unicode.Scripts {
+		if name == "Common" || name == "Inherited" {
+			continue
+		}
+		scriptNames = append(scriptNames, name)
+	}
+	sort.Strings(scriptNames)
+	latin = sort.SearchStrings(scriptNames, "Latin")
+
+	for id, name := range scriptNames {
+		add := func(lo, hi, stride rune) {
+			if stride == 1 {
+				scripts = append(scripts, script{lo, hi, id})
+				return
+			}
+			for r := lo; r <= hi; r += stride {
+				scripts = append(scripts, script{r, r, id})
+			}
+		}
+
+		table := unicode.Scripts[name]
+		for _, r := range table.R16 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+		for _, r := range table.R32 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+	}
+	sort.Slice(scripts, func(i, j int) bool {
+		return scripts[i].lo < scripts[j].lo
+	})
+}
+
+// scriptIndex returns the index in scripts of the range which contains r,
+// or -1 if r is Common or Inherited. Last is the previous result, which is
+// likely to match, as runes in a token are usually in the same range.
+func scriptIndex(r rune, last int) int {
+	if last >= 0 && scripts[last].lo <= r && r <= scripts[last].hi {
+		return last
+	}
+
+	i := sort.Search(len(scripts), func(i int) bool {
+		return scripts[i].hi >= r
+	})
+	if i < len(scripts) && scripts[i].lo <= r {
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,121 @@
+package words_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestScriptOf(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected string
+	}
+
+	tests := []test{
+		{"hello", "Latin"},
+		{"Привет", "Cyrillic"},
+		{"日本語", "Han"},
+		{"トウキョウ", "Katakana"},
+		{"東京タワー", "Han"}, // a tie, ー is Common
+		{"שלום", "Hebrew"},
+		{"é", "Latin"},
+		{"123", "Common"},
+		{"...", "Common"},
+		{"👍🏽", "Common"},
+		{"\u0301", "Common"},
+		{"", "Common"},
+		{"abcПр", "Latin"},
+		{"abПр", "Latin"},
+		{"aПрыb", "Cyrillic"},
+	}
+
+	for _, test := range tests {
+		got := words.ScriptOf([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%q: expected %s, got %s", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestSegmenterScript(t *testing.T) {
+	t.Parallel()
+
+	seg := words.NewSegmenter([]byte("Hello, 世界 мир"))
+
+	var got []string
+	for seg.Next() {
+		got = append(got, seg.Script())
+	}
+
+	expected := []string{"Latin", "Common", "Common", "Han", "Han", "Common", "Cyrillic"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	}
+}
+
+func BenchmarkScriptOf(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	b.SetBytes(int64(len(file)))
+
+	seg := words.NewSegmenter(file)
+	for i := 0; i < b.N; i++ {
+		seg.SetText(file)
+		for seg.Next() {
+			_ = seg.Script()
+		}
+	}
+}
+
+func TestIsMixedScript(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected bool
+	}
+
+	tests := []test{
+		{"paypal", false},
+		{"p\u0430ypal", true}, // Cyrillic а
+		{"Привет", false},
+		{"東京タワー", true},
+		{"abc123", false},
+		{"é", false},
+		{"ΑΒΓ", false},
+		{"ABΓ", true},
+		{"👍🏽", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		got := words.IsMixedScript([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%q: expected %t, got %t", test.input, test.expected, got)
+		}
+	}
+
+	seg := words.NewSegmenter([]byte("paypal p\u0430ypal"))
+	var got []bool
+	for seg.Next() {
+		got = append(got, seg.MixedScript())
+	}
+	expected := []bool{false, false, true}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
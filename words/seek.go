@@ -37,6 +37,41 @@ func previous(properties property, data []byte) bool {
 	return previousIndex(properties, data) != -1
 }
 
+// subsequent is the package-level subsequent, with Joiners.Overrides applied
+func (j *Joiners) subsequent(properties property, data []byte, atEOF bool) (found bool, more bool) {
+	if j == nil || j.Overrides == nil {
+		return subsequent(properties, data, atEOF)
+	}
+
+	i := 0
+	for i < len(data) {
+		lookup, w := trie.lookup(data[i:])
+		if w == 0 {
+			if atEOF {
+				// Nothing more to evaluate
+				return false, false
+			}
+			// More to evaluate
+			return false, true
+		}
+		lookup = j.override(decodeRune(data[i:], w), lookup)
+
+		if lookup.is(_Ignore) {
+			i += w
+			continue
+		}
+
+		return lookup.is(properties), false
+	}
+
+	if atEOF {
+		// Nothing more to evaluate
+		return false, false
+	}
+	// More to evaluate
+	return false, true
+}
+
 // subsequent looks ahead in the buffer until it hits a rune in properties,
 // ignoring runes with the _Ignore property per WB4
 func subsequent(properties property, data []byte, atEOF bool) (found bool, more bool) {
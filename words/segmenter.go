@@ -37,3 +37,13 @@ func SegmentAll(data []byte) [][]byte {
 	_ = iterators.All(data, &result, j.splitFunc) // can elide the error, see tests
 	return result
 }
+
+// Count returns the number of words in data, as would be returned by
+// SegmentAll or a Segmenter, without collecting them. Note that all segments
+// are counted, including whitespace and punctuation; to count only words,
+// use a Segmenter with a filter, such as filter.Wordlike.
+func Count(data []byte) int {
+	j := Joiners{}
+	n, _ := iterators.Count(data, j.splitFunc) // can elide the error, see tests
+	return n
+}
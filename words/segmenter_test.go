@@ -48,6 +48,11 @@ func TestSegmenterUnicode(t *testing.T) {
 		if !reflect.DeepEqual(all, segmented) {
 			t.Error("calling SegmentAll should be identical to iterating Segmenter")
 		}
+
+		// And Count
+		if c := words.Count(test.input); c != len(segmented) {
+			t.Errorf("calling Count should be identical to counting Segmenter, expected %d, got %d", len(segmented), c)
+		}
 	}
 
 	if len(unicodeTests) != passed+failed {
@@ -123,6 +128,108 @@ func TestSegmenterJoiners(t *testing.T) {
 	}
 }
 
+func TestSegmenterStrict(t *testing.T) {
+	t.Parallel()
+
+	seg := words.NewSegmenter(nil)
+	seg.Joiners(joiners)
+	seg.Strict()
+
+	for _, test := range unicodeTests {
+		seg.SetText(test.input)
+
+		var segmented [][]byte
+		for seg.Next() {
+			segmented = append(segmented, seg.Bytes())
+		}
+
+		if err := seg.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(segmented, test.expected) {
+			t.Fatalf(`
+	for input %v
+	expected  %v
+	got       %v
+	spec      %s`, test.input, test.expected, segmented, test.comment)
+		}
+	}
+
+	seg.SetText(joinersInput)
+	founds := segToSet(seg)
+
+	for _, test := range joinersTests {
+		_, found := founds[test.input]
+		if found != test.found1 {
+			t.Fatalf("For %q, expected %t for found in strict segmenter, but got %t", test.input, test.found1, found)
+		}
+	}
+
+	// Strict removes other extensions, too
+	input := []byte("\x1b[31mhttps://example.com!!!")
+	expected := words.SegmentAll(input)
+	extensions := []func(){
+		func() { seg.ANSI(&words.ANSI{}) },
+		func() { seg.Split(words.Combine(words.SplitFunc, words.URLs, words.PunctuationRuns(0))) },
+	}
+	for _, extend := range extensions {
+		extend()
+		seg.Strict()
+		seg.SetText(input)
+
+		var got [][]byte
+		for seg.Next() {
+			got = append(got, seg.Bytes())
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected strict segmenter to match SegmentAll, got %q", got)
+		}
+	}
+}
+
+// TestSegmenterVertical tests Mongolian variation selectors and separators, and
+// presentation forms for vertical text, per WordBreakProperty.txt
+func TestSegmenterVertical(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+	}
+
+	tests := []test{
+		// Free variation selectors FVS1-4 are Extend (WB4)
+		{"\u182e\u180b\u1823\u1829", []string{"\u182e\u180b\u1823\u1829"}},
+		{"\u182e\u180f\u1823", []string{"\u182e\u180f\u1823"}},
+		// The vowel separator (MVS) is Format (WB4)
+		{"\u182e\u180e\u1820", []string{"\u182e\u180e\u1820"}},
+		// Narrow no-break space, before a suffix, is ExtendNumLet (WB13a, WB13b)
+		{"\u182c\u1820\u1828\u202f\u1833\u1824", []string{"\u182c\u1820\u1828\u202f\u1833\u1824"}},
+		// Mongolian punctuation is not part of a word
+		{"\u182e\u1803 \u1820", []string{"\u182e", "\u1803", " ", "\u1820"}},
+		// Vertical colon is MidLetter (WB6, WB7), vertical comma is MidNum
+		// (WB11, WB12), and vertical low line is ExtendNumLet (WB13a, WB13b)
+		{"a\ufe13b", []string{"a\ufe13b"}},
+		{"1\ufe10000", []string{"1\ufe10000"}},
+		{"foo\ufe33bar", []string{"foo\ufe33bar"}},
+		// Vertical full stop and brackets are not part of a word
+		{"\ufe41\u6587\ufe12\ufe42", []string{"\ufe41", "\u6587", "\ufe12", "\ufe42"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		seg := words.NewSegmenter([]byte(test.input))
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%+q: expected %+q, got %+q", test.input, test.expected, got)
+		}
+	}
+}
+
 func TestSegmenterInvalidUTF8(t *testing.T) {
 	t.Parallel()
 
@@ -276,6 +383,24 @@ func BenchmarkSegmentAll(b *testing.B) {
 	}
 }
 
+func BenchmarkCount(b *testing.B) {
+	file, err := os.ReadFile("../testdata/sample.txt")
+
+	if err != nil {
+		b.Error(err)
+	}
+
+	c := words.Count(file)
+	b.ResetTimer()
+	b.SetBytes(int64(len(file)))
+
+	for i := 0; i < b.N; i++ {
+		_ = words.Count(file)
+	}
+
+	b.ReportMetric(float64(c), "tokens")
+}
+
 func BenchmarkUnicodeTests(b *testing.B) {
 	var buf bytes.Buffer
 	for _, test := range unicodeTests {
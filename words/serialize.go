@@ -0,0 +1,193 @@
+package words
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// unicodeVersion is the version of the Unicode data in trie.go; it is
+// part of the Hash, since the data determines tokenization
+const unicodeVersion = "15.0.0"
+
+// rulesVersion is incremented on any change to this package which changes
+// tokenization, such as a fix to the rules; it is part of the Fingerprint.
+// TestFingerprintGuard will fail until it is. Record the change in
+// versions/changes.json, too.
+const rulesVersion = 1
+
+// ErrDigitsNotSerializable is returned when serializing Joiners which have
+// a Digits func, which can't be represented.
+var ErrDigitsNotSerializable = errors.New("words: Joiners with a Digits func can't be serialized")
+
+// joinersJSON is the serialized form of Joiners
+type joinersJSON struct {
+	Middle         string              `json:"middle,omitempty"`
+	Leading        string              `json:"leading,omitempty"`
+	Trailing       string              `json:"trailing,omitempty"`
+	Apostrophes    string              `json:"apostrophes,omitempty"`
+	SoftHyphens    bool                `json:"softHyphens,omitempty"`
+	NoBreakHyphens bool                `json:"noBreakHyphens,omitempty"`
+	Dashes         Dash                `json:"dashes,omitempty"`
+	Lookahead      int                 `json:"lookahead,omitempty"`
+	Delimiters     string              `json:"delimiters,omitempty"`
+	Overrides      map[string]Property `json:"overrides,omitempty"`
+}
+
+// MarshalJSON serializes the Joiners, so that an index can record which
+// configuration produced it. The form is canonical: runes are sorted and
+// de-duplicated, since their order has no effect, and zero values are omitted.
+// It returns ErrDigitsNotSerializable if Digits is set.
+func (j *Joiners) MarshalJSON() ([]byte, error) {
+	if j.Digits != nil {
+		return nil, ErrDigitsNotSerializable
+	}
+
+	var overrides map[string]Property
+	if len(j.Overrides) > 0 {
+		overrides = make(map[string]Property, len(j.Overrides))
+		for r, p := range j.Overrides {
+			overrides[string(r)] = p
+		}
+	}
+
+	return json.Marshal(joinersJSON{
+		Middle:         canonical(j.Middle),
+		Leading:        canonical(j.Leading),
+		Trailing:       canonical(j.Trailing),
+		Apostrophes:    canonical(j.Apostrophes),
+		SoftHyphens:    j.SoftHyphens,
+		NoBreakHyphens: j.NoBreakHyphens,
+		Dashes:         j.Dashes,
+		Lookahead:      j.Lookahead,
+		Delimiters:     canonical(j.Delimiters),
+		Overrides:      overrides,
+	})
+}
+
+// UnmarshalJSON deserializes Joiners, see MarshalJSON.
+func (j *Joiners) UnmarshalJSON(data []byte) error {
+	var v joinersJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var overrides map[rune]Property
+	for key, p := range v.Overrides {
+		rs := []rune(key)
+		if len(rs) != 1 {
+			return fmt.Errorf("words: override %q is not a single rune", key)
+		}
+		if overrides == nil {
+			overrides = make(map[rune]Property, len(v.Overrides))
+		}
+		overrides[rs[0]] = p
+	}
+
+	*j = Joiners{
+		Middle:         runes(v.Middle),
+		Leading:        runes(v.Leading),
+		Trailing:       runes(v.Trailing),
+		Apostrophes:    runes(v.Apostrophes),
+		SoftHyphens:    v.SoftHyphens,
+		NoBreakHyphens: v.NoBreakHyphens,
+		Dashes:         v.Dashes,
+		Lookahead:      v.Lookahead,
+		Delimiters:     runes(v.Delimiters),
+	}
+	j.Overrides = overrides
+	return nil
+}
+
+// Hash returns a stable identifier of the tokenization that the Joiners will
+// produce, as a hex string. Joiners which are equivalent (such as the same
+// runes in a different order) have the same hash. It includes the Unicode
+// version of the data, which also determines tokenization. A nil *Joiners,
+// i.e. the spec, has a hash too.
+//
+// An index might record the hash, and reject queries tokenized with a
+// different one. It returns ErrDigitsNotSerializable if Digits is set.
+func (j *Joiners) Hash() (string, error) {
+	if j == nil {
+		j = &Joiners{}
+	}
+
+	b, err := j.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte("words/" + unicodeVersion + "/" + string(b)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Fingerprint returns an identifier of the tokenization that the Joiners will
+// produce, with this version of this package, for use in cache keys. It
+// combines the version of the rules, the Unicode version and the Hash of the
+// Joiners. Equal fingerprints imply identical tokenization of the same input,
+// so results can be shared across instances; a change to the rules or the
+// data which changes tokenization changes the fingerprint, invalidating
+// caches on upgrade. A nil *Joiners, i.e. the spec, has a fingerprint too.
+//
+// Combiners, filters and transforms are not included; if you use them, add
+// your own identifiers to the key. Likewise for the [ANSI] wrapper: the
+// fingerprint of its Joiners does not include its MaxLength or Payloads,
+// which change tokenization of escape sequences. It returns
+// ErrDigitsNotSerializable if Digits is set.
+func (j *Joiners) Fingerprint() (string, error) {
+	h, err := j.Hash()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("words/%d/%s/%s", rulesVersion, unicodeVersion, h), nil
+}
+
+var dashNames = [...]string{"break", "hyphen", "join"}
+
+// MarshalText implements encoding.TextMarshaler, for serialization
+func (d Dash) MarshalText() ([]byte, error) {
+	if d < 0 || int(d) >= len(dashNames) {
+		return nil, fmt.Errorf("words: unknown Dash %d", d)
+	}
+	return []byte(dashNames[d]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, for serialization
+func (d *Dash) UnmarshalText(text []byte) error {
+	for i, name := range dashNames {
+		if string(text) == name {
+			*d = Dash(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("words: unknown Dash %q", text)
+}
+
+// canonical returns the runes as a string, sorted and de-duplicated
+func canonical(rs []rune) string {
+	if len(rs) == 0 {
+		return ""
+	}
+
+	sorted := append([]rune(nil), rs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	result := sorted[:1]
+	for _, r := range sorted[1:] {
+		if r != result[len(result)-1] {
+			result = append(result, r)
+		}
+	}
+	return string(result)
+}
+
+// runes is the inverse of canonical
+func runes(s string) []rune {
+	if s == "" {
+		return nil
+	}
+	return []rune(s)
+}
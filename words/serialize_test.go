@@ -0,0 +1,218 @@
+package words_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestJoinersJSON(t *testing.T) {
+	t.Parallel()
+
+	presets := []*words.Joiners{
+		{},
+		words.SearchJoiners(),
+		words.EditorialJoiners(),
+		words.ChatJoiners(),
+		words.OCRJoiners(),
+		words.TSVJoiners(),
+		{Dashes: words.DashJoin, Lookahead: 100, Delimiters: []rune(",\t")},
+		{Trailing: []rune("+#")},
+		{Overrides: map[rune]words.Property{':': words.MidLetter, '\'': words.Other, '#': words.HebrewLetter}},
+	}
+
+	for _, j := range presets {
+		b, err := json.Marshal(j)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got words.Joiners
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+
+		// Roundtrip should be equivalent, and hash identically
+		b2, err := json.Marshal(&got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != string(b2) {
+			t.Fatalf("expected %s, got %s", b, b2)
+		}
+
+		h1, _ := j.Hash()
+		h2, _ := got.Hash()
+		if h1 != h2 {
+			t.Fatalf("%s: expected identical hashes", b)
+		}
+	}
+
+	// Known form
+	b, err := json.Marshal(&words.Joiners{Middle: []rune("@-@"), Dashes: words.DashAsHyphen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"middle":"-@","dashes":"hyphen"}`
+	if string(b) != expected {
+		t.Fatalf("expected %s, got %s", expected, b)
+	}
+
+	b, err = json.Marshal(&words.Joiners{Overrides: map[rune]words.Property{':': words.MidLetter, '_': words.Other}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"overrides":{":":"MidLetter","_":"Other"}}`
+	if string(b) != expected {
+		t.Fatalf("expected %s, got %s", expected, b)
+	}
+
+	for _, bogus := range []string{`{"dashes":"bogus"}`, `{"overrides":{":":"bogus"}}`, `{"overrides":{"ab":"ALetter"}}`} {
+		var j words.Joiners
+		if err := json.Unmarshal([]byte(bogus), &j); err == nil {
+			t.Fatalf("%s: expected an error", bogus)
+		}
+		if !reflect.DeepEqual(j, words.Joiners{}) {
+			t.Fatalf("%s: expected Joiners to be unchanged on error", bogus)
+		}
+	}
+}
+
+func TestJoinersHash(t *testing.T) {
+	t.Parallel()
+
+	h1, err := (&words.Joiners{Middle: []rune("@-")}).Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h2, _ := (&words.Joiners{Middle: []rune("-@-")}).Hash()
+	if h1 != h2 {
+		t.Error("equivalent joiners should have identical hashes")
+	}
+
+	h3, _ := (&words.Joiners{Leading: []rune("@-")}).Hash()
+	if h1 == h3 {
+		t.Error("different joiners should have different hashes")
+	}
+
+	var none *words.Joiners
+	h4, err := none.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h5, _ := (&words.Joiners{}).Hash()
+	if h4 != h5 {
+		t.Error("nil and empty joiners should have identical hashes")
+	}
+
+	// The hash must be stable across versions of this package. It should
+	// change only when tokenization does, such as a new Unicode version.
+	const stable = "881c69735e3c756a1bb604089b53f139eea106d1526a0f9b936e8dd9cab8eef2"
+	if h5 != stable {
+		t.Errorf("expected stable hash %s, got %s", stable, h5)
+	}
+
+	_, err = (&words.Joiners{Digits: func(left, right []byte) bool { return true }}).Hash()
+	if !errors.Is(err, words.ErrDigitsNotSerializable) {
+		t.Errorf("expected ErrDigitsNotSerializable, got %v", err)
+	}
+}
+
+func TestJoinersFingerprint(t *testing.T) {
+	t.Parallel()
+
+	var none *words.Joiners
+	f1, err := none.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h1, _ := none.Hash()
+	if !strings.HasPrefix(f1, "words/") || !strings.HasSuffix(f1, "/"+h1) {
+		t.Errorf("expected fingerprint to include the package and hash, got %s", f1)
+	}
+
+	f2, _ := (&words.Joiners{}).Fingerprint()
+	if f1 != f2 {
+		t.Error("nil and empty joiners should have identical fingerprints")
+	}
+
+	f3, _ := words.ChatJoiners().Fingerprint()
+	if f1 == f3 {
+		t.Error("different joiners should have different fingerprints")
+	}
+
+	_, err = (&words.Joiners{Digits: func(left, right []byte) bool { return true }}).Fingerprint()
+	if !errors.Is(err, words.ErrDigitsNotSerializable) {
+		t.Errorf("expected ErrDigitsNotSerializable, got %v", err)
+	}
+}
+
+// TestFingerprintGuard pins the tokenization of the test corpora to the
+// fingerprint. If you've changed tokenization, and this test fails, increment
+// rulesVersion in serialize.go, and update the expectations below.
+func TestFingerprintGuard(t *testing.T) {
+	t.Parallel()
+
+	var inputs [][]byte
+	for _, name := range []string{"sample.txt", "chat.txt", "UTF-8-test.txt"} {
+		file, err := os.ReadFile("../testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, file)
+	}
+	for _, test := range unicodeTests {
+		inputs = append(inputs, test.input)
+	}
+
+	tests := []struct {
+		joiners     *words.Joiners
+		fingerprint string
+		digest      string
+	}{
+		{
+			joiners:     nil,
+			fingerprint: "words/1/15.0.0/881c69735e3c756a1bb604089b53f139eea106d1526a0f9b936e8dd9cab8eef2",
+			digest:      "47581a55024c05a60fe98d8c84fc98d8edad284602d5981568c044be784214fe",
+		},
+		{
+			joiners:     words.ChatJoiners(),
+			fingerprint: "words/1/15.0.0/d71a2ca77d91741bf7f62c3dd936b25c1d46ebc848ac2b71baab4060fd6f90d5",
+			digest:      "ad46db635978d9e0028ece94b63ff1df1425eaae7962a5f99b381e1bba9041a8",
+		},
+	}
+
+	for _, test := range tests {
+		fingerprint, err := test.joiners.Fingerprint()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h := sha256.New()
+		for _, input := range inputs {
+			seg := words.NewSegmenter(input)
+			seg.Joiners(test.joiners)
+			for seg.Next() {
+				var n [8]byte
+				binary.BigEndian.PutUint64(n[:], uint64(len(seg.Bytes())))
+				h.Write(n[:])
+				h.Write(seg.Bytes())
+			}
+		}
+		digest := hex.EncodeToString(h.Sum(nil))
+
+		if fingerprint != test.fingerprint || digest != test.digest {
+			t.Errorf("tokenization or fingerprint has changed; if tokenization has changed, increment rulesVersion\n"+
+				"expected fingerprint %s, digest %s\n"+
+				"got      fingerprint %s, digest %s", test.fingerprint, test.digest, fingerprint, digest)
+		}
+	}
+}
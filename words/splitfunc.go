@@ -2,6 +2,7 @@ package words
 
 import (
 	"bufio"
+	"bytes"
 	"unicode/utf8"
 )
 
@@ -18,6 +19,8 @@ const (
 	_Ignore     = _Extend | _Format | _ZWJ
 )
 
+var softHyphen = []byte("\u00AD")
+
 // SplitFunc is a bufio.SplitFunc implementation of word segmentation, for use with bufio.Scanner.
 var SplitFunc bufio.SplitFunc = none.splitFunc
 
@@ -35,6 +38,9 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 	var lastLastExIgnore property // "the last one before that"
 	var regionalIndicatorCount int
 
+	// decode determines if joiners need the rune at each position
+	decode := j != nil && (j.hasMiddle() || j.Delimiters != nil)
+
 	// https://unicode.org/reports/tr29/#WB1
 	{
 		// Start of text always advances
@@ -48,11 +54,16 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 			return pos, data[:pos], nil
 		}
 
-		if j != nil && j.Leading != nil {
-			r, _ := utf8.DecodeRune(data[pos:])
+		if j != nil && (j.Leading != nil || j.Delimiters != nil || j.Overrides != nil) {
+			r := decodeRune(data[pos:], w)
+			current = j.override(r, current)
 			if runesContain(j.Leading, r) {
 				current |= _AHLetter
 			}
+			if j.delimits(r) {
+				// Joiners: a delimiter breaks like a line break, see WB3a
+				current = _Newline
+			}
 		}
 
 		pos += w
@@ -91,10 +102,13 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 			return 0, nil, nil
 		}
 
-		if j != nil && j.Middle != nil {
-			r, _ := utf8.DecodeRune(data[pos:])
-			if runesContain(j.Middle, r) {
-				current |= _MidNumLet
+		if decode {
+			r := decodeRune(data[pos:], w)
+			current = j.override(r, current)
+			current |= j.middle(r)
+			if j.delimits(r) {
+				// Joiners: a delimiter breaks like a line break, see WB3b
+				current = _Newline
 			}
 		}
 
@@ -109,6 +123,37 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 			continue
 		}
 
+		// Joiners: a soft hyphen and a line break, between letters, joins the word
+		if j != nil && j.SoftHyphens && current.is(_CR|_LF) && lastExIgnore.is(_AHLetter) && bytes.HasSuffix(data[:pos], softHyphen) {
+			end := pos + w
+
+			// CR LF is a single line break
+			if current.is(_CR) {
+				if end == len(data) && !atEOF {
+					// Token extends past current data, request more
+					return 0, nil, nil
+				}
+				if end < len(data) && data[end] == '\n' {
+					end++
+				}
+			}
+
+			ahead, final := j.lookahead(data[end:], atEOF)
+			found, more := j.subsequent(_AHLetter, ahead, final)
+
+			if more {
+				// Token extends past current data, request more
+				return 0, nil, nil
+			}
+
+			if found {
+				pos = end
+				// Treat the line break as ignorable, so the subsequent letter joins per WB5
+				current = _Format
+				continue
+			}
+		}
+
 		// https://unicode.org/reports/tr29/#WB3a
 		// https://unicode.org/reports/tr29/#WB3b
 		if (last | current).is(_Newline | _CR | _LF) {
@@ -127,6 +172,37 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 			continue
 		}
 
+		// Joiners: digits, a single space, and digits may be joined by callback
+		if j != nil && j.Digits != nil && current.is(_WSegSpace) && last.is(_Numeric) {
+			start := pos + w
+			end := start
+			for end < len(data) {
+				lookup, w := trie.lookup(data[end:])
+				if w == 0 {
+					if !atEOF {
+						// Rune extends past current data, request more
+						return 0, nil, nil
+					}
+					break
+				}
+				if !lookup.is(_Numeric | _Ignore) {
+					break
+				}
+				end += w
+			}
+
+			if end == len(data) && !atEOF {
+				// Token extends past current data, request more
+				return 0, nil, nil
+			}
+
+			if end > start && j.Digits(data[:pos], data[start:end]) {
+				pos = end
+				current = _Numeric
+				continue
+			}
+		}
+
 		// https://unicode.org/reports/tr29/#WB4
 		if current.is(_Extend | _Format | _ZWJ) {
 			pos += w
@@ -145,7 +221,8 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 
 		// https://unicode.org/reports/tr29/#WB6
 		if current.is(_MidLetter|_MidNumLetQ) && lastExIgnore.is(_AHLetter) {
-			found, more := subsequent(_AHLetter, data[pos+w:], atEOF)
+			ahead, final := j.lookahead(data[pos+w:], atEOF)
+			found, more := j.subsequent(_AHLetter, ahead, final)
 
 			if more {
 				// Token extends past current data, request more
@@ -172,7 +249,8 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 
 		// https://unicode.org/reports/tr29/#WB7b
 		if current.is(_DoubleQuote) && lastExIgnore.is(_HebrewLetter) {
-			found, more := subsequent(_HebrewLetter, data[pos+w:], atEOF)
+			ahead, final := j.lookahead(data[pos+w:], atEOF)
+			found, more := j.subsequent(_HebrewLetter, ahead, final)
 
 			if more {
 				// Token extends past current data, request more
@@ -207,7 +285,8 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 
 		// https://unicode.org/reports/tr29/#WB12
 		if current.is(_MidNum|_MidNumLetQ) && lastExIgnore.is(_Numeric) {
-			found, more := subsequent(_Numeric, data[pos+w:], atEOF)
+			ahead, final := j.lookahead(data[pos+w:], atEOF)
+			found, more := j.subsequent(_Numeric, ahead, final)
 
 			if more {
 				// Token extends past current data, request more
@@ -252,6 +331,32 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 			}
 		}
 
+		// Joiners: a run of trailing joiners, after a letter or number, and at the end of a word, joins it
+		if j != nil && j.Trailing != nil && lastExIgnore.is(_AHLetter|_Numeric) && runesContain(j.Trailing, decodeRune(data[pos:], w)) {
+			ahead, final := j.lookahead(data[pos+w:], atEOF)
+
+			end := 0
+			for end < len(ahead) {
+				lookup, w := trie.lookup(ahead[end:])
+				if w == 0 || !(lookup.is(_Ignore) || runesContain(j.Trailing, decodeRune(ahead[end:], w))) {
+					break
+				}
+				end += w
+			}
+
+			found, more := j.subsequent(_AHLetter|_Numeric, ahead[end:], final)
+
+			if more {
+				// Token extends past current data, request more
+				return 0, nil, nil
+			}
+
+			if !found {
+				pos += w + end
+				break
+			}
+		}
+
 		// https://unicode.org/reports/tr29/#WB999
 		// If we fall through all the above rules, it's a word break
 		break
@@ -259,3 +364,30 @@ func (j *Joiners) splitFunc(data []byte, atEOF bool) (advance int, token []byte,
 
 	return pos, data[:pos], nil
 }
+
+// decodeRune decodes the rune at the start of data, of width w, as returned
+// by trie.lookup. Because the trie has already determined the width, it is
+// cheaper than utf8.DecodeRune, and small enough to be inlined for ASCII.
+// For a valid rune, the result is the same as utf8.DecodeRune; for invalid
+// UTF-8, it may not be, which is undefined behavior, see the README.
+func decodeRune(data []byte, w int) rune {
+	if w == 1 && data[0] < utf8.RuneSelf {
+		return rune(data[0])
+	}
+	return decodeMulti(data, w)
+}
+
+// decodeMulti is decodeRune for runes of more than one byte
+func decodeMulti(data []byte, w int) rune {
+	b0 := rune(data[0])
+	switch w {
+	case 2:
+		return (b0&0x1F)<<6 | rune(data[1]&0x3F)
+	case 3:
+		return (b0&0x0F)<<12 | rune(data[1]&0x3F)<<6 | rune(data[2]&0x3F)
+	case 4:
+		return (b0&0x07)<<18 | rune(data[1]&0x3F)<<12 | rune(data[2]&0x3F)<<6 | rune(data[3]&0x3F)
+	default:
+		return utf8.RuneError
+	}
+}
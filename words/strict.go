@@ -0,0 +1,24 @@
+package words
+
+// Strict disables all package-specific extensions to word segmentation, such
+// that tokens match the UAX #29 specification exactly. It replaces any split
+// function previously set, which removes all of these extensions:
+//
+//   - [Joiners], including their Trailing joiners, Overrides and Delimiters
+//   - [ANSI], including any Joiners within it
+//   - [Combine] and its Combiners, such as [URLs], [Phones], [Measurements]
+//     and [PunctuationRuns]
+//
+// Other deviations in this module do not affect word boundaries, and are
+// unchanged by Strict. BleveNumeric and BleveIdeographic are separate
+// functions, which categorize tokens but do not change segmentation. The
+// phrases package is a deliberate variation on the spec, and has no strict mode.
+func (seg *Segmenter) Strict() {
+	seg.Split(SplitFunc)
+}
+
+// Strict disables all package-specific extensions to word segmentation, such
+// that tokens match the UAX #29 specification exactly. See [Segmenter.Strict].
+func (sc *Scanner) Strict() {
+	sc.Split(SplitFunc)
+}
@@ -0,0 +1,31 @@
+package words
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/clipperhouse/uax29/iterators"
+)
+
+// NewBufferTail returns a Tail, which is an iterator over text as it is appended to buf.
+// Each call to Next segments only newly-appended text; iterate while Next() is true,
+// and again after appending. Call Flush when done appending, to get the final token.
+// See [iterators.Tail].
+func NewBufferTail(buf *bytes.Buffer) *iterators.Tail {
+	return iterators.NewBufferTail(buf, SplitFunc)
+}
+
+// NewBuilderTail returns a Tail, which is an iterator over text as it is appended to b.
+// Each call to Next segments only newly-appended text; iterate while Next() is true,
+// and again after appending. Call Flush when done appending, to get the final token.
+// See [iterators.Tail].
+func NewBuilderTail(b *strings.Builder) *iterators.Tail {
+	return iterators.NewBuilderTail(b, SplitFunc)
+}
+
+// NewIncremental returns an Incremental, which is a push-style iterator: write chunks
+// of text as they arrive, and iterate while Next() is true, and again after writing.
+// Call Close when done writing, to get the final token. See [iterators.Incremental].
+func NewIncremental() *iterators.Incremental {
+	return iterators.NewIncremental(SplitFunc)
+}
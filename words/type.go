@@ -0,0 +1,133 @@
+package words
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Type classifies a token, such as a word, a number or punctuation, see
+// [TypeOf]. This API is experimental.
+type Type uint8
+
+const (
+	// TypeOther is a token which is none of the below, such as a lone
+	// combining mark, or a control.
+	TypeOther Type = iota
+	// TypeLetter is a word, which may include digits and joiners, as in
+	// "hello", "can't" or "a1".
+	TypeLetter
+	// TypeNumeric is a number, which may include letters and separators, as
+	// in "1,000.5" or "3rd".
+	TypeNumeric
+	// TypeKatakana is a run of Katakana.
+	TypeKatakana
+	// TypeIdeographic is a Han ideograph or Hiragana character, which the spec
+	// segments one per token.
+	TypeIdeographic
+	// TypeEmoji is an emoji, including a ZWJ sequence or a flag.
+	TypeEmoji
+	// TypePunctuation is punctuation, such as "," or "—".
+	TypePunctuation
+	// TypeSymbol is a symbol, such as "+" or "$".
+	TypeSymbol
+	// TypeSpace is horizontal whitespace, such as spaces or a tab.
+	TypeSpace
+	// TypeNewline is a line break, such as "\n" or "\r\n".
+	TypeNewline
+)
+
+var typeNames = [...]string{"Other", "Letter", "Numeric", "Katakana", "Ideographic", "Emoji", "Punctuation", "Symbol", "Space", "Newline"}
+
+func (t Type) String() string {
+	if int(t) < len(typeNames) {
+		return typeNames[t]
+	}
+	return "Unknown"
+}
+
+// TypeOf classifies a token, as returned by a Segmenter or Scanner, by the
+// word break property of its first rune, which is the property by which the
+// spec segmented it. A token with joiners, such as "#hashtag" or "foo-bar",
+// is classified by its first letter or number. Runes without a relevant
+// property, such as punctuation, are classified by Unicode category.
+//
+// It does a single trie lookup, rather than inspecting each rune of the
+// token. Leading Extend and Format characters are skipped. This API is
+// experimental.
+func TypeOf(token []byte) Type {
+	pos := 0
+	for pos < len(token) {
+		current, w := trie.lookup(token[pos:])
+		if w == 0 {
+			// Incomplete rune
+			return TypeOther
+		}
+
+		switch {
+		case current.is(_Ignore):
+			// Extend, Format or ZWJ, look at the next rune
+			pos += w
+			continue
+		case current.is(_AHLetter):
+			return TypeLetter
+		case current.is(_Numeric):
+			return TypeNumeric
+		case current.is(_Katakana):
+			return TypeKatakana
+		case current.is(_BleveIdeographic):
+			// Han and Hiragana; Katakana is handled above
+			return TypeIdeographic
+		case current.is(_ExtendedPictographic | _RegionalIndicator):
+			return TypeEmoji
+		case current.is(_WSegSpace):
+			return TypeSpace
+		case current.is(_CR | _LF | _Newline):
+			return TypeNewline
+		}
+
+		// Joiners, such as "#" or "@", may lead a token of letters or numbers
+		for p := pos + w; p < len(token); {
+			next, w := trie.lookup(token[p:])
+			if next.is(_AHLetter) {
+				return TypeLetter
+			}
+			if next.is(_Numeric) {
+				return TypeNumeric
+			}
+			if w == 0 || next != 0 && !next.is(_Ignore) {
+				break
+			}
+			p += w
+		}
+
+		r, _ := utf8.DecodeRune(token[pos:])
+		switch {
+		case r == utf8.RuneError:
+			// Invalid UTF-8
+			return TypeOther
+		case unicode.IsLetter(r):
+			return TypeLetter
+		case unicode.IsNumber(r):
+			return TypeNumeric
+		case unicode.IsPunct(r):
+			return TypePunctuation
+		case unicode.IsSymbol(r):
+			return TypeSymbol
+		case unicode.IsSpace(r):
+			return TypeSpace
+		}
+		return TypeOther
+	}
+
+	return TypeOther
+}
+
+// Type returns the [Type] of the current token, see [TypeOf].
+func (seg *Segmenter) Type() Type {
+	return TypeOf(seg.Bytes())
+}
+
+// Type returns the [Type] of the current token, see [TypeOf].
+func (sc *Scanner) Type() Type {
+	return TypeOf(sc.Bytes())
+}
@@ -0,0 +1,95 @@
+package words_test
+
+import (
+	"testing"
+
+	"github.com/clipperhouse/uax29/words"
+)
+
+func TestTypeOf(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected words.Type
+	}
+
+	tests := []test{
+		{"hello", words.TypeLetter},
+		{"can't", words.TypeLetter},
+		{"שלום", words.TypeLetter},
+		{"a1", words.TypeLetter},
+		{"e\u0301", words.TypeLetter},
+		{"\u0301a", words.TypeLetter},
+		{"ไทย", words.TypeLetter}, // Thai, which has no word break property
+		{"1,000.5", words.TypeNumeric},
+		{"3rd", words.TypeNumeric},
+		{"٣", words.TypeNumeric},
+		{"カタカナ", words.TypeKatakana},
+		{"東", words.TypeIdeographic},
+		{"ひ", words.TypeIdeographic},
+		{"\U0001F44D\U0001F3FD", words.TypeEmoji},
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467", words.TypeEmoji},
+		{"\U0001F1FA\U0001F1F8", words.TypeEmoji},
+		{",", words.TypePunctuation},
+		{"—", words.TypePunctuation},
+		{"#", words.TypePunctuation},
+		{"+", words.TypeSymbol},
+		{"$", words.TypeSymbol},
+		{" ", words.TypeSpace},
+		{"  ", words.TypeSpace},
+		{"\t", words.TypeSpace},
+		{"\n", words.TypeNewline},
+		{"\r\n", words.TypeNewline},
+		{"\u2028", words.TypeNewline},
+		{"\x00", words.TypeOther},
+		{"\u0301", words.TypeOther},
+		{"", words.TypeOther},
+		{"\xff", words.TypeOther},
+
+		// With joiners
+		{"#winning", words.TypeLetter},
+		{"@ada", words.TypeLetter},
+		{"$5", words.TypeNumeric},
+		{".01", words.TypeNumeric},
+		{"#東京", words.TypePunctuation},
+	}
+
+	for _, test := range tests {
+		got := words.TypeOf([]byte(test.input))
+		if got != test.expected {
+			t.Errorf("%q: expected %s, got %s", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestType(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("Hi, 世界 123 #go 👍\n")
+	expected := []words.Type{
+		words.TypeLetter, words.TypePunctuation, words.TypeSpace, words.TypeIdeographic, words.TypeIdeographic,
+		words.TypeSpace, words.TypeNumeric, words.TypeSpace, words.TypeLetter, words.TypeSpace, words.TypeEmoji, words.TypeNewline,
+	}
+
+	seg := words.NewSegmenter(text)
+	seg.Joiners(&words.Joiners{Leading: []rune("#")})
+
+	var got []words.Type
+	for seg.Next() {
+		got = append(got, seg.Type())
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+
+	if words.Type(255).String() != "Unknown" {
+		t.Error("expected an unknown type to be Unknown")
+	}
+}
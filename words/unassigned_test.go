@@ -0,0 +1,82 @@
+package words_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/clipperhouse/uax29/words"
+)
+
+// unassigned determines if r is unassigned, per package unicode, whose
+// version must be at least that of the tables
+func unassigned(r rune) bool {
+	return !unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z,
+		unicode.Cc, unicode.Cf, unicode.Co, unicode.Cs)
+}
+
+// eachUnassigned calls f with unassigned code points: all of them in the
+// first three planes and in the tags block, and a sample of the rest
+func eachUnassigned(t *testing.T, f func(r rune)) {
+	major, _ := strconv.Atoi(strings.Split(unicode.Version, ".")[0])
+	if major < 15 {
+		t.Skipf("package unicode is version %s, older than the tables", unicode.Version)
+	}
+
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		if r >= 0x30000 && (r < 0xE0000 || r > 0xE0FFF) && r%256 != 0 {
+			continue
+		}
+		if r >= 0xD800 && r <= 0xDFFF || !unassigned(r) {
+			continue
+		}
+		f(r)
+	}
+}
+
+func TestUnassigned(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		input    string
+		expected []string
+		comment  string
+	}
+
+	tests := []test{
+		{"\U00040000\U00040000", []string{"\U00040000", "\U00040000"}, "Other, WB999"},
+		{"\U00040000\u0301", []string{"\U00040000\u0301"}, "Other, WB4"},
+		{"abc\u0378def", []string{"abc", "\u0378", "def"}, "Other, WB999"},
+		{"1\U00040000 2", []string{"1", "\U00040000", " ", "2"}, "Other, WB999"},
+		{"\U0001FAFF\u200D\U0001FAFF", []string{"\U0001FAFF\u200D\U0001FAFF"}, "reserved Extended_Pictographic, WB3c"},
+		{"\U0001FAFF\U0001FAFF", []string{"\U0001FAFF", "\U0001FAFF"}, "reserved Extended_Pictographic, WB999"},
+	}
+
+	for _, test := range tests {
+		var got []string
+		seg := words.NewSegmenter([]byte(test.input))
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: for %+q, expected %+q, got %+q", test.comment, test.input, test.expected, got)
+		}
+	}
+
+	seg := words.NewSegmenter(nil)
+	eachUnassigned(t, func(r rune) {
+		s := string(r)
+		expected := []string{"a", s + "\u0301", "a"}
+
+		seg.SetText([]byte("a" + s + "\u0301a"))
+		var got []string
+		for seg.Next() {
+			got = append(got, seg.Text())
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("for %U, expected %+q, got %+q", r, expected, got)
+		}
+	})
+}